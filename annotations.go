@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Annotation attaches context ("started new job") to a date or date range
+type Annotation struct {
+	ID        string `json:"id"`
+	Date      string `json:"date"`              // Start date
+	EndDate   string `json:"endDate,omitempty"` // Inclusive end date for ranges
+	Text      string `json:"text"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// lastDate returns the final date the annotation covers
+func (n Annotation) lastDate() string {
+	if n.EndDate == "" {
+		return n.Date
+	}
+	return n.EndDate
+}
+
+// AddAnnotation attaches a note to a single date
+func (a *App) AddAnnotation(date string, text string) (Annotation, error) {
+	return a.AddAnnotationRange(date, "", text)
+}
+
+// AddAnnotationRange attaches a note to a date range (end may be empty for a single day)
+func (a *App) AddAnnotationRange(start string, end string, text string) (Annotation, error) {
+	if _, err := time.Parse("2006-01-02", start); err != nil {
+		return Annotation{}, errors.New("invalid start date")
+	}
+	if end != "" {
+		if _, err := time.Parse("2006-01-02", end); err != nil {
+			return Annotation{}, errors.New("invalid end date")
+		}
+		if end < start {
+			start, end = end, start
+		}
+		if end == start {
+			end = ""
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	note := Annotation{
+		ID:        uuid.New().String(),
+		Date:      start,
+		EndDate:   end,
+		Text:      text,
+		CreatedAt: time.Now().Format("2006-01-02"),
+	}
+
+	a.data.Annotations = append(a.data.Annotations, note)
+	a.audit("AddAnnotation", start, "", nil, text)
+	return note, a.saveDataLocked()
+}
+
+// DeleteAnnotation removes an annotation
+func (a *App) DeleteAnnotation(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, note := range a.data.Annotations {
+		if note.ID == id {
+			a.data.Annotations = append(a.data.Annotations[:i], a.data.Annotations[i+1:]...)
+			a.audit("DeleteAnnotation", note.Date, "", note.Text, nil)
+			return a.saveDataLocked()
+		}
+	}
+
+	return nil
+}
+
+// GetAnnotations returns annotations overlapping the inclusive range.
+// Empty bounds are open-ended.
+func (a *App) GetAnnotations(from string, to string) []Annotation {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.annotationsInRangeLocked(from, to)
+}
+
+// annotationsInRangeLocked returns annotations overlapping a range sorted by
+// date (must hold lock)
+func (a *App) annotationsInRangeLocked(from string, to string) []Annotation {
+	result := []Annotation{}
+	for _, note := range a.data.Annotations {
+		if from != "" && note.lastDate() < from {
+			continue
+		}
+		if to != "" && note.Date > to {
+			continue
+		}
+		result = append(result, note)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Date < result[j].Date
+	})
+
+	return result
+}
@@ -0,0 +1,146 @@
+package main
+
+// apiVersion is the version of the methods bound to the frontend. Bump it
+// whenever a bound method changes shape or is deprecated; new features are
+// announced by adding to apiCapabilities instead.
+//
+// Version 3: LoadDay returns DayValues instead of a bare map, weekly-quota
+// progress moved from LoadWeekProgress into LoadWeekDetailed, ImportCSV's
+// report lists row errors apart from warnings, ScoringConfig is weighted by
+// priority, and SaveDay rejects changes to computed tasks.
+const apiVersion = 3
+
+// apiCapabilities lists optional feature sets available in this version, so
+// a frontend can check for a feature instead of comparing versions
+var apiCapabilities = []string{
+	"audit",
+	"changelog",
+	"timers",
+	"quickCheck",
+	"secondaryBackup",
+	"subitems",
+	"archive",
+	"annotations",
+	"notifications",
+	"dashboard",
+	"pause",
+	"vacations",
+	"autoTasks",
+	"records",
+	"weeklyQuota",
+	"monthlyCadence",
+	"groups",
+	"cloneTask",
+	"weekDetail",
+	"gapSummary",
+	"purgeTask",
+	"reminders",
+	"exportFormats",
+	"prefill",
+	"valueTasks",
+	"quarantine",
+	"skipped",
+	"scaleTasks",
+	"compareWeeks",
+	"focusReport",
+	"undelete",
+	"bulkAdd",
+	"scoringConfig",
+	"presets",
+	"futureNotes",
+	"exportSchedule",
+	"mergeTasks",
+	"taper",
+	"priority",
+	"taskDescription",
+	"fileDrop",
+	"dataWipe",
+	"dependencies",
+	"weekdayWeekend",
+	"query",
+	"streakGoals",
+	"streakCertificate",
+	"sqliteStorage",
+	"rotatingBackups",
+	"weeklyReview",
+	"retention",
+	"fileBackup",
+	"undo",
+	"replay",
+	"specialDays",
+	"dataTransfer",
+	"csvImport",
+	"encryption",
+	"idempotencyKeys",
+	"tiers",
+	"rolloverHour",
+	"schemaVersion",
+	"externalChanges",
+	"customCharts",
+	"valueStamps",
+	"journal",
+	"integrityCheck",
+	"saveStatus",
+	"valueDistribution",
+	"weekSummary",
+	"webdavSync",
+	"cloudSync",
+	"demoMode",
+	"dayProfiles",
+	"gitHistory",
+	"loopExport",
+	"trash",
+	"habitImport",
+	"lifetimeGoals",
+	"markdownExport",
+	"simulateReport",
+	"coalescedEvents",
+	"icsCompletions",
+	"yearArchives",
+	"compositeTasks",
+	"dataCompression",
+	"onboarding",
+	"skipDay",
+	"dataLock",
+	"pastDayLock",
+	"heatmap",
+	"changeHistory",
+	"planReminder",
+	"csvPreview",
+}
+
+// DeprecatedMethod describes a bound method kept for compatibility
+type DeprecatedMethod struct {
+	Method      string `json:"method"`
+	Since       int    `json:"since"`       // API version that deprecated it
+	Replacement string `json:"replacement"` // Method to use instead
+	Note        string `json:"note,omitempty"`
+}
+
+// deprecatedMethods lists bound methods that still work but shouldn't be
+// used by new code
+var deprecatedMethods = []DeprecatedMethod{
+	{
+		Method:      "SaveHTMLExport",
+		Since:       2,
+		Replacement: "Export",
+		Note:        `Export("html", scope, {"filename": ..., "content": ...}) saves pre-rendered HTML the same way.`,
+	},
+}
+
+// APIInfo describes the bound API for capability detection at runtime
+type APIInfo struct {
+	Version      int                `json:"version"`
+	Capabilities []string           `json:"capabilities"`
+	Deprecated   []DeprecatedMethod `json:"deprecated"`
+}
+
+// GetAPIVersion returns the API version, available capabilities and
+// deprecated methods
+func (a *App) GetAPIVersion() APIInfo {
+	return APIInfo{
+		Version:      apiVersion,
+		Capabilities: append([]string{}, apiCapabilities...),
+		Deprecated:   append([]DeprecatedMethod{}, deprecatedMethods...),
+	}
+}
@@ -2,12 +2,16 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
@@ -15,26 +19,141 @@ import (
 
 // TaskTemplate represents a task that can be checked off daily
 type TaskTemplate struct {
-	ID        string  `json:"id"`
-	Name      string  `json:"name"`
-	Type      string  `json:"type,omitempty"` // "binary" (default) or "count"
-	Unit      string  `json:"unit,omitempty"` // For count tasks: "min", "hrs", "reps", etc.
-	Order     int     `json:"order"`
-	CreatedAt string  `json:"createdAt"`
-	DeletedAt *string `json:"deletedAt,omitempty"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type,omitempty"` // "binary" (default), "count", "negative", "duration", "value" or "scale"
+	Unit      string    `json:"unit,omitempty"` // For count tasks: "min", "hrs", "reps", etc.
+	Order     int       `json:"order"`
+	CreatedAt string    `json:"createdAt"`
+	DeletedAt *string   `json:"deletedAt,omitempty"`
+	Subitems  []Subitem `json:"subitems,omitempty"` // Optional checklist steps
+
+	Archived   bool   `json:"archived,omitempty"`
+	ArchivedAt string `json:"archivedAt,omitempty"` // Date the task was archived
+
+	Paused []DateRange `json:"paused,omitempty"` // Ranges where the task doesn't apply
+
+	Source *AutoSource `json:"source,omitempty"` // Set for computed, read-only tasks
+
+	EndsAt string `json:"endsAt,omitempty"` // Last date the task applies (inclusive)
+
+	TimesPerWeek int `json:"timesPerWeek,omitempty"` // Weekly quota; 0 means every day
+
+	Group string `json:"group,omitempty"` // TaskGroup ID; empty for ungrouped
+
+	ReminderTime string `json:"reminderTime,omitempty"` // "15:04"; remind if still incomplete
+
+	Target  int    `json:"target,omitempty"`  // Daily goal for count/duration tasks
+	Prefill string `json:"prefill,omitempty"` // New-day default: "zero", "yesterday" or "target"
+
+	DayOfMonth int `json:"dayOfMonth,omitempty"` // Monthly cadence: the task applies only on this day
+
+	ScaleMax int `json:"scaleMax,omitempty"` // Top rating for scale tasks (1..ScaleMax)
+
+	Taper *Taper `json:"taper,omitempty"` // Decreasing weekly target for quit habits
+
+	Priority int `json:"priority,omitempty"` // PriorityNone..PriorityHigh
+
+	Description string `json:"description,omitempty"` // Why the habit exists and what "done" means
+
+	Requires string `json:"requires,omitempty"` // Prerequisite task ID, done first
+
+	OnDays string `json:"onDays,omitempty"` // "weekdays" or "weekends"; "" for every day
+
+	StreakGoal int `json:"streakGoal,omitempty"` // Consecutive days aimed for
+
+	Tiers []int `json:"tiers,omitempty"` // Bronze/silver/gold thresholds for count and duration tasks
+
+	Profiles []string `json:"profiles,omitempty"` // Day profile IDs the task applies on; empty for every day
+
+	Composite *Composite `json:"composite,omitempty"` // Set for tasks derived from other tasks, read-only
+
+	ReadOnly bool `json:"readOnly,omitempty"` // Set on tasks returned to the UI whose value is computed; never saved
 }
 
 // PlannerData is the root data structure for storage
 type PlannerData struct {
+	Version       int                 `json:"version,omitempty"` // Format version; see migrations
 	Templates     []TaskTemplate      `json:"templates"`
 	Days          map[string]DayTasks `json:"days"`
-	ExportPath    string              `json:"exportPath,omitempty"`
+	ExportPath    string              `json:"exportPath,omitempty"`    // Deprecated: moved to LocalSettings
 	ExportHistory map[string]string   `json:"exportHistory,omitempty"` // weekStart -> exportedDate
+
+	SeenChangesVersion string          `json:"seenChangesVersion,omitempty"` // Last changelog version shown
+	OptIns             map[string]bool `json:"optIns,omitempty"`             // feature -> enabled
+
+	Timers map[string]string `json:"timers,omitempty"` // taskID -> running timer start (RFC3339)
+
+	SecondaryBackup *SecondaryBackupSettings `json:"secondaryBackup,omitempty"` // Deprecated: moved to LocalSettings
+
+	SubitemDays map[string]map[string][]string `json:"subitemDays,omitempty"` // date -> taskID -> done subitem IDs
+
+	Annotations []Annotation `json:"annotations,omitempty"`
+
+	Notifications []Notification `json:"notifications,omitempty"`
+
+	Vacations []DateRange `json:"vacations,omitempty"` // Whole days excluded from stats
+
+	Signals map[string]map[string]float64 `json:"signals,omitempty"` // date -> signal -> imported reading
+
+	Records map[string]PersonalRecord `json:"records,omitempty"` // taskID -> personal bests
+
+	Groups []TaskGroup `json:"groups,omitempty"`
+
+	GapHandledThrough string `json:"gapHandledThrough,omitempty"` // Last date of an untracked gap already resolved
+
+	RemindersSent map[string]string `json:"remindersSent,omitempty"` // taskID -> date the reminder last fired
+
+	Measurements map[string]map[string]float64 `json:"measurements,omitempty"` // date -> taskID -> value task reading
+
+	Quarantine []QuarantinedEntry `json:"quarantine,omitempty"` // Unreadable saved values, kept for round-tripping
+
+	Skipped map[string]map[string]string `json:"skipped,omitempty"` // date -> taskID -> reason
+
+	SkippedDays map[string]string `json:"skippedDays,omitempty"` // date -> reason, for days skipped as a whole
+
+	FocusSessions []FocusSession `json:"focusSessions,omitempty"`
+
+	Scoring *ScoringConfig `json:"scoring,omitempty"` // Only in data from older versions; moved to local settings
+
+	FutureNotes []FutureNote `json:"futureNotes,omitempty"`
+
+	SortMode string `json:"sortMode,omitempty"` // "manual" (default) or "priority"
+
+	Reviews []WeeklyReview `json:"reviews,omitempty"`
+
+	Retention *RetentionPolicy `json:"retention,omitempty"` // nil keeps all detail
+
+	SpecialDays []SpecialDay `json:"specialDays,omitempty"`
+
+	RolloverHour int `json:"rolloverHour,omitempty"` // Check-offs before this hour count for the previous day
+
+	EditWindowDays int `json:"editWindowDays,omitempty"` // Days older than this are read-only; 0 leaves every day editable
+
+	Charts []ChartConfig `json:"charts,omitempty"` // Custom charts built in the frontend
+
+	Stamps map[string]map[string]ValueStamp `json:"stamps,omitempty"` // date -> taskID -> last write, for merging devices
+
+	WeekSummarySent string `json:"weekSummarySent,omitempty"` // Start of the last week summarized
+
+	PlanReminderTime string `json:"planReminderTime,omitempty"` // "15:04" on a week's last day; remind if next week has no intentions
+	PlanReminderSent string `json:"planReminderSent,omitempty"` // Start of the last week reminded to plan
+
+	DayProfiles  []DayProfile      `json:"dayProfiles,omitempty"`
+	ProfileDates map[string]string `json:"profileDates,omitempty"` // date -> day profile ID, overriding weekdays
+
+	LifetimeGoals []LifetimeGoal `json:"lifetimeGoals,omitempty"`
+
+	ArchivedYears []int `json:"archivedYears,omitempty"` // Years whose days are in days-YYYY.json
 }
 
 // DayTasks maps task IDs to numeric value.
 // - binary habits: 0/1
 // - count habits: 0..N
+// - negative habits: 0 (abstained) or >0 (slipped)
+// - duration habits: minutes spent
+// - value tasks: 1 when measured; the reading is kept in Measurements
+// - scale tasks: 1..ScaleMax rating, 0 when not rated
 type DayTasks map[string]int
 
 // App struct holds the application state
@@ -42,200 +161,201 @@ type App struct {
 	ctx      context.Context
 	dataPath string
 	data     PlannerData
-	mu       sync.RWMutex
+
+	settingsPath string
+	settings     LocalSettings
+
+	mu      sync.RWMutex
+	actor   string
+	auditMu sync.Mutex
+
+	resetToken string // Issued by a FactoryReset preview
+
+	store          dataStore
+	lastAutoBackup string       // Date of the newest automatic backup
+	review         *ReviewState // Weekly review in progress
+
+	undoStack   []undoStep // Newest last
+	redoStack   []undoStep
+	pendingUndo *pendingUndo // Change being recorded until the next save
+
+	replayPath string     // Recorded dataset given with --replay
+	replayDir  string     // Throwaway data directory while replaying
+	demo       *demoState // Real state put aside while demo mode is on
+
+	dataKey        []byte // Encryption key while unlocked; nil when not encrypted
+	dataSalt       []byte
+	dataPassphrase string // Passphrase while unlocked, for files sealed with another salt
+	locked         bool   // Encrypted data not yet unlocked
+
+	idempotencyMu   sync.Mutex
+	idempotencyKeys map[string]idempotentResult
+
+	dataSum        string        // Checksum of data.json as last read or written
+	journalMetaSum string        // Checksum of the non-day data last journaled
+	dataDirChanged chan struct{} // Tells the data file watcher to move
+
+	saveStatus       SaveStatus
+	saveStatusSent   time.Time // When the last save status event went out
+	saveStatusQueued bool      // A trailing save status event is scheduled
+
+	syncMu        sync.Mutex    // Held for the whole of a Sync
+	syncing       bool          // A Sync is under way
+	syncFailures  int           // Failed syncs in a row
+	nextSync      time.Time     // When the background sync runs next
+	syncRequested chan struct{} // Asks the background sync to run now
+	cloudSession  *oauthSession // Kept between syncs with a cloud provider
+
+	historyQueue  chan historyCommit // Saves waiting to be committed to the history repository
+	historyMu     sync.Mutex         // Held while a commit or reset touches the history repository
+	historySealed bool               // Encryption is on, so plain saves aren't committed; guarded by historyMu
+
+	tempFiles   sync.Map     // Temporary files being written, which cleanup leaves alone
+	tempCleanup *TempCleanup // Last cleanup of leftover temporary files
+
+	changes pendingChanges // Data changed events waiting to go out
+
+	archiveMu    sync.Mutex           // Guards yearArchives, which readers fill in
+	yearArchives map[int]*yearArchive // Archived years read so far
+
+	dataLock *dataLock // Held on the data directory so other processes don't overwrite saves
+	readOnly bool      // Serving reports: takes no lock and never writes the data
+
+	unlockedDays map[string]bool // Past days unlocked for editing until the app quits
+
+	savedDays    map[string]DayTasks // Day values as of the last save, for the change history
+	pendingAudit []AuditEntry        // Audited changes waiting for the next save; guarded by auditMu
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{
+	app := &App{
 		data: PlannerData{
+			Version:       schemaVersion,
 			Templates:     []TaskTemplate{},
 			Days:          make(map[string]DayTasks),
 			ExportHistory: make(map[string]string),
 		},
 	}
+	app.store = &jsonStore{app: app}
+	app.dataDirChanged = make(chan struct{}, 1)
+	app.syncRequested = make(chan struct{}, 1)
+	app.historyQueue = make(chan historyCommit, historyQueueSize)
+	return app
 }
 
 // startup is called when the app starts
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 
-	// Set up data directory in user's home
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		homeDir = "."
-	}
-
-	dataDir := filepath.Join(homeDir, ".plan")
-	if err := os.MkdirAll(dataDir, 0755); err != nil {
-		println("Error creating data directory:", err.Error())
+	if a.replayPath != "" {
+		if err := a.startReplay(); err != nil {
+			println("Error starting replay:", err.Error())
+			runtime.Quit(ctx)
+		}
+		return
 	}
 
-	a.dataPath = filepath.Join(dataDir, "data.json")
+	a.openStorage()
 
-	// Load existing data
+	// Load existing data, upgrading older formats
 	a.loadData()
 
-	// Migrate old format if needed
-	a.migrateOldData()
-
 	// Create default tasks if none exist
-	if len(a.data.Templates) == 0 {
+	if len(a.data.Templates) == 0 && !a.IsLocked() {
 		a.createDefaultTasks()
 	}
-}
 
-// loadData loads planner data from the JSON file
-func (a *App) loadData() {
-	a.mu.Lock()
-	defer a.mu.Unlock()
+	a.refreshMenu()
+	a.restoreWindowState()
+	runtime.OnFileDrop(ctx, a.handleFileDrop)
+
+	go a.runSecondaryBackups()
+	go a.runReminders()
+	go a.runScheduledExports()
+	go a.runMaintenance()
+	go a.watchDataFile()
+	go a.runSync()
+	go a.runGitHistory()
+	go a.cleanupTempFiles()
+}
 
-	data, err := os.ReadFile(a.dataPath)
+// openStorage finds the settings and data directories, loads local
+// settings and opens the configured storage backend
+func (a *App) openStorage() {
+	// Set up data directory in user's home
+	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return
+		homeDir = "."
 	}
 
-	// Detect format safely.
-	// Unmarshalling old-format data into PlannerData succeeds with empty fields,
-	// so we look for known top-level keys before choosing the new format.
-	var root map[string]json.RawMessage
-	if err := json.Unmarshal(data, &root); err == nil {
-		_, hasTemplates := root["templates"]
-		_, hasDays := root["days"]
-		_, hasExportPath := root["exportPath"]
-		_, hasExportHistory := root["exportHistory"]
-
-		if hasTemplates || hasDays || hasExportPath || hasExportHistory {
-			// We intentionally parse Days as a loose map to support older saved data
-			// where day values were booleans.
-			type plannerDataWire struct {
-				Templates     []TaskTemplate              `json:"templates"`
-				Days          map[string]map[string]any   `json:"days"`
-				ExportPath    string                      `json:"exportPath,omitempty"`
-				ExportHistory map[string]string           `json:"exportHistory,omitempty"`
-			}
+	settingsDir := filepath.Join(homeDir, ".plan")
+	if err := os.MkdirAll(settingsDir, 0755); err != nil {
+		println("Error creating data directory:", err.Error())
+	}
 
-			var wire plannerDataWire
-			if err := json.Unmarshal(data, &wire); err == nil {
-				convertedDays := make(map[string]DayTasks)
-				for date, taskMap := range wire.Days {
-					dayTasks := make(DayTasks)
-					for id, raw := range taskMap {
-						switch v := raw.(type) {
-						case bool:
-							if v {
-								dayTasks[id] = 1
-							} else {
-								dayTasks[id] = 0
-							}
-						case float64:
-							dayTasks[id] = int(v)
-						case int:
-							dayTasks[id] = v
-						default:
-							// Ignore unsupported values
-						}
-					}
-					convertedDays[date] = dayTasks
-				}
+	// Machine-specific settings always stay local; data may live elsewhere
+	a.settingsPath = filepath.Join(settingsDir, "settings.json")
+	a.loadSettings()
 
-				// Default type for older templates.
-				for i := range wire.Templates {
-					if wire.Templates[i].Type == "" {
-						wire.Templates[i].Type = "binary"
-					}
-				}
+	dataDir := settingsDir
+	if a.settings.DataDir != "" {
+		if err := os.MkdirAll(a.settings.DataDir, 0755); err == nil {
+			dataDir = a.settings.DataDir
+		} else {
+			println("Error opening data directory:", err.Error())
+		}
+	}
 
-				a.data = PlannerData{
-					Templates:     wire.Templates,
-					Days:          convertedDays,
-					ExportPath:    wire.ExportPath,
-					ExportHistory: wire.ExportHistory,
-				}
-				if a.data.Days == nil {
-					a.data.Days = make(map[string]DayTasks)
-				}
-				if a.data.ExportHistory == nil {
-					a.data.ExportHistory = make(map[string]string)
-				}
-				return
-			}
+	a.dataPath = a.dataFileIn(dataDir)
+	a.actor = auditActor()
+
+	if a.settings.Storage == storageSQLite {
+		if store, err := openSQLiteStore(filepath.Join(dataDir, sqliteFileName)); err == nil {
+			a.store = store
+			a.dataPath = store.path()
+		} else {
+			println("Error opening database, using data.json:", err.Error())
 		}
 	}
 
-	// Try old format (map[string][]bool)
-	var oldFormat map[string][]bool
-	if err := json.Unmarshal(data, &oldFormat); err == nil {
-		a.data.Days = make(map[string]DayTasks)
-		// Will be migrated in migrateOldData
+	if a.readOnly {
+		return
+	}
+	if err := a.holdDataLockLocked(); err != nil {
+		println("Error locking data directory:", err.Error())
 	}
 }
 
-// migrateOldData converts old format to new format
-func (a *App) migrateOldData() {
+// loadData loads planner data from the JSON file
+func (a *App) loadData() {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	// Check if we need to read old format
-	data, err := os.ReadFile(a.dataPath)
-	if err != nil {
+	decoded, quarantined, err := a.store.load()
+	if errors.Is(err, errDataLocked) {
+		// Encrypted; waits for Unlock
+		a.locked = true
 		return
 	}
-
-	var oldFormat map[string][]bool
-	if err := json.Unmarshal(data, &oldFormat); err != nil {
+	if err != nil {
 		return
 	}
 
-	// Skip if no old data or already migrated
-	if len(oldFormat) == 0 {
+	a.data = decoded
+	a.forgetYearArchives()
+	if a.readOnly {
+		// Migrating, recovering and quarantining are left to the app,
+		// which holds the lock
+		a.rememberSavedDaysLocked()
 		return
 	}
-
-	// Check if this is actually old format (has array values)
-	hasOldData := false
-	for _, v := range oldFormat {
-		if len(v) > 0 {
-			hasOldData = true
-			break
-		}
+	if len(quarantined) > 0 {
+		a.quarantineLocked(quarantined)
 	}
-
-	if !hasOldData {
-		return
-	}
-
-	// Create default templates for migration
-	today := time.Now().Format("2006-01-02")
-	defaultTasks := []TaskTemplate{
-		{ID: "task-1", Name: "Task 1", Type: "binary", Order: 0, CreatedAt: today},
-		{ID: "task-2", Name: "Task 2", Type: "binary", Order: 1, CreatedAt: today},
-		{ID: "task-3", Name: "Task 3", Type: "binary", Order: 2, CreatedAt: today},
-		{ID: "task-4", Name: "Task 4", Type: "binary", Order: 3, CreatedAt: today},
-	}
-
-	// Convert old data
-	newDays := make(map[string]DayTasks)
-	for date, tasks := range oldFormat {
-		dayTasks := make(DayTasks)
-		for i, completed := range tasks {
-			if i < len(defaultTasks) {
-				if completed {
-					dayTasks[defaultTasks[i].ID] = 1
-				} else {
-					dayTasks[defaultTasks[i].ID] = 0
-				}
-			}
-		}
-		newDays[date] = dayTasks
-	}
-
-	a.data = PlannerData{
-		Templates: defaultTasks,
-		Days:      newDays,
-	}
-
-	a.saveDataLocked()
+	a.migrateDataLocked()
+	a.recoverJournalLocked()
+	a.rememberSavedDaysLocked()
 }
 
 // createDefaultTasks creates initial default tasks
@@ -243,14 +363,16 @@ func (a *App) createDefaultTasks() {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	today := time.Now().Format("2006-01-02")
-	a.data.Templates = []TaskTemplate{
-		{ID: uuid.New().String(), Name: "Morning Routine", Type: "binary", Order: 0, CreatedAt: today},
-		{ID: uuid.New().String(), Name: "Deep Work", Type: "binary", Order: 1, CreatedAt: today},
-		{ID: uuid.New().String(), Name: "Exercise", Type: "binary", Order: 2, CreatedAt: today},
-		{ID: uuid.New().String(), Name: "Evening Review", Type: "binary", Order: 3, CreatedAt: today},
-	}
+	a.data.Templates = []TaskTemplate{}
+	a.applyPresetLocked(defaultPresetID)
+
+	// Fresh installs have nothing new to catch up on
+	a.data.SeenChangesVersion = latestChangeVersion()
 
+	a.startOnboardingLocked()
+	if err := a.saveSettingsLocked(); err != nil {
+		println("Error saving settings:", err.Error())
+	}
 	a.saveDataLocked()
 }
 
@@ -261,13 +383,49 @@ func (a *App) saveData() error {
 	return a.saveDataLocked()
 }
 
-// saveDataLocked persists data (must be called with lock held)
+// saveDataLocked persists data, taking the day's automatic backup first
+// (must be called with lock held)
 func (a *App) saveDataLocked() error {
-	data, err := json.MarshalIndent(a.data, "", "  ")
-	if err != nil {
+	// Changes are only audited once they're saved
+	audited := a.takeAudit()
+	a.finishUndoStepLocked()
+	if a.locked {
+		a.saveFinishedLocked(errDataLocked)
+		return errDataLocked
+	}
+	if a.data.Version > schemaVersion {
+		a.saveFinishedLocked(errNewerData)
+		return errNewerData
+	}
+	if a.readOnly {
+		a.saveFinishedLocked(errReadOnly)
+		return errReadOnly
+	}
+	if err := a.holdDataLockLocked(); err != nil {
+		a.saveFinishedLocked(err)
+		return err
+	}
+	a.recomputeCompositesLocked()
+	changes := a.stampDaysLocked()
+	a.saveStartedLocked(len(changes))
+	if err := a.journalLocked(changes); err != nil {
+		a.saveFinishedLocked(err)
 		return err
 	}
-	return a.atomicWriteFile(a.dataPath, data)
+	a.autoBackupLocked()
+	if err := a.store.save(&a.data); err != nil {
+		a.saveFinishedLocked(err)
+		return err
+	}
+	a.clearJournalLocked()
+	a.saveFinishedLocked(nil)
+	a.recordChangesLocked(auditCause(audited))
+	a.recordHistoryLocked(changes)
+	a.writeAudit(audited)
+
+	// Rebuild the menu once the lock is released
+	go a.refreshMenu()
+	return nil
 }
 
 // atomicWriteFile writes data to a temporary file first, then renames it
@@ -279,6 +437,8 @@ func (a *App) atomicWriteFile(filename string, data []byte) error {
 		return err
 	}
 	tmpPath := tmpFile.Name()
+	a.tempFiles.Store(tmpPath, true)
+	defer a.tempFiles.Delete(tmpPath)
 
 	// Write data
 	if _, err := tmpFile.Write(data); err != nil {
@@ -320,7 +480,7 @@ func (a *App) atomicWriteFile(filename string, data []byte) error {
 	return renameErr
 }
 
-// GetTaskTemplates returns all active task templates
+// GetTaskTemplates returns all active task templates, grouped and ordered within groups
 func (a *App) GetTaskTemplates() []TaskTemplate {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -330,40 +490,41 @@ func (a *App) GetTaskTemplates() []TaskTemplate {
 		if t.Type == "" {
 			t.Type = "binary"
 		}
-		if t.DeletedAt == nil {
+		t.ReadOnly = isAutoTask(t)
+		if t.DeletedAt == nil && !t.Archived {
 			active = append(active, t)
 		}
 	}
 
-	sort.Slice(active, func(i, j int) bool {
-		return active[i].Order < active[j].Order
-	})
+	a.sortTasksLocked(active)
 
 	return active
 }
 
+// findTemplateLocked looks up a template by ID (must hold lock)
+func (a *App) findTemplateLocked(id string) (TaskTemplate, bool) {
+	for _, t := range a.data.Templates {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return TaskTemplate{}, false
+}
+
 // GetTasksForDate returns tasks valid for a specific date
 func (a *App) GetTasksForDate(date string) []TaskTemplate {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
-	var tasks []TaskTemplate
-	for _, t := range a.data.Templates {
-		if t.Type == "" {
-			t.Type = "binary"
-		}
-		// Include if created on or before this date
-		if t.CreatedAt <= date {
-			// Exclude if deleted before this date
-			if t.DeletedAt == nil || *t.DeletedAt > date {
-				tasks = append(tasks, t)
-			}
+	tasks := a.getTasksForDateLocked(date)
+	for i := range tasks {
+		if tasks[i].Type == "" {
+			tasks[i].Type = "binary"
 		}
+		tasks[i].ReadOnly = isAutoTask(tasks[i])
 	}
 
-	sort.Slice(tasks, func(i, j int) bool {
-		return tasks[i].Order < tasks[j].Order
-	})
+	a.sortTasksLocked(tasks)
 
 	return tasks
 }
@@ -373,10 +534,16 @@ func (a *App) AddTask(name string, taskType string, unit string) (TaskTemplate,
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	if taskType == "" {
-		taskType = "binary"
-	}
-	if taskType != "binary" && taskType != "count" {
+	task := a.addTaskLocked(name, taskType, unit)
+	a.audit("AddTask", "", task.ID, nil, task.Name)
+	a.saveDataLocked()
+
+	return task, nil
+}
+
+// addTaskLocked appends a new task after all others (must hold lock; caller saves)
+func (a *App) addTaskLocked(name string, taskType string, unit string) TaskTemplate {
+	if !isValidTaskType(taskType) {
 		taskType = "binary"
 	}
 
@@ -396,9 +563,78 @@ func (a *App) AddTask(name string, taskType string, unit string) (TaskTemplate,
 		Order:     maxOrder + 1,
 		CreatedAt: time.Now().Format("2006-01-02"),
 	}
+	if isScaleTask(task) {
+		task.ScaleMax = defaultScaleMax
+	}
 
 	a.data.Templates = append(a.data.Templates, task)
-	a.saveDataLocked()
+	return task
+}
+
+// CloneTask duplicates a task's settings (type, unit, checklist, schedule,
+// group, auto source, composite) as a new task named "<name> copy". History is not copied.
+func (a *App) CloneTask(id string) (TaskTemplate, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	source, ok := a.findTemplateLocked(id)
+	if !ok {
+		return TaskTemplate{}, errors.New("task not found")
+	}
+
+	// Place the copy last within the source task's group
+	maxOrder := -1
+	for _, t := range a.data.Templates {
+		if t.Group == source.Group && t.Order > maxOrder {
+			maxOrder = t.Order
+		}
+	}
+
+	task := TaskTemplate{
+		ID:           uuid.New().String(),
+		Name:         source.Name + " copy",
+		Type:         source.Type,
+		Unit:         source.Unit,
+		Order:        maxOrder + 1,
+		CreatedAt:    time.Now().Format("2006-01-02"),
+		EndsAt:       source.EndsAt,
+		TimesPerWeek: source.TimesPerWeek,
+		Group:        source.Group,
+		ReminderTime: source.ReminderTime,
+		ScaleMax:     source.ScaleMax,
+		Target:       source.Target,
+		Prefill:      source.Prefill,
+		DayOfMonth:   source.DayOfMonth,
+		OnDays:       source.OnDays,
+		StreakGoal:   source.StreakGoal,
+		Tiers:        append([]int(nil), source.Tiers...),
+		Profiles:     append([]string(nil), source.Profiles...),
+		Priority:     source.Priority,
+		Description:  source.Description,
+	}
+	for _, s := range source.Subitems {
+		task.Subitems = append(task.Subitems, Subitem{ID: uuid.New().String(), Name: s.Name})
+	}
+	if source.Source != nil {
+		autoSource := *source.Source
+		task.Source = &autoSource
+	}
+	if source.Taper != nil {
+		taper := *source.Taper
+		task.Taper = &taper
+	}
+	if source.Composite != nil {
+		task.Composite = &Composite{Op: source.Composite.Op, Parts: append([]string(nil), source.Composite.Parts...)}
+	}
+
+	a.data.Templates = append(a.data.Templates, task)
+	if isAutoTask(task) {
+		a.recomputeAutoTasksLocked([]string{task.CreatedAt})
+	}
+	a.audit("CloneTask", "", task.ID, id, task.Name)
+	if err := a.saveDataLocked(); err != nil {
+		return TaskTemplate{}, err
+	}
 
 	return task, nil
 }
@@ -411,13 +647,18 @@ func (a *App) SetTaskType(id string, taskType string) error {
 	if taskType == "" {
 		taskType = "binary"
 	}
-	if taskType != "binary" && taskType != "count" {
+	if !isValidTaskType(taskType) {
 		return nil
 	}
 
 	for i, t := range a.data.Templates {
 		if t.ID == id {
 			a.data.Templates[i].Type = taskType
+			if taskType == "scale" && t.ScaleMax == 0 {
+				a.data.Templates[i].ScaleMax = defaultScaleMax
+			}
+			a.convertTaskValuesLocked(id, taskTypeOf(t), taskType)
+			a.audit("SetTaskType", "", id, t.Type, taskType)
 			return a.saveDataLocked()
 		}
 	}
@@ -425,6 +666,26 @@ func (a *App) SetTaskType(id string, taskType string) error {
 	return nil
 }
 
+// SetTaskTarget sets the daily goal for a count or duration task (0 clears it)
+func (a *App) SetTaskTarget(id string, target int) error {
+	if target < 0 {
+		return errors.New("target must not be negative")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			a.data.Templates[i].Target = target
+			a.audit("SetTaskTarget", "", id, t.Target, target)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("task not found")
+}
+
 // UpdateTask renames a task
 func (a *App) UpdateTask(id, name string) error {
 	a.mu.Lock()
@@ -433,6 +694,54 @@ func (a *App) UpdateTask(id, name string) error {
 	for i, t := range a.data.Templates {
 		if t.ID == id {
 			a.data.Templates[i].Name = name
+			a.audit("UpdateTask", "", id, t.Name, name)
+			return a.saveDataLocked()
+		}
+	}
+
+	return nil
+}
+
+// maxDescriptionLength caps a task description's length in characters
+const maxDescriptionLength = 2000
+
+// UpdateTaskDescription sets a task's description ("" clears it)
+func (a *App) UpdateTaskDescription(id string, description string) error {
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return errors.New("description is too long")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			a.data.Templates[i].Description = description
+			a.audit("UpdateTaskDescription", "", id, t.Description, description)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("task not found")
+}
+
+// SetTaskEndDate schedules the last date a task applies ("" removes the end date).
+// Unlike DeleteTask, the task stays in reports for every date up to its end.
+func (a *App) SetTaskEndDate(id string, endsAt string) error {
+	if endsAt != "" {
+		if _, err := time.Parse("2006-01-02", endsAt); err != nil {
+			return errors.New("invalid end date")
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			a.data.Templates[i].EndsAt = endsAt
+			a.audit("SetTaskEndDate", endsAt, id, t.EndsAt, endsAt)
 			return a.saveDataLocked()
 		}
 	}
@@ -440,7 +749,7 @@ func (a *App) UpdateTask(id, name string) error {
 	return nil
 }
 
-// DeleteTask soft-deletes a task (only affects future dates)
+// DeleteTask moves a task to the trash (only affects future dates)
 func (a *App) DeleteTask(id string) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -448,7 +757,9 @@ func (a *App) DeleteTask(id string) error {
 	today := time.Now().Format("2006-01-02")
 	for i, t := range a.data.Templates {
 		if t.ID == id {
+			a.rememberLocked("DeleteTask")
 			a.data.Templates[i].DeletedAt = &today
+			a.audit("DeleteTask", today, id, nil, today)
 			return a.saveDataLocked()
 		}
 	}
@@ -456,6 +767,147 @@ func (a *App) DeleteTask(id string) error {
 	return nil
 }
 
+// UndeleteTask restores a deleted task. The days it spent deleted are
+// recorded as a pause, so they don't count as missed.
+func (a *App) UndeleteTask(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID != id {
+			continue
+		}
+		if t.DeletedAt == nil {
+			return nil
+		}
+
+		deletedAt := *t.DeletedAt
+		yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+		if deletedAt <= yesterday {
+			a.data.Templates[i].Paused = append(a.data.Templates[i].Paused, DateRange{From: deletedAt, To: yesterday})
+		}
+		a.data.Templates[i].DeletedAt = nil
+
+		a.audit("UndeleteTask", "", id, deletedAt, nil)
+		return a.saveDataLocked()
+	}
+
+	return errors.New("task not found")
+}
+
+// GetDeletedTasks returns deleted tasks that can still be restored, most
+// recently deleted first
+func (a *App) GetDeletedTasks() []TaskTemplate {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	deleted := []TaskTemplate{}
+	for _, t := range a.data.Templates {
+		if t.Type == "" {
+			t.Type = "binary"
+		}
+		if t.DeletedAt != nil {
+			deleted = append(deleted, t)
+		}
+	}
+
+	sort.SliceStable(deleted, func(i, j int) bool {
+		return *deleted[i].DeletedAt > *deleted[j].DeletedAt
+	})
+
+	return deleted
+}
+
+// PurgeTask permanently removes a task and every value ever recorded for it.
+// confirmName must match the task's name exactly, so the frontend has to ask
+// the user to type it before a purge can happen.
+func (a *App) PurgeTask(id string, confirmName string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	index := -1
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return errors.New("task not found")
+	}
+
+	task := a.data.Templates[index]
+	if confirmName != task.Name {
+		return errors.New("confirmation does not match task name")
+	}
+	a.rememberLocked("PurgeTask")
+	a.purgeTaskLocked(index)
+
+	a.audit("PurgeTask", "", id, task.Name, nil)
+	return a.saveDataLocked()
+}
+
+// purgeTaskLocked removes the task at index in Templates along with
+// everything recorded for it (must hold lock; caller saves)
+func (a *App) purgeTaskLocked(index int) {
+	id := a.data.Templates[index].ID
+	a.data.Templates = append(a.data.Templates[:index], a.data.Templates[index+1:]...)
+	for date, dayTasks := range a.data.Days {
+		delete(dayTasks, id)
+		if len(dayTasks) == 0 {
+			delete(a.data.Days, date)
+		}
+	}
+	for date, subitems := range a.data.SubitemDays {
+		delete(subitems, id)
+		if len(subitems) == 0 {
+			delete(a.data.SubitemDays, date)
+		}
+	}
+	for date, values := range a.data.Measurements {
+		delete(values, id)
+		if len(values) == 0 {
+			delete(a.data.Measurements, date)
+		}
+	}
+	for date, skipped := range a.data.Skipped {
+		delete(skipped, id)
+		if len(skipped) == 0 {
+			delete(a.data.Skipped, date)
+		}
+	}
+	sessions := a.data.FocusSessions[:0]
+	for _, session := range a.data.FocusSessions {
+		if session.TaskID != id {
+			sessions = append(sessions, session)
+		}
+	}
+	a.data.FocusSessions = sessions
+	delete(a.data.Timers, id)
+	delete(a.data.Records, id)
+	if scoring := a.settings.Scoring; scoring != nil {
+		if _, ok := scoring.TaskWeights[id]; ok {
+			delete(scoring.TaskWeights, id)
+			if err := a.saveSettingsLocked(); err != nil {
+				println("Error saving settings:", err.Error())
+			}
+		}
+	}
+	goals := a.data.LifetimeGoals[:0]
+	for _, goal := range a.data.LifetimeGoals {
+		if goal.TaskID != id {
+			goals = append(goals, goal)
+		}
+	}
+	a.data.LifetimeGoals = goals
+	a.removeCompositePartLocked(id)
+	for i := range a.data.Templates {
+		if a.data.Templates[i].Requires == id {
+			a.data.Templates[i].Requires = ""
+		}
+	}
+}
+
 // ReorderTasks updates the order of tasks
 func (a *App) ReorderTasks(ids []string) error {
 	a.mu.Lock()
@@ -466,55 +918,137 @@ func (a *App) ReorderTasks(ids []string) error {
 		orderMap[id] = i
 	}
 
+	a.rememberLocked("ReorderTasks")
 	for i, t := range a.data.Templates {
-		if order, ok := orderMap[t.ID]; ok {
+		if order, ok := orderMap[t.ID]; ok && order != t.Order {
 			a.data.Templates[i].Order = order
+			a.audit("ReorderTasks", "", t.ID, t.Order, order)
 		}
 	}
 
 	return a.saveDataLocked()
 }
 
-// LoadDay returns task completion status for a specific date
-func (a *App) LoadDay(date string) map[string]int {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+// DayValues is a day's recorded values with what the UI needs to edit them
+type DayValues struct {
+	Values   map[string]int `json:"values"`   // taskID -> value
+	ScaleMax map[string]int `json:"scaleMax"` // taskID -> top rating, for the scale tasks that apply
+}
+
+// LoadDay returns task completion status for a specific date, with the top
+// rating of each scale task that applies on it
+func (a *App) LoadDay(date string) DayValues {
+	defer a.lockDays(date, date)()
 
-	if tasks, ok := a.data.Days[date]; ok {
-		result := make(map[string]int)
-		for k, v := range tasks {
-			result[k] = v
+	day := DayValues{Values: make(map[string]int), ScaleMax: make(map[string]int)}
+	for k, v := range a.data.Days[date] {
+		day.Values[k] = v
+	}
+	for _, t := range a.getTasksForDateLocked(date) {
+		if isScaleTask(t) {
+			day.ScaleMax[t.ID] = scaleMaxOf(t)
 		}
-		return result
 	}
-
-	return make(map[string]int)
+	return day
 }
 
-// SaveDay saves task completion status for a specific date
-func (a *App) SaveDay(date string, tasks map[string]int) error {
+// SaveDay saves task completion status for a specific date.
+// Returns any personal records broken by the new values. During the
+// late-night grace period a save for today goes to the previous day.
+func (a *App) SaveDay(date string, tasks map[string]int) ([]RecordBreak, error) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	date = a.attributeDateLocked(date, time.Now())
+	if err := a.checkEditableLocked(date); err != nil {
+		return nil, err
+	}
+
 	if a.data.Days == nil {
 		a.data.Days = make(map[string]DayTasks)
 	}
 
+	if tasks == nil {
+		tasks = make(map[string]int)
+	}
+	if err := a.pullArchivedDayLocked(date); err != nil {
+		return nil, err
+	}
+	old := a.data.Days[date]
+
+	// Computed tasks are read-only: sending their current value back is fine,
+	// changing it isn't. Value tasks are set with SetMeasurement; keep their
+	// values whatever the UI sent.
+	for _, t := range a.data.Templates {
+		if !isAutoTask(t) && !isValueTask(t) {
+			continue
+		}
+		if value, sent := tasks[t.ID]; sent && isAutoTask(t) && value != old[t.ID] {
+			return nil, errors.New("task value is computed automatically")
+		}
+		if prev, ok := old[t.ID]; ok {
+			tasks[t.ID] = prev
+		} else {
+			delete(tasks, t.ID)
+		}
+	}
+
+	if err := a.checkScaleValuesLocked(old, tasks); err != nil {
+		return nil, err
+	}
+	if !maps.Equal(old, tasks) {
+		a.rememberDaysLocked("SaveDay")
+	}
+
+	for id, value := range tasks {
+		if prev, ok := old[id]; !ok || prev != value {
+			a.audit("SaveDay", date, id, auditValue(old, id), value)
+		}
+	}
+	for id, prev := range old {
+		if _, ok := tasks[id]; !ok {
+			a.audit("SaveDay", date, id, prev, nil)
+		}
+	}
+
 	a.data.Days[date] = tasks
-	return a.saveDataLocked()
+
+	changed := []string{}
+	for id := range tasks {
+		changed = append(changed, id)
+	}
+	breaks := a.updateRecordsLocked(date, changed)
+
+	edited := []string{}
+	for id, value := range tasks {
+		if prev, ok := old[id]; !ok || prev != value {
+			edited = append(edited, id)
+		}
+	}
+	if len(edited) > 0 {
+		a.emitDependencyWarnings(a.dependencyWarningsLocked(date, edited))
+		a.emitTiersReached(a.tiersReachedLocked(date, old, edited))
+		a.checkLifetimeGoalsLocked(edited)
+	}
+
+	return breaks, a.saveDataLocked()
 }
 
-// LoadWeek returns task data for a week starting from the given date
+// LoadWeek returns task data for a week starting from the given date.
+// LoadWeekDetailed adds per-task and weekly-quota progress.
 func (a *App) LoadWeek(startDate string) map[string]map[string]int {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
-
-	result := make(map[string]map[string]int)
+	defer a.lockWeek(startDate)()
 
 	t, err := time.Parse("2006-01-02", startDate)
 	if err != nil {
-		return result
+		return make(map[string]map[string]int)
 	}
+	return a.loadWeekLocked(t)
+}
+
+// loadWeekLocked copies day values for the 7 days from start (must hold lock)
+func (a *App) loadWeekLocked(t time.Time) map[string]map[string]int {
+	result := make(map[string]map[string]int)
 
 	for i := 0; i < 7; i++ {
 		date := t.AddDate(0, 0, i)
@@ -536,8 +1070,7 @@ func (a *App) LoadWeek(startDate string) map[string]map[string]int {
 
 // GetWeeklyReport calculates daily completion percentages for a week
 func (a *App) GetWeeklyReport(startDate string) map[string]interface{} {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	defer a.lockWeek(startDate)()
 
 	result := map[string]interface{}{
 		"dailyPercentages": []float64{},
@@ -549,63 +1082,126 @@ func (a *App) GetWeeklyReport(startDate string) map[string]interface{} {
 		return result
 	}
 
-	dailyPercentages := make([]float64, 7)
+	dailyPercentages, weeklyAverage, frequency := a.weeklyAverageLocked(t)
+	result["dailyPercentages"] = dailyPercentages
+	result["weeklyAverage"] = weeklyAverage
+	result["frequencyProgress"] = frequency
+	result["measurements"] = a.measurementsInRangeLocked(startDate, t.AddDate(0, 0, 6).Format("2006-01-02"))
+	result["scales"] = a.scaleStatsInRangeLocked(startDate, t.AddDate(0, 0, 6).Format("2006-01-02"))
+	result["focus"] = a.focusReportLocked(startDate, t.AddDate(0, 0, 6).Format("2006-01-02"))
+	result["annotations"] = a.annotationsInRangeLocked(startDate, t.AddDate(0, 0, 6).Format("2006-01-02"))
+	result["specialDays"] = a.specialDaysInRangeLocked(startDate, t.AddDate(0, 0, 6).Format("2006-01-02"))
+	result["skippedDays"] = a.skippedDaysInRangeLocked(startDate, t.AddDate(0, 0, 6).Format("2006-01-02"))
+	result["highPriorityUnfinished"] = a.unfinishedPriorityLocked(t)
+	result["streakGoalsHit"] = a.streakGoalHitsLocked(startDate, t.AddDate(0, 0, 6).Format("2006-01-02"))
+
+	return result
+}
+
+// weeklyAverageLocked scores the week starting t: day scores, the weekly
+// average and weekly-quota progress (must hold lock)
+func (a *App) weeklyAverageLocked(t time.Time) (dailyPercentages []float64, weeklyAverage float64, frequency []FrequencyProgress) {
+	dailyPercentages = make([]float64, 7)
 	total := 0.0
+	countedDays := 0
+	dailyTaskSlots := 0
 
 	for i := 0; i < 7; i++ {
 		date := t.AddDate(0, 0, i)
 		dateKey := date.Format("2006-01-02")
 
-		// Get tasks valid for this date
-		tasksForDate := a.getTasksForDateLocked(dateKey)
-		taskCount := len(tasksForDate)
-
-		if taskCount == 0 {
+		if a.dayExcludedLocked(dateKey) {
 			continue
 		}
+		countedDays++
+		dailyTaskSlots += len(a.getDailyTasksForDateLocked(dateKey))
 
-		if dayTasks, ok := a.data.Days[dateKey]; ok {
-			completed := 0
-			for _, task := range tasksForDate {
-				typeVal := task.Type
-				if typeVal == "" {
-					typeVal = "binary"
-				}
-				if typeVal == "binary" || typeVal == "count" {
-					if dayTasks[task.ID] > 0 {
-						completed++
-					}
-				}
-			}
-			percentage := float64(completed) / float64(taskCount) * 100.0
+		if percentage, ok := a.dayScoreLocked(dateKey); ok {
 			dailyPercentages[i] = percentage
 			total += percentage
 		}
 	}
 
-	result["dailyPercentages"] = dailyPercentages
-	result["weeklyAverage"] = total / 7.0
+	if countedDays > 0 {
+		weeklyAverage = total / float64(countedDays)
+	}
 
-	return result
+	// Weekly-quota tasks are scored against their quota rather than daily;
+	// each counts as one task next to the average number of daily tasks
+	frequency = a.frequencyProgressLocked(t)
+	if len(frequency) > 0 && countedDays > 0 {
+		dailyWeight := float64(dailyTaskSlots) / float64(countedDays)
+		sum := weeklyAverage * dailyWeight
+		for _, p := range frequency {
+			sum += frequencyPercentage(p)
+		}
+		weeklyAverage = sum / (dailyWeight + float64(len(frequency)))
+	}
+
+	return dailyPercentages, weeklyAverage, frequency
 }
 
 // getTasksForDateLocked returns tasks for a date (must hold lock)
 func (a *App) getTasksForDateLocked(date string) []TaskTemplate {
 	var tasks []TaskTemplate
 	for _, t := range a.data.Templates {
-		if t.CreatedAt <= date {
-			if t.DeletedAt == nil || *t.DeletedAt > date {
-				tasks = append(tasks, t)
-			}
+		if a.taskAppliesLocked(t, date) {
+			tasks = append(tasks, t)
 		}
 	}
 	return tasks
 }
 
+// getDailyTasksForDateLocked returns tasks scored on a date, leaving out
+// weekly-quota tasks, value and scale tasks (measured or rated rather than
+// completed) and tasks skipped that day (must hold lock)
+func (a *App) getDailyTasksForDateLocked(date string) []TaskTemplate {
+	var tasks []TaskTemplate
+	for _, t := range a.getTasksForDateLocked(date) {
+		if !isFrequencyTask(t) && !isValueTask(t) && !isScaleTask(t) && !a.taskSkippedLocked(date, t.ID) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+// taskActiveOn reports whether a task applies to a date
+func taskActiveOn(t TaskTemplate, date string) bool {
+	// Include if created on or before this date
+	if t.CreatedAt > date {
+		return false
+	}
+	// Exclude if deleted before this date
+	if t.DeletedAt != nil && *t.DeletedAt <= date {
+		return false
+	}
+	// Time-bounded tasks stop applying after their end date
+	if t.EndsAt != "" && t.EndsAt < date {
+		return false
+	}
+	// Archived tasks keep their history but drop out from the archive date on
+	if t.Archived && t.ArchivedAt <= date {
+		return false
+	}
+	// Paused tasks don't apply during their pause
+	if rangesContain(t.Paused, date) {
+		return false
+	}
+	// Monthly tasks apply on their day of the month only
+	if isMonthlyTask(t) && !occursOnMonthDay(t.DayOfMonth, date) {
+		return false
+	}
+	// Weekday-only and weekend-only tasks skip the other days
+	if !occursOnDayKind(t.OnDays, date) {
+		return false
+	}
+	return true
+}
+
 // GetMonthlyReport calculates weekly averages for a given month
 func (a *App) GetMonthlyReport(year int, month int) map[string]interface{} {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	defer a.lockDays(start.Format("2006-01-02"), start.AddDate(0, 1, -1).Format("2006-01-02"))()
 
 	result := map[string]interface{}{
 		"weeklyAverages": []float64{},
@@ -625,27 +1221,12 @@ func (a *App) GetMonthlyReport(year int, month int) map[string]interface{} {
 
 		for i := 0; i < 7 && (currentDay.Before(lastDay) || currentDay.Equal(lastDay)); i++ {
 			dateKey := currentDay.Format("2006-01-02")
-			tasksForDate := a.getTasksForDateLocked(dateKey)
-			taskCount := len(tasksForDate)
-
-			if taskCount > 0 {
-				if dayTasks, ok := a.data.Days[dateKey]; ok {
-					completed := 0
-					for _, task := range tasksForDate {
-						typeVal := task.Type
-						if typeVal == "" {
-							typeVal = "binary"
-						}
-						if typeVal == "binary" || typeVal == "count" {
-							if dayTasks[task.ID] > 0 {
-								completed++
-							}
-						}
-					}
-					weekTotal += float64(completed) / float64(taskCount) * 100.0
+			if !a.dayExcludedLocked(dateKey) {
+				if percentage, ok := a.dayScoreLocked(dateKey); ok {
+					weekTotal += percentage
 				}
+				daysInWeek++
 			}
-			daysInWeek++
 			currentDay = currentDay.AddDate(0, 0, 1)
 		}
 
@@ -655,6 +1236,11 @@ func (a *App) GetMonthlyReport(year int, month int) map[string]interface{} {
 	}
 
 	result["weeklyAverages"] = weeklyAverages
+	result["measurements"] = a.measurementsInRangeLocked(firstDay.Format("2006-01-02"), lastDay.Format("2006-01-02"))
+	result["scales"] = a.scaleStatsInRangeLocked(firstDay.Format("2006-01-02"), lastDay.Format("2006-01-02"))
+	result["annotations"] = a.annotationsInRangeLocked(firstDay.Format("2006-01-02"), lastDay.Format("2006-01-02"))
+	result["specialDays"] = a.specialDaysInRangeLocked(firstDay.Format("2006-01-02"), lastDay.Format("2006-01-02"))
+	result["skippedDays"] = a.skippedDaysInRangeLocked(firstDay.Format("2006-01-02"), lastDay.Format("2006-01-02"))
 
 	if len(weeklyAverages) >= 2 {
 		first := weeklyAverages[0]
@@ -671,8 +1257,7 @@ func (a *App) GetMonthlyReport(year int, month int) map[string]interface{} {
 
 // GetYearlyReport calculates monthly averages for a given year
 func (a *App) GetYearlyReport(year int) map[string]interface{} {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	defer a.lockDays(fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year))()
 
 	result := map[string]interface{}{
 		"monthlyAverages":     make([]float64, 12),
@@ -694,25 +1279,8 @@ func (a *App) GetYearlyReport(year int) map[string]interface{} {
 
 		for currentDay.Before(lastDay) || currentDay.Equal(lastDay) {
 			dateKey := currentDay.Format("2006-01-02")
-			tasksForDate := a.getTasksForDateLocked(dateKey)
-			taskCount := len(tasksForDate)
-
-			if taskCount > 0 {
-				if dayTasks, ok := a.data.Days[dateKey]; ok {
-					completed := 0
-					for _, task := range tasksForDate {
-						typeVal := task.Type
-						if typeVal == "" {
-							typeVal = "binary"
-						}
-						if typeVal == "binary" || typeVal == "count" {
-							if dayTasks[task.ID] > 0 {
-								completed++
-							}
-						}
-					}
-					dailyPercentages = append(dailyPercentages, float64(completed)/float64(taskCount)*100.0)
-				}
+			if percentage, ok := a.dayScoreLocked(dateKey); ok {
+				dailyPercentages = append(dailyPercentages, percentage)
 			}
 			currentDay = currentDay.AddDate(0, 0, 1)
 		}
@@ -747,6 +1315,11 @@ func (a *App) GetYearlyReport(year int) map[string]interface{} {
 
 	result["monthlyAverages"] = monthlyAverages
 	result["mostConsistentMonth"] = mostConsistent
+	result["measurements"] = a.measurementsInRangeLocked(fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year))
+	result["scales"] = a.scaleStatsInRangeLocked(fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year))
+	result["annotations"] = a.annotationsInRangeLocked(fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year))
+	result["specialDays"] = a.specialDaysInRangeLocked(fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year))
+	result["skippedDays"] = a.skippedDaysInRangeLocked(fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year))
 	if validMonths > 0 {
 		result["yearTotal"] = yearTotal / float64(validMonths)
 	}
@@ -755,32 +1328,10 @@ func (a *App) GetYearlyReport(year int) map[string]interface{} {
 }
 
 // SaveHTMLExport saves HTML content to Downloads folder
+//
+// Deprecated: use Export with the "html" format and a "content" option.
 func (a *App) SaveHTMLExport(filename string, htmlContent string) (string, error) {
-	// Check export path setting or default
-	a.mu.RLock()
-	exportDir := a.data.ExportPath
-	a.mu.RUnlock()
-	if exportDir == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return "", err
-		}
-		exportDir = filepath.Join(homeDir, "Downloads")
-	}
-
-	// Create PLAN_Exports subfolder
-	finalDir := filepath.Join(exportDir, "PLAN_Exports")
-	if err := os.MkdirAll(finalDir, 0755); err != nil {
-		return "", err
-	}
-
-	downloadsPath := filepath.Join(finalDir, filename)
-
-	if err := a.atomicWriteFile(downloadsPath, []byte(htmlContent)); err != nil {
-		return "", err
-	}
-
-	return downloadsPath, nil
+	return a.writeExportFile(filename, []byte(htmlContent))
 }
 
 // SetExportPath updates the export directory
@@ -788,19 +1339,26 @@ func (a *App) SetExportPath(path string) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	a.data.ExportPath = path
-	return a.saveDataLocked()
+	a.audit("SetExportPath", "", "", a.settings.ExportPath, path)
+	a.settings.ExportPath = path
+	return a.saveSettingsLocked()
 }
 
 // GetExportPath returns the current export directory
 func (a *App) GetExportPath() string {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
-	return a.data.ExportPath
+	return a.settings.ExportPath
 }
 
-// MarkWeekExported records that a week has been exported
+// MarkWeekExported records that a week has been exported. Any date within
+// the week is accepted and stored under the week's start.
 func (a *App) MarkWeekExported(weekStart string) error {
+	weekStart, err := canonicalWeekStart(weekStart)
+	if err != nil {
+		return err
+	}
+
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
@@ -808,10 +1366,29 @@ func (a *App) MarkWeekExported(weekStart string) error {
 		a.data.ExportHistory = make(map[string]string)
 	}
 
-	a.data.ExportHistory[weekStart] = time.Now().Format("2006-01-02")
+	today := time.Now().Format("2006-01-02")
+	a.audit("MarkWeekExported", weekStart, "", a.data.ExportHistory[weekStart], today)
+	a.data.ExportHistory[weekStart] = today
 	return a.saveDataLocked()
 }
 
+// migrateExportHistoryLocked rewrites export history keys that aren't week
+// starts, keeping the latest export date when several keys share a week
+// (must hold lock)
+func (a *App) migrateExportHistoryLocked() error {
+	for key, exported := range a.data.ExportHistory {
+		canonical, err := canonicalWeekStart(key)
+		if err != nil || canonical == key {
+			continue
+		}
+		if existing, ok := a.data.ExportHistory[canonical]; !ok || exported > existing {
+			a.data.ExportHistory[canonical] = exported
+		}
+		delete(a.data.ExportHistory, key)
+	}
+	return nil
+}
+
 // IsWeekExported checks if a week has already been exported
 func (a *App) IsWeekExported(weekStart string) bool {
 	a.mu.RLock()
@@ -820,6 +1397,9 @@ func (a *App) IsWeekExported(weekStart string) bool {
 	if a.data.ExportHistory == nil {
 		return false
 	}
+	if canonical, err := canonicalWeekStart(weekStart); err == nil {
+		weekStart = canonical
+	}
 
 	_, exists := a.data.ExportHistory[weekStart]
 	return exists
@@ -839,178 +1419,109 @@ func (a *App) SelectDirectory() (string, error) {
 // GetStreaks calculates current streak and longest streak
 // A streak is consecutive days where completion >= 50%
 func (a *App) GetStreaks() map[string]interface{} {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	defer a.lockDays("", "")()
 
-	result := map[string]interface{}{
-		"currentStreak":  0,
-		"longestStreak":  0,
-		"totalPerfectDays": 0,
+	streaks := a.streaksLocked("")
+	return map[string]interface{}{
+		"currentStreak":    streaks.current,
+		"longestStreak":    streaks.longest,
+		"totalPerfectDays": streaks.perfectDays,
 	}
+}
+
+// streakStats are the overall streaks GetStreaks reports
+type streakStats struct {
+	current     int
+	longest     int
+	perfectDays int
+}
 
+// recentStreaks is GetStreaks over the past year, which the current streak
+// never reaches beyond. It's for the menu, rebuilt after every save, so it
+// leaves archived years alone.
+func (a *App) recentStreaks() streakStats {
+	from := time.Now().AddDate(-1, 0, 0).Format("2006-01-02")
+	defer a.lockDays(from, "")()
+	return a.streaksLocked(from)
+}
+
+// streaksLocked calculates the streaks of GetStreaks from dates on or after
+// from ("" for all of them) (must hold lock)
+func (a *App) streaksLocked(from string) streakStats {
 	// Get all dates and sort them
 	var dates []string
 	for date := range a.data.Days {
-		dates = append(dates, date)
+		if date >= from {
+			dates = append(dates, date)
+		}
 	}
 	sort.Strings(dates)
 
 	if len(dates) == 0 {
-		return result
+		return streakStats{}
 	}
 
-	// Calculate streaks
 	longestStreak := 0
 	currentStreak := 0
 	totalPerfectDays := 0
-	
-	// Start from today and go backwards
-	today := time.Now()
-	todayKey := today.Format("2006-01-02")
-	
+
 	// Calculate current streak (going backwards from today)
+	today := time.Now()
 	checkDate := today
 	for {
 		dateKey := checkDate.Format("2006-01-02")
-		
-		tasksForDate := a.getTasksForDateLocked(dateKey)
-		taskCount := len(tasksForDate)
-		
-		if taskCount == 0 {
-			// No tasks for this day, skip but don't break streak
-			checkDate = checkDate.AddDate(0, 0, -1)
-			// Stop if we go back more than a year
-			if today.Sub(checkDate).Hours() > 365*24 {
+
+		if len(a.getDailyTasksForDateLocked(dateKey)) > 0 && !a.dayExcludedLocked(dateKey) {
+			// Days with tasks but no data break the streak
+			percentage, ok := a.dayScoreLocked(dateKey)
+			if !ok || percentage < 50.0 {
 				break
 			}
-			continue
-		}
-		
-		dayTasks, ok := a.data.Days[dateKey]
-		if !ok {
-			// No data for this day with tasks - break current streak
-			break
-		}
-		
-		completed := 0
-		for _, task := range tasksForDate {
-			typeVal := task.Type
-			if typeVal == "" {
-				typeVal = "binary"
-			}
-			if dayTasks[task.ID] > 0 {
-				completed++
-			}
-		}
-		
-		percentage := float64(completed) / float64(taskCount) * 100.0
-		
-		if percentage >= 50.0 {
 			currentStreak++
-			if percentage == 100.0 {
+			if percentage >= 100.0 {
 				totalPerfectDays++
 			}
-		} else {
-			break
 		}
-		
+
 		checkDate = checkDate.AddDate(0, 0, -1)
 		// Stop if we go back more than a year
 		if today.Sub(checkDate).Hours() > 365*24 {
 			break
 		}
 	}
-	
+
 	// Calculate longest streak (going through all dates)
 	streak := 0
 	var prevDate *time.Time
-	
+
 	for _, dateKey := range dates {
 		date, err := time.Parse("2006-01-02", dateKey)
 		if err != nil {
 			continue
 		}
-		
-		tasksForDate := a.getTasksForDateLocked(dateKey)
-		taskCount := len(tasksForDate)
-		
-		if taskCount == 0 {
-			continue
-		}
-		
-		dayTasks, ok := a.data.Days[dateKey]
+
+		percentage, ok := a.dayScoreLocked(dateKey)
 		if !ok {
-			streak = 0
-			prevDate = &date
 			continue
 		}
-		
-		completed := 0
-		for _, task := range tasksForDate {
-			typeVal := task.Type
-			if typeVal == "" {
-				typeVal = "binary"
-			}
-			if dayTasks[task.ID] > 0 {
-				completed++
-			}
-		}
-		
-		percentage := float64(completed) / float64(taskCount) * 100.0
-		
+
 		if percentage >= 50.0 {
-			// Check if consecutive day
-			if prevDate != nil {
-				diff := date.Sub(*prevDate).Hours() / 24
-				if diff <= 1 {
-					streak++
-				} else {
-					streak = 1
-				}
+			// Check if consecutive, ignoring days that can't break a streak
+			if prevDate != nil && a.neutralGapLocked(*prevDate, date) {
+				streak++
 			} else {
 				streak = 1
 			}
-			
-			if percentage == 100.0 && dateKey != todayKey {
-				// Already counted in current streak check
-			}
-			
+
 			if streak > longestStreak {
 				longestStreak = streak
 			}
 		} else {
 			streak = 0
 		}
-		
+
 		prevDate = &date
 	}
-	
-	// Also count perfect days from historical data
-	for _, dateKey := range dates {
-		tasksForDate := a.getTasksForDateLocked(dateKey)
-		taskCount := len(tasksForDate)
-		if taskCount == 0 {
-			continue
-		}
-		dayTasks, ok := a.data.Days[dateKey]
-		if !ok {
-			continue
-		}
-		completed := 0
-		for _, task := range tasksForDate {
-			if dayTasks[task.ID] > 0 {
-				completed++
-			}
-		}
-		if completed == taskCount && taskCount > 0 {
-			// Count only if not already counted in current streak
-			// This is a simplified count
-		}
-	}
-
-	result["currentStreak"] = currentStreak
-	result["longestStreak"] = longestStreak
-	result["totalPerfectDays"] = totalPerfectDays
 
-	return result
+	return streakStats{current: currentStreak, longest: longestStreak, perfectDays: totalPerfectDays}
 }
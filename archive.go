@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// ArchiveTask hides a task from the daily view from today on while keeping
+// all of its history, so it can be restored later
+func (a *App) ArchiveTask(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	today := time.Now().Format("2006-01-02")
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			if t.Archived {
+				return nil
+			}
+			a.rememberLocked("ArchiveTask")
+			a.data.Templates[i].Archived = true
+			a.data.Templates[i].ArchivedAt = today
+			a.audit("ArchiveTask", today, id, false, true)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("task not found")
+}
+
+// UnarchiveTask brings an archived task back into the daily view. The days
+// it spent archived are recorded as a pause, so they don't count as missed.
+func (a *App) UnarchiveTask(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			if !t.Archived {
+				return nil
+			}
+			archivedAt := t.ArchivedAt
+			yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+			if archivedAt != "" && archivedAt <= yesterday {
+				a.data.Templates[i].Paused = append(a.data.Templates[i].Paused, DateRange{From: archivedAt, To: yesterday})
+			}
+			a.data.Templates[i].Archived = false
+			a.data.Templates[i].ArchivedAt = ""
+			a.audit("UnarchiveTask", "", id, true, false)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("task not found")
+}
+
+// GetArchivedTasks returns archived (not deleted) tasks
+func (a *App) GetArchivedTasks() []TaskTemplate {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	archived := []TaskTemplate{}
+	for _, t := range a.data.Templates {
+		if t.Type == "" {
+			t.Type = "binary"
+		}
+		if t.Archived && t.DeletedAt == nil {
+			archived = append(archived, t)
+		}
+	}
+
+	a.sortTasksLocked(archived)
+
+	return archived
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// auditMaxFileSize is the size at which the active audit file is rotated
+	auditMaxFileSize = 512 * 1024
+	// auditMaxFiles is the number of audit files kept, including the active one
+	auditMaxFiles = 5
+)
+
+// AuditEntry records a single mutation of planner data
+type AuditEntry struct {
+	Time     string `json:"time"`             // RFC3339 timestamp of the change
+	Actor    string `json:"actor"`            // Machine/user that made the change
+	Method   string `json:"method"`           // API method that caused the change
+	Date     string `json:"date,omitempty"`   // Day affected, for day-level changes
+	TaskID   string `json:"taskId,omitempty"` // Task affected, if any
+	OldValue any    `json:"oldValue,omitempty"`
+	NewValue any    `json:"newValue,omitempty"`
+}
+
+// auditActor identifies who is making changes on this machine
+func auditActor() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "localhost"
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name + "@" + host
+	}
+	if name := os.Getenv("USERNAME"); name != "" {
+		return name + "@" + host
+	}
+	return host
+}
+
+// auditPath returns the path of the active audit log file
+func (a *App) auditPath() string {
+	return filepath.Join(filepath.Dir(a.dataPath), "audit.jsonl")
+}
+
+// rotatedAuditPath returns the path of the n-th rotated audit file
+func (a *App) rotatedAuditPath(n int) string {
+	return filepath.Join(filepath.Dir(a.dataPath), fmt.Sprintf("audit.%d.jsonl", n))
+}
+
+// auditPaths returns the audit files, oldest first
+func (a *App) auditPaths() []string {
+	paths := []string{}
+	for n := auditMaxFiles - 1; n >= 1; n-- {
+		paths = append(paths, a.rotatedAuditPath(n))
+	}
+	return append(paths, a.auditPath())
+}
+
+// audit records a mutation in the audit log. The entry waits for the save
+// that follows: saveDataLocked and saveSettingsLocked write it once they
+// succeed and drop it if they fail, so only saved changes are logged.
+func (a *App) audit(method, date, taskID string, oldValue, newValue any) {
+	if a.dataPath == "" {
+		return
+	}
+
+	a.auditMu.Lock()
+	defer a.auditMu.Unlock()
+	a.pendingAudit = append(a.pendingAudit, newAuditEntry(a.actor, method, date, taskID, oldValue, newValue))
+}
+
+// auditNow writes an entry straight away, for actions that don't save
+// anything, such as unlocking a day for editing
+func (a *App) auditNow(method, date, taskID string, oldValue, newValue any) {
+	if a.dataPath == "" {
+		return
+	}
+	a.writeAudit([]AuditEntry{newAuditEntry(a.actor, method, date, taskID, oldValue, newValue)})
+}
+
+// newAuditEntry stamps an audit entry with the current time
+func newAuditEntry(actor, method, date, taskID string, oldValue, newValue any) AuditEntry {
+	return AuditEntry{
+		Time:     time.Now().Format(time.RFC3339),
+		Actor:    actor,
+		Method:   method,
+		Date:     date,
+		TaskID:   taskID,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}
+}
+
+// auditValue returns a day value for the audit log, nil when there is none
+func auditValue(tasks DayTasks, id string) any {
+	if value, ok := tasks[id]; ok {
+		return value
+	}
+	return nil
+}
+
+// takeAudit removes and returns the entries waiting for a save
+func (a *App) takeAudit() []AuditEntry {
+	a.auditMu.Lock()
+	defer a.auditMu.Unlock()
+
+	entries := a.pendingAudit
+	a.pendingAudit = nil
+	return entries
+}
+
+// auditCause returns the method behind a save's audited entries, for the
+// change history: the last one audited, or "" when there are none
+func auditCause(entries []AuditEntry) string {
+	if len(entries) == 0 {
+		return ""
+	}
+	return entries[len(entries)-1].Method
+}
+
+// writeAudit appends entries to the audit log, sealed like the change
+// history when encryption is on. Failures are logged but never block the
+// mutation itself (must hold lock).
+func (a *App) writeAudit(entries []AuditEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err == nil {
+			line, err = a.sealLineLocked(line)
+		}
+		if err != nil {
+			continue
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	a.auditMu.Lock()
+	defer a.auditMu.Unlock()
+
+	a.rotateAuditLocked()
+
+	f, err := os.OpenFile(a.auditPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		println("Error opening audit log:", err.Error())
+		return
+	}
+	defer f.Close()
+
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		println("Error writing audit log:", err.Error())
+	}
+}
+
+// rotateAuditLocked shifts audit files when the active one grows too large
+// (must be called with auditMu held)
+func (a *App) rotateAuditLocked() {
+	info, err := os.Stat(a.auditPath())
+	if err != nil || info.Size() < auditMaxFileSize {
+		return
+	}
+
+	// Drop the oldest file, then shift the rest up by one
+	os.Remove(a.rotatedAuditPath(auditMaxFiles - 1))
+	for n := auditMaxFiles - 2; n >= 1; n-- {
+		os.Rename(a.rotatedAuditPath(n), a.rotatedAuditPath(n+1))
+	}
+	os.Rename(a.auditPath(), a.rotatedAuditPath(1))
+}
+
+// GetAuditLog returns audit entries recorded between from and to (inclusive,
+// "2006-01-02" format), oldest first. Empty bounds are open-ended.
+func (a *App) GetAuditLog(from, to string) []AuditEntry {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	a.auditMu.Lock()
+	defer a.auditMu.Unlock()
+
+	// Oldest files come first so the result is chronological
+	entries := []AuditEntry{}
+	for _, path := range a.auditPaths() {
+		lines, err := a.readSealedLinesLocked(path)
+		if err != nil {
+			continue
+		}
+
+		for _, line := range lines {
+			var entry AuditEntry
+			if err := json.Unmarshal(line, &entry); err != nil {
+				continue
+			}
+
+			day := entry.Time
+			if len(day) >= 10 {
+				day = day[:10]
+			}
+			if from != "" && day < from {
+				continue
+			}
+			if to != "" && day > to {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
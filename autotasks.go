@@ -0,0 +1,183 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"os"
+	"time"
+)
+
+// AutoSource computes a task's day value from an imported signal
+// (e.g. "sleep_hours" >= 7) instead of manual entry
+type AutoSource struct {
+	Signal    string  `json:"signal"`
+	Op        string  `json:"op,omitempty"` // ">=", ">", "<=", "<", "==" or "" for the raw value
+	Threshold float64 `json:"threshold,omitempty"`
+}
+
+// isAutoTask reports whether a task's value is computed rather than
+// entered, from a signal or from other tasks
+func isAutoTask(t TaskTemplate) bool {
+	return t.Source != nil || t.Composite != nil
+}
+
+// evaluate returns the day value for a signal reading
+func (s AutoSource) evaluate(task TaskTemplate, reading float64) int {
+	var holds bool
+	switch s.Op {
+	case ">=":
+		holds = reading >= s.Threshold
+	case ">":
+		holds = reading > s.Threshold
+	case "<=":
+		holds = reading <= s.Threshold
+	case "<":
+		holds = reading < s.Threshold
+	case "==":
+		holds = reading == s.Threshold
+	default:
+		// No condition: count-like tasks take the reading as-is
+		return int(math.Round(reading))
+	}
+
+	if holds {
+		return 1
+	}
+	return 0
+}
+
+// SetTaskAutoSource turns a task into a computed task driven by a signal.
+// An empty signal turns it back into a manual task.
+func (a *App) SetTaskAutoSource(taskID string, signal string, op string, threshold float64) error {
+	switch op {
+	case "", ">=", ">", "<=", "<", "==":
+	default:
+		return errors.New("unsupported comparison: " + op)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID != taskID {
+			continue
+		}
+
+		if t.Composite != nil {
+			return errors.New("task is derived from other tasks")
+		}
+		if signal == "" {
+			a.data.Templates[i].Source = nil
+			a.audit("SetTaskAutoSource", "", taskID, t.Source, nil)
+			return a.saveDataLocked()
+		}
+
+		source := &AutoSource{Signal: signal, Op: op, Threshold: threshold}
+		a.data.Templates[i].Source = source
+		a.audit("SetTaskAutoSource", "", taskID, t.Source, source)
+
+		dates := []string{}
+		for date, readings := range a.data.Signals {
+			if _, ok := readings[signal]; ok {
+				dates = append(dates, date)
+			}
+		}
+		a.recomputeAutoTasksLocked(dates)
+		return a.saveDataLocked()
+	}
+
+	return errors.New("task not found")
+}
+
+// ImportSignals stores readings for a signal keyed by date and recomputes
+// any tasks driven by it
+func (a *App) ImportSignals(signal string, values map[string]float64) error {
+	if signal == "" {
+		return errors.New("signal name is required")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.data.Signals == nil {
+		a.data.Signals = make(map[string]map[string]float64)
+	}
+
+	dates := []string{}
+	for date, value := range values {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			continue
+		}
+		if a.data.Signals[date] == nil {
+			a.data.Signals[date] = make(map[string]float64)
+		}
+		a.data.Signals[date][signal] = value
+		dates = append(dates, date)
+	}
+
+	a.audit("ImportSignals", "", "", nil, signal)
+	a.recomputeAutoTasksLocked(dates)
+	return a.saveDataLocked()
+}
+
+// ImportSignalCSV reads "date,value" rows from a CSV file (e.g. a health or
+// bank export) into a signal. Values on the same date are summed.
+// Returns the number of dates imported.
+func (a *App) ImportSignalCSV(path string, signal string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	values, err := readSignalCSV(f)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := a.ImportSignals(signal, values); err != nil {
+		return 0, err
+	}
+	return len(values), nil
+}
+
+// recomputeAutoTasksLocked refreshes computed task values for the given
+// dates (must hold lock; caller saves)
+func (a *App) recomputeAutoTasksLocked(dates []string) {
+	if a.data.Days == nil {
+		a.data.Days = make(map[string]DayTasks)
+	}
+
+	for _, date := range dates {
+		readings := a.data.Signals[date]
+		for _, task := range a.getTasksForDateLocked(date) {
+			if task.Source == nil {
+				continue
+			}
+			reading, ok := readings[task.Source.Signal]
+			if !ok {
+				continue
+			}
+			if err := a.pullArchivedDayLocked(date); err != nil {
+				println("Error reading archived day:", err.Error())
+				continue
+			}
+			if a.data.Days[date] == nil {
+				a.data.Days[date] = make(DayTasks)
+			}
+			a.data.Days[date][task.ID] = task.Source.evaluate(task, reading)
+		}
+	}
+}
+
+// GetSignals returns imported readings for a date keyed by signal
+func (a *App) GetSignals(date string) map[string]float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := make(map[string]float64)
+	for k, v := range a.data.Signals[date] {
+		result[k] = v
+	}
+	return result
+}
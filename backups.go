@@ -0,0 +1,352 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Default number of automatic backups kept
+const (
+	defaultDailyBackups  = 7
+	defaultWeeklyBackups = 4
+)
+
+// autoBackupPrefix starts the name of each automatic daily backup
+const autoBackupPrefix = "plan-auto-"
+
+// BackupRetention is how many automatic backups are kept: the newest
+// Daily days, plus one per week for the Weekly weeks before that
+type BackupRetention struct {
+	Daily  int `json:"daily"`
+	Weekly int `json:"weekly"`
+}
+
+// BackupInfo describes a backup file in the backups folder
+type BackupInfo struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Kind    string `json:"kind"`    // "daily", or what it was taken before ("delete", "reset", "restore")
+	Created string `json:"created"` // RFC3339
+	Size    int64  `json:"size"`
+}
+
+// backupsDir returns the local folder backups are written to. It sits
+// with the local settings rather than the data, so a bad sync of a shared
+// data folder can't take the backups with it.
+func (a *App) backupsDir() string {
+	return filepath.Join(filepath.Dir(a.settingsPath), "backups")
+}
+
+// retentionLocked returns the configured retention or the defaults (must hold lock)
+func (a *App) retentionLocked() BackupRetention {
+	if a.settings.BackupRetention == nil {
+		return BackupRetention{Daily: defaultDailyBackups, Weekly: defaultWeeklyBackups}
+	}
+	return *a.settings.BackupRetention
+}
+
+// GetBackupRetention returns how many automatic backups are kept
+func (a *App) GetBackupRetention() BackupRetention {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.retentionLocked()
+}
+
+// SetBackupRetention sets how many daily and weekly backups are kept.
+// At least one daily backup is always kept.
+func (a *App) SetBackupRetention(daily int, weekly int) error {
+	if daily < 1 || daily > 365 || weekly < 0 || weekly > 520 {
+		return errors.New("keep between 1 and 365 daily and up to 520 weekly backups")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	retention := BackupRetention{Daily: daily, Weekly: weekly}
+	a.audit("SetBackupRetention", "", "", a.retentionLocked(), retention)
+	a.settings.BackupRetention = &retention
+	if err := a.saveSettingsLocked(); err != nil {
+		return err
+	}
+	return a.pruneBackupsLocked()
+}
+
+// autoBackupLocked copies the saved data into today's backup before the
+// first save of the day, then prunes old backups. Failures are logged but
+// never block the save (must hold lock).
+func (a *App) autoBackupLocked() {
+	if a.settingsPath == "" {
+		return
+	}
+	today := time.Now().Format("2006-01-02")
+	if a.lastAutoBackup == today {
+		return
+	}
+
+	path := filepath.Join(a.backupsDir(), autoBackupPrefix+today+".json")
+	if _, err := os.Stat(path); err == nil {
+		a.lastAutoBackup = today
+		return
+	}
+
+	saved, err := a.store.snapshot()
+	if errors.Is(err, os.ErrNotExist) {
+		// Nothing saved yet
+		return
+	}
+	if err == nil {
+		err = os.MkdirAll(a.backupsDir(), 0755)
+	}
+	if err == nil {
+		err = a.atomicWriteFile(path, saved)
+	}
+	if err != nil {
+		println("Error writing backup:", err.Error())
+		return
+	}
+
+	a.lastAutoBackup = today
+	if err := a.pruneBackupsLocked(); err != nil {
+		println("Error pruning backups:", err.Error())
+	}
+}
+
+// pruneBackupsLocked removes automatic backups outside the retention:
+// the newest Daily are kept, then the newest backup of each of the next
+// Weekly weeks (must hold lock)
+func (a *App) pruneBackupsLocked() error {
+	matches, err := filepath.Glob(filepath.Join(a.backupsDir(), autoBackupPrefix+"*.json"))
+	if err != nil {
+		return err
+	}
+	// Names sort chronologically; newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+
+	retention := a.retentionLocked()
+	weeks := make(map[string]bool)
+	for i, path := range matches {
+		if i < retention.Daily {
+			continue
+		}
+
+		date := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), autoBackupPrefix), ".json")
+		week, err := canonicalWeekStart(date)
+		if err == nil && !weeks[week] && len(weeks) < retention.Weekly {
+			weeks[week] = true
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// preChangeBackupLocked writes a full copy of the data before a
+// destructive change. The change must not go ahead if this fails (must hold lock).
+func (a *App) preChangeBackupLocked(reason string) (string, error) {
+	dir := a.backupsDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	data, err := a.encodeDataLocked()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, "pre-"+reason+"-"+time.Now().Format("20060102-150405")+".json")
+	if err := a.atomicWriteFile(path, data); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ListBackups returns the backups in the backups folder, newest first
+func (a *App) ListBackups() ([]BackupInfo, error) {
+	a.mu.RLock()
+	dir := a.backupsDir()
+	a.mu.RUnlock()
+
+	backups := []BackupInfo{}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return backups, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		kind := "daily"
+		if rest, ok := strings.CutPrefix(name, "pre-"); ok {
+			kind, _, _ = strings.Cut(rest, "-")
+		} else if !strings.HasPrefix(name, autoBackupPrefix) {
+			continue
+		}
+
+		backups = append(backups, BackupInfo{
+			Name:    name,
+			Path:    filepath.Join(dir, name),
+			Kind:    kind,
+			Created: info.ModTime().Format(time.RFC3339),
+			Size:    info.Size(),
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Created > backups[j].Created
+	})
+	return backups, nil
+}
+
+// RestoreBackup replaces the current data with a backup from the backups
+// folder. The current data is backed up first, so a restore can be undone.
+func (a *App) RestoreBackup(name string) error {
+	return a.RestoreBackupWithPassphrase(name, "")
+}
+
+// RestoreBackupWithPassphrase is RestoreBackup for a backup encrypted
+// separately from the current data, which RestoreBackup rejects with
+// errOtherKey
+func (a *App) RestoreBackupWithPassphrase(name string, passphrase string) error {
+	a.mu.RLock()
+	path := filepath.Join(a.backupsDir(), filepath.Base(name))
+	a.mu.RUnlock()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return a.restoreData("RestoreBackup", filepath.Base(name), content, passphrase)
+}
+
+// BackupToFile saves a full copy of the data wherever the user picks in a
+// save dialog. Returns the file path, or "" if the dialog was cancelled.
+func (a *App) BackupToFile() (string, error) {
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Back Up PLAN Data",
+		DefaultFilename: "plan-backup-" + time.Now().Format("2006-01-02") + ".json",
+		Filters:         []runtime.FileFilter{{DisplayName: "PLAN backup (*.json)", Pattern: "*.json"}},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	a.mu.RLock()
+	data, err := a.encodeDataLocked()
+	a.mu.RUnlock()
+	if err != nil {
+		return "", err
+	}
+	if err := a.atomicWriteFile(path, data); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// RestoreFromFile replaces the current data with a backup the user picks
+// in an open dialog, such as one made by BackupToFile on another machine.
+// The current data is backed up first. Returns the file path, or "" if the
+// dialog was cancelled. A file encrypted separately from the current data
+// returns its path with errOtherKey, for RestoreFileWithPassphrase.
+func (a *App) RestoreFromFile() (string, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:   "Restore PLAN Data",
+		Filters: []runtime.FileFilter{{DisplayName: "PLAN backup (*.json)", Pattern: "*.json"}},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if err := a.restoreData("RestoreFromFile", path, content, ""); err != nil {
+		if errors.Is(err, errOtherKey) {
+			return path, err
+		}
+		return "", err
+	}
+	return path, nil
+}
+
+// RestoreFileWithPassphrase restores a file RestoreFromFile returned
+// errOtherKey for, with the passphrase it was encrypted with
+func (a *App) RestoreFileWithPassphrase(path string, passphrase string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return a.restoreData("RestoreFromFile", path, content, passphrase)
+}
+
+// restoreData validates a saved data file and swaps it in for the current
+// data, after backing the current data up. passphrase opens files
+// encrypted separately; "" uses the current key.
+func (a *App) restoreData(method, source string, content []byte, passphrase string) error {
+	a.mu.RLock()
+	content, err := a.openWithPassphraseLocked(content, passphrase)
+	a.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	restored, quarantined, ok := decodePlannerData(content)
+	if !ok {
+		return errors.New("file is not a PLAN data file")
+	}
+	if restored.Version > schemaVersion {
+		return errNewerData
+	}
+	for _, t := range restored.Templates {
+		if t.ID == "" || t.Name == "" {
+			return errors.New("file has a task without an ID or name")
+		}
+	}
+
+	a.mu.Lock()
+	if _, err := a.preChangeBackupLocked("restore"); err != nil {
+		a.mu.Unlock()
+		return err
+	}
+
+	a.rememberLocked(method)
+	a.audit(method, "", "", nil, source)
+	if restored.Days == nil {
+		restored.Days = make(map[string]DayTasks)
+	}
+	if restored.ExportHistory == nil {
+		restored.ExportHistory = make(map[string]string)
+	}
+	if a.data.Stamps != nil {
+		// Restored values are new writes as far as other devices are concerned
+		restored.Stamps = a.data.Stamps
+	}
+	a.data = restored
+	if len(quarantined) > 0 {
+		a.quarantineLocked(quarantined)
+	}
+	a.migrateDataLocked()
+	err = a.saveDataLocked()
+	a.mu.Unlock()
+
+	a.emitDataChanged("")
+	a.refreshMenu()
+	return err
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bulkTaskLine is one parsed line of AddTasksBulk input
+type bulkTaskLine struct {
+	name     string
+	taskType string
+	unit     string
+	target   int
+}
+
+// parseBulkTaskLine parses "Name", "Name:count" or "Name:target=N". Options
+// after the name are separated by colons: a task type, "target=N" (implies
+// a count task) or "unit=U".
+func parseBulkTaskLine(line string) (bulkTaskLine, error) {
+	parts := strings.Split(line, ":")
+	parsed := bulkTaskLine{name: strings.TrimSpace(parts[0]), taskType: "binary"}
+	if parsed.name == "" {
+		return parsed, errors.New("missing task name")
+	}
+
+	typeSet := false
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		key, value, hasValue := strings.Cut(part, "=")
+		switch {
+		case part == "":
+			continue
+		case hasValue && key == "target":
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n < 0 {
+				return parsed, fmt.Errorf("invalid target %q", value)
+			}
+			parsed.target = n
+			if !typeSet {
+				parsed.taskType = "count"
+			}
+		case hasValue && key == "unit":
+			parsed.unit = strings.TrimSpace(value)
+		case !hasValue && isValidTaskType(part):
+			parsed.taskType = part
+			typeSet = true
+		default:
+			return parsed, fmt.Errorf("unknown option %q", part)
+		}
+	}
+
+	return parsed, nil
+}
+
+// AddTasksBulk creates tasks from pasted text, one per line, in order.
+// Lines look like "Meditate", "Pushups:count", "Steps:target=8000" or
+// "Run:duration:unit=min". Blank lines and lines starting with # are
+// ignored. Nothing is created if any line is invalid.
+func (a *App) AddTasksBulk(text string) ([]TaskTemplate, error) {
+	lines := []bulkTaskLine{}
+	for i, raw := range strings.Split(text, "\n") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || strings.HasPrefix(raw, "#") {
+			continue
+		}
+		parsed, err := parseBulkTaskLine(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		lines = append(lines, parsed)
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("no tasks to add")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	created := make([]TaskTemplate, 0, len(lines))
+	for _, line := range lines {
+		task := a.addTaskLocked(line.name, line.taskType, line.unit)
+		if line.target > 0 {
+			a.data.Templates[len(a.data.Templates)-1].Target = line.target
+			task.Target = line.target
+		}
+		a.audit("AddTasksBulk", "", task.ID, nil, task.Name)
+		created = append(created, task)
+	}
+
+	return created, a.saveDataLocked()
+}
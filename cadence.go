@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// isMonthlyTask reports whether a task occurs once a month instead of daily
+func isMonthlyTask(t TaskTemplate) bool {
+	return t.DayOfMonth > 0
+}
+
+// occursOnMonthDay reports whether a monthly task set for dayOfMonth falls on
+// date. Days past the end of a short month fall on its last day.
+func occursOnMonthDay(dayOfMonth int, date string) bool {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return false
+	}
+	lastDay := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if dayOfMonth > lastDay {
+		dayOfMonth = lastDay
+	}
+	return d.Day() == dayOfMonth
+}
+
+// SetTaskMonthly makes a task occur once a month on the given day (1-31).
+// 0 makes it a daily task again. Monthly tasks can't also have a weekly quota
+// or be limited to weekdays or weekends.
+func (a *App) SetTaskMonthly(id string, dayOfMonth int) error {
+	if dayOfMonth < 0 || dayOfMonth > 31 {
+		return errors.New("day of month must be between 0 and 31")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			a.data.Templates[i].DayOfMonth = dayOfMonth
+			if dayOfMonth > 0 {
+				a.data.Templates[i].TimesPerWeek = 0
+				a.data.Templates[i].OnDays = ""
+			}
+			a.audit("SetTaskMonthly", "", id, t.DayOfMonth, dayOfMonth)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("task not found")
+}
+
+// Values for TaskTemplate.OnDays
+const (
+	onWeekdays = "weekdays"
+	onWeekends = "weekends"
+)
+
+// occursOnDayKind reports whether a task limited to weekdays or weekends
+// applies on date. Tasks without a limit apply every day.
+func occursOnDayKind(onDays string, date string) bool {
+	if onDays == "" {
+		return true
+	}
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return false
+	}
+	weekend := d.Weekday() == time.Saturday || d.Weekday() == time.Sunday
+	return weekend == (onDays == onWeekends)
+}
+
+// SetTaskOnDays limits a task to "weekdays" or "weekends"; "" shows it every
+// day again. Days a task doesn't apply to don't count in reports or streaks.
+func (a *App) SetTaskOnDays(id string, onDays string) error {
+	if onDays != "" && onDays != onWeekdays && onDays != onWeekends {
+		return errors.New(`days must be "weekdays", "weekends" or empty`)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			if onDays != "" && isMonthlyTask(t) {
+				return errors.New("monthly tasks can't be limited to weekdays or weekends")
+			}
+			a.data.Templates[i].OnDays = onDays
+			a.audit("SetTaskOnDays", "", id, t.OnDays, onDays)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("task not found")
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// StreakCertificate is the content of a shareable streak certificate
+type StreakCertificate struct {
+	TaskName string `json:"taskName"`
+	Days     int    `json:"days"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+// headline is the certificate's main line
+func (c StreakCertificate) headline() string {
+	unit := "consecutive days"
+	if c.Days == 1 {
+		unit = "day"
+	}
+	return fmt.Sprintf("%d %s of %s", c.Days, unit, c.TaskName)
+}
+
+// period formats the streak's dates as "Jan 1, 2025 – Apr 10, 2025"
+func (c StreakCertificate) period() string {
+	from, _ := time.Parse("2006-01-02", c.From)
+	to, _ := time.Parse("2006-01-02", c.To)
+	return from.Format("Jan 2, 2006") + " – " + to.Format("Jan 2, 2006")
+}
+
+// ExportStreakCertificate renders a certificate for a task's current
+// streak. The format follows the path's extension: .pdf or .svg. An empty
+// path saves a PDF in the export folder. Returns the file path.
+func (a *App) ExportStreakCertificate(taskID string, path string) (string, error) {
+	a.mu.RLock()
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		a.mu.RUnlock()
+		return "", errors.New("task not found")
+	}
+
+	now := time.Now()
+	through := now
+	if _, doneToday := a.currentStreakLocked(task, now); !doneToday {
+		through = now.AddDate(0, 0, -1)
+	}
+	to := through.Format("2006-01-02")
+	days, from := a.streakSpanLocked(task, to)
+	a.mu.RUnlock()
+
+	if days == 0 {
+		return "", errors.New("task has no current streak")
+	}
+	cert := StreakCertificate{TaskName: task.Name, Days: days, From: from, To: to}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if path == "" {
+		ext = ".pdf"
+	}
+
+	var content []byte
+	switch ext {
+	case ".pdf":
+		content = renderCertificatePDF(cert)
+	case ".svg":
+		content = renderCertificateSVG(cert)
+	default:
+		return "", errors.New("certificate must be saved as .pdf or .svg")
+	}
+
+	if path == "" {
+		return a.writeExportFile(fmt.Sprintf("plan-streak-%s-%d-days.pdf", taskID, days), content)
+	}
+	if err := a.atomicWriteFile(path, content); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// renderCertificateSVG draws the certificate as a landscape SVG image
+func renderCertificateSVG(c StreakCertificate) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="1200" height="850" viewBox="0 0 1200 850">
+<rect width="1200" height="850" fill="#fffdf7"/>
+<rect x="30" y="30" width="1140" height="790" fill="none" stroke="#c9a227" stroke-width="6"/>
+<rect x="48" y="48" width="1104" height="754" fill="none" stroke="#c9a227" stroke-width="2"/>
+<g font-family="Georgia, serif" text-anchor="middle" fill="#222">
+<text x="600" y="230" font-size="64">Certificate of Consistency</text>
+`)
+	fmt.Fprintf(&buf, "<text x=\"600\" y=\"420\" font-size=\"52\" font-weight=\"bold\">%s</text>\n", html.EscapeString(c.headline()))
+	fmt.Fprintf(&buf, "<text x=\"600\" y=\"500\" font-size=\"32\" fill=\"#555\">%s</text>\n", html.EscapeString(c.period()))
+	buf.WriteString(`<text x="600" y="720" font-size="24" fill="#888">PLAN</text>
+</g>
+</svg>
+`)
+	return buf.Bytes()
+}
+
+// renderCertificatePDF writes the certificate as a one-page landscape A4 PDF
+// using the built-in Helvetica fonts, so no font files are needed
+func renderCertificatePDF(c StreakCertificate) []byte {
+	const width, height = 842, 595
+
+	// Helvetica averages about half an em per character; close enough to centre
+	centred := func(font string, size float64, y float64, text string) string {
+		x := (width - 0.5*size*float64(len([]rune(text)))) / 2
+		return fmt.Sprintf("BT /%s %.0f Tf %.1f %.1f Td (%s) Tj ET\n", font, size, x, y, pdfString(text))
+	}
+
+	var content strings.Builder
+	content.WriteString("0.79 0.64 0.15 RG 4 w 24 24 794 547 re S 1 w 36 36 770 523 re S\n")
+	content.WriteString("0.13 g\n")
+	content.WriteString(centred("F2", 40, 430, "Certificate of Consistency"))
+	content.WriteString(centred("F2", 30, 300, c.headline()))
+	content.WriteString("0.33 g\n")
+	content.WriteString(centred("F1", 20, 250, c.period()))
+	content.WriteString("0.53 g\n")
+	content.WriteString(centred("F1", 14, 80, "PLAN"))
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Contents 4 0 R /Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> >>", width, height),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	}
+
+	return pdfFile(objects)
+}
+
+// pdfFile writes a PDF from its objects, numbered from 1 in order; the
+// first must be the catalog
+func pdfFile(objects []string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// pdfString escapes text for a PDF string literal in WinAnsi encoding.
+// Characters outside it become "?".
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '–':
+			b.WriteByte(0x96)
+		case r == '…':
+			b.WriteByte(0x85)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// changeHistoryMaxFileSize is the size at which the active change
+	// history file is rotated
+	changeHistoryMaxFileSize = 1024 * 1024
+	// changeHistoryMaxFiles is the number of change history files kept,
+	// including the active one
+	changeHistoryMaxFiles = 3
+)
+
+// ChangeEntry is one day value a save changed. Unlike the audit log, which
+// records API calls, it's worked out from the data itself, so it also
+// catches values changed by syncs, merges, restores and other processes.
+// Lines are sealed like the journal's while encryption is on.
+type ChangeEntry struct {
+	Time   string `json:"time"`            // RFC3339 time of the save
+	Device string `json:"device"`          // Device that saved it
+	Cause  string `json:"cause,omitempty"` // Last audited method before the save
+	Date   string `json:"date"`
+	TaskID string `json:"taskId"`
+	Old    *int   `json:"old,omitempty"` // nil when the value was added
+	New    *int   `json:"new,omitempty"` // nil when the value was removed
+}
+
+// changeHistoryPath returns the path of the active change history file
+func (a *App) changeHistoryPath() string {
+	return filepath.Join(filepath.Dir(a.dataPath), "changes.jsonl")
+}
+
+// rotatedChangeHistoryPath returns the path of the n-th rotated change
+// history file
+func (a *App) rotatedChangeHistoryPath(n int) string {
+	return filepath.Join(filepath.Dir(a.dataPath), fmt.Sprintf("changes.%d.jsonl", n))
+}
+
+// GetChangeHistory returns how a day's values changed, save by save,
+// oldest first
+func (a *App) GetChangeHistory(date string) ([]ChangeEntry, error) {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return nil, errors.New("invalid date")
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	entries := []ChangeEntry{}
+	if a.dataPath == "" {
+		return entries, nil
+	}
+	for _, path := range a.changeHistoryPaths() {
+		lines, err := a.readSealedLinesLocked(path)
+		if err != nil {
+			continue
+		}
+		for _, line := range lines {
+			var entry ChangeEntry
+			if err := json.Unmarshal(line, &entry); err != nil {
+				continue
+			}
+			if entry.Date == date {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// changeHistoryPaths returns the change history files, oldest first
+func (a *App) changeHistoryPaths() []string {
+	paths := []string{}
+	for n := changeHistoryMaxFiles - 1; n >= 1; n-- {
+		paths = append(paths, a.rotatedChangeHistoryPath(n))
+	}
+	return append(paths, a.changeHistoryPath())
+}
+
+// sealLineLocked encodes a line of the change history or audit log for the
+// file: sealed like data.json and base64-encoded when encryption is on
+// (must hold lock)
+func (a *App) sealLineLocked(line []byte) ([]byte, error) {
+	if a.dataKey == nil {
+		return line, nil
+	}
+	sealed, err := a.sealLocked(line)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+// readSealedLinesLocked reads a change history or audit file's lines as
+// JSON, opening sealed ones. Lines that can't be opened are left out (must
+// hold lock).
+func (a *App) readSealedLinesLocked(path string) ([][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	lines := [][]byte{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := append([]byte{}, scanner.Bytes()...)
+		if len(line) > 0 && line[0] != '{' {
+			sealed, err := base64.StdEncoding.DecodeString(string(line))
+			if err != nil {
+				continue
+			}
+			if line, err = a.openLocked(sealed); err != nil {
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// readSealedFilesLocked reads every change history and audit file, before
+// the key their lines were sealed with changes (must hold lock)
+func (a *App) readSealedFilesLocked() map[string][][]byte {
+	files := make(map[string][][]byte)
+	for _, path := range append(a.changeHistoryPaths(), a.auditPaths()...) {
+		if lines, err := a.readSealedLinesLocked(path); err == nil {
+			files[path] = lines
+		}
+	}
+	return files
+}
+
+// rewriteSealedFilesLocked writes the files read by readSealedFilesLocked
+// again with the current key, after encryption is turned on or off (must
+// hold lock)
+func (a *App) rewriteSealedFilesLocked(files map[string][][]byte) error {
+	for path, lines := range files {
+		var buf bytes.Buffer
+		for _, line := range lines {
+			sealed, err := a.sealLineLocked(line)
+			if err != nil {
+				return err
+			}
+			buf.Write(sealed)
+			buf.WriteByte('\n')
+		}
+		if err := a.atomicWriteFile(path, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreSealedFilesLocked writes the files back with the key put back
+// after turning encryption on or off failed (must hold lock)
+func (a *App) restoreSealedFilesLocked(files map[string][][]byte) {
+	if err := a.rewriteSealedFilesLocked(files); err != nil {
+		println("Error restoring the change history and audit log:", err.Error())
+	}
+}
+
+// rememberSavedDaysLocked takes the day values the next save is compared
+// with, after data is loaded or replaced by something that isn't a change
+// (must hold lock)
+func (a *App) rememberSavedDaysLocked() {
+	a.savedDays = make(map[string]DayTasks, len(a.data.Days))
+	for date, tasks := range a.data.Days {
+		a.savedDays[date] = copyDayTasks(tasks)
+	}
+}
+
+// recordChangesLocked appends the day values that changed since the last
+// save to the change history, with the method that caused them. Failures
+// are logged but never block the save (must hold lock).
+func (a *App) recordChangesLocked(cause string) {
+	entries := []ChangeEntry{}
+	add := func(date, id string, old, value int, had, has bool) {
+		entry := ChangeEntry{Date: date, TaskID: id}
+		if had {
+			entry.Old = &old
+		}
+		if has {
+			entry.New = &value
+		}
+		entries = append(entries, entry)
+	}
+	for date, tasks := range a.data.Days {
+		saved := a.savedDays[date]
+		for id, value := range tasks {
+			if old, had := saved[id]; !had || old != value {
+				add(date, id, old, value, had, true)
+			}
+		}
+		for id, old := range saved {
+			if _, has := tasks[id]; !has {
+				add(date, id, old, 0, true, false)
+			}
+		}
+	}
+	for date, saved := range a.savedDays {
+		if _, ok := a.data.Days[date]; ok {
+			continue
+		}
+		for id, old := range saved {
+			add(date, id, old, 0, true, false)
+		}
+	}
+	if len(entries) == 0 {
+		return
+	}
+	a.rememberSavedDaysLocked()
+	if a.dataPath == "" {
+		return
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Date != entries[j].Date {
+			return entries[i].Date < entries[j].Date
+		}
+		return entries[i].TaskID < entries[j].TaskID
+	})
+	now, device := time.Now().Format(time.RFC3339), a.deviceIDLocked()
+	lines := []byte{}
+	for _, entry := range entries {
+		entry.Time, entry.Device, entry.Cause = now, device, cause
+		line, err := json.Marshal(entry)
+		if err == nil {
+			line, err = a.sealLineLocked(line)
+		}
+		if err != nil {
+			continue
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+
+	a.rotateChangeHistoryLocked()
+	f, err := os.OpenFile(a.changeHistoryPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		println("Error opening change history:", err.Error())
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(lines); err != nil {
+		println("Error writing change history:", err.Error())
+	}
+}
+
+// rotateChangeHistoryLocked shifts change history files when the active
+// one grows too large (must hold lock)
+func (a *App) rotateChangeHistoryLocked() {
+	info, err := os.Stat(a.changeHistoryPath())
+	if err != nil || info.Size() < changeHistoryMaxFileSize {
+		return
+	}
+
+	os.Remove(a.rotatedChangeHistoryPath(changeHistoryMaxFiles - 1))
+	for n := changeHistoryMaxFiles - 2; n >= 1; n-- {
+		os.Rename(a.rotatedChangeHistoryPath(n), a.rotatedChangeHistoryPath(n+1))
+	}
+	os.Rename(a.changeHistoryPath(), a.rotatedChangeHistoryPath(1))
+}
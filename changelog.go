@@ -0,0 +1,119 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ChangeNote describes a user-facing change shipped in a given version.
+// Notes with an OptIn key refer to capabilities the user has to enable.
+type ChangeNote struct {
+	Version string `json:"version"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	OptIn   string `json:"optIn,omitempty"`
+}
+
+// changelog lists change notes, oldest first.
+// Append a note here whenever a release adds something users should know about.
+var changelog = []ChangeNote{
+	{
+		Version: "1.1.0",
+		Title:   "Audit log",
+		Body:    "Every change to your tasks and days is now recorded, so you can see when a past value was edited.",
+	},
+	{
+		Version: "1.2.0",
+		Title:   "Task reminders",
+		Body:    "Set a reminder time on a task and you'll be notified if it isn't done by then.",
+	},
+	{
+		Version: "1.3.0",
+		Title:   "SQLite storage",
+		Body:    "Years of history can be kept in an SQLite database instead of data.json, so each change saves only what changed. Switch storage in Settings; your current data.json is kept as a copy.",
+	},
+}
+
+// latestChangeVersion returns the newest version in the changelog
+func latestChangeVersion() string {
+	if len(changelog) == 0 {
+		return ""
+	}
+	return changelog[len(changelog)-1].Version
+}
+
+// compareVersions compares dotted numeric versions ("1.2.0").
+// Returns -1, 0 or 1 like strings.Compare.
+func compareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+	}
+	return 0
+}
+
+// GetUnseenChanges returns change notes newer than the last version the user saw
+func (a *App) GetUnseenChanges() []ChangeNote {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	unseen := []ChangeNote{}
+	for _, note := range changelog {
+		if compareVersions(note.Version, a.data.SeenChangesVersion) > 0 {
+			unseen = append(unseen, note)
+		}
+	}
+	return unseen
+}
+
+// MarkChangesSeen records that the user has seen all notes up to version
+func (a *App) MarkChangesSeen(version string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if compareVersions(version, a.data.SeenChangesVersion) <= 0 {
+		return nil
+	}
+
+	a.data.SeenChangesVersion = version
+	return a.saveDataLocked()
+}
+
+// SetFeatureOptIn records the user's choice for an opt-in capability
+func (a *App) SetFeatureOptIn(feature string, enabled bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.data.OptIns == nil {
+		a.data.OptIns = make(map[string]bool)
+	}
+
+	a.audit("SetFeatureOptIn", "", "", a.data.OptIns[feature], enabled)
+	a.data.OptIns[feature] = enabled
+	return a.saveDataLocked()
+}
+
+// GetFeatureOptIns returns the user's opt-in choices keyed by feature
+func (a *App) GetFeatureOptIns() map[string]bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := make(map[string]bool)
+	for k, v := range a.data.OptIns {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Chart metrics
+const (
+	chartMetricValue      = "value"      // Sum of the tasks' recorded values
+	chartMetricCompletion = "completion" // Percentage of the tasks that succeeded
+	chartMetricScore      = "score"      // Day score; tasks are ignored
+)
+
+// maxChartConfigs bounds how many custom charts can be saved
+const maxChartConfigs = 50
+
+// ChartConfig is a custom chart built in the frontend
+type ChartConfig struct {
+	Name    string   `json:"name"`
+	TaskIDs []string `json:"taskIds"` // Empty for every task
+	Metric  string   `json:"metric"`  // "value", "completion" or "score"
+	Days    int      `json:"days"`    // Days shown, ending on the last day; 0 for the whole range
+	Type    string   `json:"type"`    // "bar" or "line"
+}
+
+// chartPoint is one day of a rendered chart
+type chartPoint struct {
+	Label  string
+	Value  float64
+	X, Y   float64 // Position in the SVG viewBox
+	Height float64
+}
+
+// chartView is a chart ready for chartTemplate
+type chartView struct {
+	ChartConfig
+	Points   []chartPoint
+	Max      float64
+	BarWidth float64
+	Line     string // SVG polyline points
+}
+
+// SaveChartConfig saves a custom chart under name, replacing any chart of
+// the same name. Saved charts are drawn in HTML exports.
+func (a *App) SaveChartConfig(name string, config ChartConfig) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if config.Metric != chartMetricValue && config.Metric != chartMetricCompletion && config.Metric != chartMetricScore {
+		return errors.New(`metric must be "value", "completion" or "score"`)
+	}
+	if config.Type != "bar" && config.Type != "line" {
+		return errors.New(`chart type must be "bar" or "line"`)
+	}
+	if config.Days < 0 || config.Days > 366 {
+		return errors.New("days must be between 0 and 366")
+	}
+	config.Name = name
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, id := range config.TaskIDs {
+		if _, ok := a.findTemplateLocked(id); !ok {
+			return errors.New("task not found")
+		}
+	}
+
+	for i, c := range a.data.Charts {
+		if c.Name == name {
+			a.audit("SaveChartConfig", "", "", c, config)
+			a.data.Charts[i] = config
+			return a.saveDataLocked()
+		}
+	}
+	if len(a.data.Charts) >= maxChartConfigs {
+		return fmt.Errorf("at most %d charts can be saved", maxChartConfigs)
+	}
+	a.audit("SaveChartConfig", "", "", nil, config)
+	a.data.Charts = append(a.data.Charts, config)
+	return a.saveDataLocked()
+}
+
+// ListChartConfigs returns the saved custom charts by name
+func (a *App) ListChartConfigs() []ChartConfig {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	charts := append([]ChartConfig{}, a.data.Charts...)
+	sort.Slice(charts, func(i, j int) bool {
+		return charts[i].Name < charts[j].Name
+	})
+	return charts
+}
+
+// DeleteChartConfig removes a saved chart
+func (a *App) DeleteChartConfig(name string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, c := range a.data.Charts {
+		if c.Name == name {
+			a.audit("DeleteChartConfig", "", "", c, nil)
+			a.data.Charts = append(a.data.Charts[:i], a.data.Charts[i+1:]...)
+			return a.saveDataLocked()
+		}
+	}
+	return errors.New("chart not found")
+}
+
+// chartViewLocked computes a chart over the end of an export table's range
+// (must hold lock)
+func (a *App) chartViewLocked(config ChartConfig, table exportTable) chartView {
+	view := chartView{ChartConfig: config}
+
+	dates := table.Dates
+	if config.Days > 0 && config.Days < len(dates) {
+		dates = dates[len(dates)-config.Days:]
+	}
+	selected := make(map[string]bool)
+	for _, id := range config.TaskIDs {
+		selected[id] = true
+	}
+
+	for _, date := range dates {
+		point := chartPoint{Label: date}
+		switch config.Metric {
+		case chartMetricScore:
+			point.Value = table.Scores[date]
+		default:
+			total, done := 0, 0
+			for _, task := range table.Tasks {
+				value, applies := table.Values[date][task.ID]
+				if !applies || (len(selected) > 0 && !selected[task.ID]) {
+					continue
+				}
+				total++
+				if taskSucceeded(task, date, value) {
+					done++
+				}
+				if config.Metric == chartMetricValue {
+					point.Value += float64(value)
+				}
+			}
+			if config.Metric == chartMetricCompletion && total > 0 {
+				point.Value = float64(done) / float64(total) * 100
+			}
+		}
+		view.Max = max(view.Max, point.Value)
+		view.Points = append(view.Points, point)
+	}
+	if len(view.Points) == 0 {
+		return view
+	}
+
+	// Lay out in a 100x40 viewBox
+	view.BarWidth = 100 / float64(len(view.Points))
+	var line []string
+	for i := range view.Points {
+		p := &view.Points[i]
+		if view.Max > 0 {
+			p.Height = p.Value / view.Max * 40
+		}
+		p.X = float64(i) * view.BarWidth
+		p.Y = 40 - p.Height
+		line = append(line, fmt.Sprintf("%.2f,%.2f", p.X+view.BarWidth/2, p.Y))
+	}
+	view.Line = strings.Join(line, " ")
+	return view
+}
+
+// renderChartsLocked draws the saved charts for an export (must hold lock)
+func (a *App) renderChartsLocked(table exportTable) ([]byte, error) {
+	if len(a.data.Charts) == 0 {
+		return nil, nil
+	}
+	views := []chartView{}
+	for _, config := range a.data.Charts {
+		views = append(views, a.chartViewLocked(config, table))
+	}
+
+	var buf bytes.Buffer
+	err := chartTemplate.Execute(&buf, views)
+	return buf.Bytes(), err
+}
+
+// chartDate shortens a chart point's date for its tooltip
+func chartDate(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return t.Format("Mon Jan 2")
+}
+
+// chartTemplate draws each chart as an inline SVG
+var chartTemplate = template.Must(template.New("charts").Funcs(template.FuncMap{"chartDate": chartDate}).Parse(`<h2>Charts</h2>
+{{range .}}<figure>
+<figcaption>{{.Name}} <small>({{.Metric}}, max {{printf "%.0f" .Max}})</small></figcaption>
+<svg viewBox="0 0 100 40" preserveAspectRatio="none" width="100%" height="120">
+{{if eq .Type "line"}}<polyline points="{{.Line}}" fill="none" stroke="#4caf7d" stroke-width="0.5"/>
+{{else}}{{$w := .BarWidth}}{{range .Points}}<rect x="{{printf "%.2f" .X}}" y="{{printf "%.2f" .Y}}" width="{{printf "%.2f" $w}}" height="{{printf "%.2f" .Height}}" fill="#4caf7d"><title>{{chartDate .Label}}: {{printf "%.0f" .Value}}</title></rect>
+{{end}}{{end}}</svg>
+</figure>
+{{end}}`))
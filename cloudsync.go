@@ -0,0 +1,457 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Cloud sync providers
+const (
+	syncDropbox     = "dropbox"
+	syncGoogleDrive = "gdrive"
+)
+
+const (
+	// cloudSyncFile is the name of the data file in cloud storage
+	cloudSyncFile = "plan.json"
+	// oauthTimeout is how long ConnectCloudSync waits for the browser sign-in
+	oauthTimeout = 5 * time.Minute
+)
+
+// oauthEndpoints are a cloud provider's OAuth addresses
+type oauthEndpoints struct {
+	authURL  string
+	tokenURL string
+	scope    string
+	extra    url.Values // Authorize parameters that ask for a refresh token
+}
+
+// cloudEndpoints lists the OAuth endpoints of each cloud provider
+var cloudEndpoints = map[string]oauthEndpoints{
+	syncDropbox: {
+		authURL:  "https://www.dropbox.com/oauth2/authorize",
+		tokenURL: "https://api.dropboxapi.com/oauth2/token",
+		extra:    url.Values{"token_access_type": {"offline"}},
+	},
+	syncGoogleDrive: {
+		authURL:  "https://accounts.google.com/o/oauth2/v2/auth",
+		tokenURL: "https://oauth2.googleapis.com/token",
+		scope:    "https://www.googleapis.com/auth/drive.appdata",
+		extra:    url.Values{"access_type": {"offline"}, "prompt": {"consent"}},
+	},
+}
+
+// cloudCredential is what cloud sync keeps with the credentials
+type cloudCredential struct {
+	RefreshToken string `json:"refreshToken"`
+	ClientSecret string `json:"clientSecret,omitempty"`
+}
+
+// tokenResponse is an OAuth token endpoint's answer
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+	Description  string `json:"error_description"`
+}
+
+// ConnectCloudSync signs in to Dropbox ("dropbox") or Google Drive
+// ("gdrive") in the browser and turns on sync with it. clientID (and for
+// Google Drive clientSecret) come from an app registered with the
+// provider, with http://127.0.0.1 allowed as a redirect address. Only a
+// refresh token is kept, with the credentials. Google Drive data goes in
+// the app's hidden folder; Dropbox data in /plan.json.
+func (a *App) ConnectCloudSync(provider, clientID, clientSecret string) error {
+	endpoints, ok := cloudEndpoints[provider]
+	if !ok {
+		return errors.New(`provider must be "dropbox" or "gdrive"`)
+	}
+	if clientID == "" {
+		return errors.New("a client ID is required")
+	}
+	if provider == syncGoogleDrive && clientSecret == "" {
+		return errors.New("Google Drive needs the client secret too")
+	}
+	if a.ctx == nil {
+		return errors.New("signing in needs the app window")
+	}
+
+	verifier := randomToken()
+	challenge := sha256.Sum256([]byte(verifier))
+	state := randomToken()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	redirect := fmt.Sprintf("http://127.0.0.1:%d/", listener.Addr().(*net.TCPAddr).Port)
+
+	codes := make(chan string, 1)
+	failures := make(chan error, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != state {
+			http.Error(w, "Unexpected sign-in response", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if code := q.Get("code"); code != "" {
+			fmt.Fprint(w, "<p>PLAN is connected. You can close this window.</p>")
+			codes <- code
+			return
+		}
+		fmt.Fprint(w, "<p>Sign-in was cancelled. You can close this window.</p>")
+		failures <- fmt.Errorf("sign-in failed: %s", q.Get("error"))
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	params := url.Values{
+		"client_id":             {clientID},
+		"response_type":         {"code"},
+		"redirect_uri":          {redirect},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	if endpoints.scope != "" {
+		params.Set("scope", endpoints.scope)
+	}
+	for k, v := range endpoints.extra {
+		params[k] = v
+	}
+	runtime.BrowserOpenURL(a.ctx, endpoints.authURL+"?"+params.Encode())
+
+	var code string
+	select {
+	case code = <-codes:
+	case err := <-failures:
+		return err
+	case <-time.After(oauthTimeout):
+		return errors.New("sign-in timed out")
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirect},
+		"client_id":     {clientID},
+		"code_verifier": {verifier},
+	}
+	if clientSecret != "" {
+		form.Set("client_secret", clientSecret)
+	}
+	token, err := postToken(&http.Client{Timeout: 30 * time.Second}, endpoints.tokenURL, form)
+	if err != nil {
+		return err
+	}
+	if token.RefreshToken == "" {
+		return errors.New("the provider didn't grant offline access")
+	}
+	credential, err := json.Marshal(cloudCredential{RefreshToken: token.RefreshToken, ClientSecret: clientSecret})
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	if err := a.setCredentialLocked(syncCredential, string(credential)); err != nil {
+		a.mu.Unlock()
+		return err
+	}
+	a.audit("ConnectCloudSync", "", "", a.syncURLLocked(), provider)
+	a.settings.Sync = &SyncSettings{Provider: provider, ClientID: clientID}
+	a.cloudSession = nil
+	err = a.saveSettingsLocked()
+	a.mu.Unlock()
+
+	a.requestSync()
+	return err
+}
+
+// randomToken returns a random URL-safe string for PKCE and OAuth state
+func randomToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// postToken calls an OAuth token endpoint
+func postToken(client *http.Client, tokenURL string, form url.Values) (tokenResponse, error) {
+	resp, err := client.PostForm(tokenURL, form)
+	if err != nil {
+		return tokenResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return tokenResponse{}, fmt.Errorf("unreadable token response (%s)", resp.Status)
+	}
+	if token.Error != "" {
+		if token.Description != "" {
+			return tokenResponse{}, fmt.Errorf("%s: %s", token.Error, token.Description)
+		}
+		return tokenResponse{}, errors.New(token.Error)
+	}
+	if token.AccessToken == "" {
+		return tokenResponse{}, fmt.Errorf("no access token in the response (%s)", resp.Status)
+	}
+	return token, nil
+}
+
+// oauthSession makes requests with an access token, refreshing it as needed
+type oauthSession struct {
+	tokenURL   string
+	clientID   string
+	credential cloudCredential
+	client     *http.Client
+
+	access string
+	expiry time.Time
+}
+
+// newOAuthSessionLocked reads the saved refresh token for a cloud provider (must hold lock)
+func (a *App) newOAuthSessionLocked(s *SyncSettings) (*oauthSession, error) {
+	var credential cloudCredential
+	if err := json.Unmarshal([]byte(a.credentialLocked(syncCredential)), &credential); err != nil || credential.RefreshToken == "" {
+		return nil, errors.New("sign in to " + s.Provider + " again")
+	}
+	return &oauthSession{
+		tokenURL:   cloudEndpoints[s.Provider].tokenURL,
+		clientID:   s.ClientID,
+		credential: credential,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// do sends req with a fresh access token
+func (s *oauthSession) do(req *http.Request) (*http.Response, error) {
+	if s.access == "" || time.Now().After(s.expiry) {
+		form := url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {s.credential.RefreshToken},
+			"client_id":     {s.clientID},
+		}
+		if s.credential.ClientSecret != "" {
+			form.Set("client_secret", s.credential.ClientSecret)
+		}
+		token, err := postToken(s.client, s.tokenURL, form)
+		if err != nil {
+			return nil, err
+		}
+		s.access = token.AccessToken
+		// Refresh a minute early rather than fail a request
+		s.expiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.access)
+	return s.client.Do(req)
+}
+
+// dropboxProvider syncs with /plan.json in Dropbox, using file revisions
+// to detect changes made by other devices
+type dropboxProvider struct {
+	session *oauthSession
+}
+
+// download fetches the data file
+func (p *dropboxProvider) download() ([]byte, string, error) {
+	req, err := http.NewRequest(http.MethodPost, "https://content.dropboxapi.com/2/files/download", nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Dropbox-API-Arg", `{"path":"/`+cloudSyncFile+`"}`)
+	resp, err := p.session.do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode == http.StatusConflict && strings.Contains(string(body), "not_found") {
+		return nil, "", errRemoteMissing
+	}
+	if resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("Dropbox answered %s", resp.Status)
+	}
+	var meta struct {
+		Rev string `json:"rev"`
+	}
+	if err := json.Unmarshal([]byte(resp.Header.Get("Dropbox-API-Result")), &meta); err != nil {
+		return nil, "", err
+	}
+	return body, meta.Rev, nil
+}
+
+// upload writes the data file if it's still at revision tag
+func (p *dropboxProvider) upload(data []byte, tag string) (string, error) {
+	mode := any("add")
+	if tag != "" {
+		mode = map[string]string{".tag": "update", "update": tag}
+	}
+	arg, err := json.Marshal(map[string]any{"path": "/" + cloudSyncFile, "mode": mode, "autorename": false, "mute": true})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "https://content.dropboxapi.com/2/files/upload", bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Dropbox-API-Arg", string(arg))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := p.session.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusConflict && strings.Contains(string(body), "conflict") {
+		return "", errRemoteChanged
+	}
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("Dropbox answered %s", resp.Status)
+	}
+	var meta struct {
+		Rev string `json:"rev"`
+	}
+	if err := json.Unmarshal(body, &meta); err != nil {
+		return "", err
+	}
+	return meta.Rev, nil
+}
+
+// driveProvider syncs with plan.json in Google Drive's hidden app folder,
+// using file versions to detect changes made by other devices. Drive
+// can't make an upload conditional, so the version is checked just before.
+type driveProvider struct {
+	session *oauthSession
+}
+
+// driveFile is the data file's Drive metadata
+type driveFile struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// find looks up the data file; a nil file means there is none yet
+func (p *driveProvider) find() (*driveFile, error) {
+	q := url.Values{
+		"spaces": {"appDataFolder"},
+		"q":      {"name = '" + cloudSyncFile + "' and trashed = false"},
+		"fields": {"files(id,version)"},
+	}
+	var list struct {
+		Files []driveFile `json:"files"`
+	}
+	if err := p.call(http.MethodGet, "https://www.googleapis.com/drive/v3/files?"+q.Encode(), nil, "", &list); err != nil {
+		return nil, err
+	}
+	if len(list.Files) == 0 {
+		return nil, nil
+	}
+	return &list.Files[0], nil
+}
+
+// call makes a Drive request and decodes its JSON answer into out, if given
+func (p *driveProvider) call(method, address string, body io.Reader, contentType string, out any) error {
+	req, err := http.NewRequest(method, address, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := p.session.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Google Drive answered %s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// download fetches the data file
+func (p *driveProvider) download() ([]byte, string, error) {
+	file, err := p.find()
+	if err != nil {
+		return nil, "", err
+	}
+	if file == nil {
+		return nil, "", errRemoteMissing
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://www.googleapis.com/drive/v3/files/"+file.ID+"?alt=media", nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := p.session.do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("Google Drive answered %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	return data, file.Version, err
+}
+
+// upload writes the data file if it's still at version tag
+func (p *driveProvider) upload(data []byte, tag string) (string, error) {
+	file, err := p.find()
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case file == nil && tag != "", file != nil && file.Version != tag:
+		return "", errRemoteChanged
+	}
+
+	var result driveFile
+	if file != nil {
+		err := p.call(http.MethodPatch, "https://www.googleapis.com/upload/drive/v3/files/"+file.ID+"?uploadType=media&fields=id,version",
+			bytes.NewReader(data), "application/json", &result)
+		return result.Version, err
+	}
+
+	// A new file is created with its metadata in a multipart body
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	meta, _ := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json; charset=UTF-8"}})
+	json.NewEncoder(meta).Encode(map[string]any{"name": cloudSyncFile, "parents": []string{"appDataFolder"}})
+	content, _ := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json"}})
+	content.Write(data)
+	parts.Close()
+
+	err = p.call(http.MethodPost, "https://www.googleapis.com/upload/drive/v3/files?uploadType=multipart&fields=id,version",
+		&body, "multipart/related; boundary="+parts.Boundary(), &result)
+	return result.Version, err
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"time"
+)
+
+// Task comparison statuses returned by CompareWeeks
+const (
+	compareImproved  = "improved"
+	compareRegressed = "regressed"
+	compareUnchanged = "unchanged"
+	compareNew       = "new"     // Task applies in week B only
+	compareRemoved   = "removed" // Task applies in week A only
+)
+
+// TaskComparison is one task's change between two weeks
+type TaskComparison struct {
+	TaskID    string  `json:"taskId"`
+	TaskName  string  `json:"taskName"`
+	Status    string  `json:"status"`
+	ProgressA float64 `json:"progressA"`
+	ProgressB float64 `json:"progressB"`
+	TotalA    int     `json:"totalA"`
+	TotalB    int     `json:"totalB"`
+}
+
+// WeekComparison compares two weeks task by task
+type WeekComparison struct {
+	WeekA    string           `json:"weekA"`
+	WeekB    string           `json:"weekB"`
+	AverageA float64          `json:"averageA"`
+	AverageB float64          `json:"averageB"`
+	Tasks    []TaskComparison `json:"tasks"`
+}
+
+// CompareWeeks diffs two weeks (given by their start dates) per task:
+// improved, regressed, unchanged, new or removed
+func (a *App) CompareWeeks(weekA string, weekB string) (WeekComparison, error) {
+	startA, err := time.Parse("2006-01-02", weekA)
+	if err != nil {
+		return WeekComparison{}, errors.New("invalid week")
+	}
+	startB, err := time.Parse("2006-01-02", weekB)
+	if err != nil {
+		return WeekComparison{}, errors.New("invalid week")
+	}
+
+	// Reuse the public report method; it takes its own read lock
+	reportA := a.GetWeeklyReport(weekA)
+	reportB := a.GetWeeklyReport(weekB)
+
+	first, last := startA, startB
+	if last.Before(first) {
+		first, last = last, first
+	}
+	defer a.lockDays(first.Format("2006-01-02"), last.AddDate(0, 0, 6).Format("2006-01-02"))()
+
+	comparison := WeekComparison{
+		WeekA:    weekA,
+		WeekB:    weekB,
+		AverageA: reportA["weeklyAverage"].(float64),
+		AverageB: reportB["weeklyAverage"].(float64),
+		Tasks:    []TaskComparison{},
+	}
+
+	detailA := a.weekDetailLocked(startA)
+	detailB := a.weekDetailLocked(startB)
+
+	inA := make(map[string]WeekTaskDetail)
+	for _, td := range detailA.Tasks {
+		inA[td.TaskID] = td
+	}
+	inB := make(map[string]bool)
+
+	for _, b := range detailB.Tasks {
+		inB[b.TaskID] = true
+		c := TaskComparison{TaskID: b.TaskID, ProgressB: b.Progress, TotalB: b.Total}
+		if prev, ok := inA[b.TaskID]; ok {
+			c.ProgressA = prev.Progress
+			c.TotalA = prev.Total
+			c.Status = compareProgress(prev, b)
+		} else {
+			c.Status = compareNew
+		}
+		comparison.Tasks = append(comparison.Tasks, c)
+	}
+	for _, td := range detailA.Tasks {
+		if !inB[td.TaskID] {
+			comparison.Tasks = append(comparison.Tasks, TaskComparison{
+				TaskID:    td.TaskID,
+				Status:    compareRemoved,
+				ProgressA: td.Progress,
+				TotalA:    td.Total,
+			})
+		}
+	}
+
+	for i := range comparison.Tasks {
+		if task, ok := a.findTemplateLocked(comparison.Tasks[i].TaskID); ok {
+			comparison.Tasks[i].TaskName = task.Name
+		}
+	}
+
+	return comparison, nil
+}
+
+// compareProgress classifies a task's change between two weeks by target
+// progress, falling back to the weekly total when progress is equal
+func compareProgress(before, after WeekTaskDetail) string {
+	switch {
+	case after.Progress > before.Progress:
+		return compareImproved
+	case after.Progress < before.Progress:
+		return compareRegressed
+	case after.Total > before.Total:
+		return compareImproved
+	case after.Total < before.Total:
+		return compareRegressed
+	}
+	return compareUnchanged
+}
+
+// ExportWeekComparison saves CompareWeeks as an HTML page in the export
+// folder and returns its path
+func (a *App) ExportWeekComparison(weekA string, weekB string) (string, error) {
+	comparison, err := a.CompareWeeks(weekA, weekB)
+	if err != nil {
+		return "", err
+	}
+	filename := fmt.Sprintf("plan-compare-%s-vs-%s.html", weekA, weekB)
+	return a.writeExportFile(filename, renderComparisonHTML(comparison))
+}
+
+// renderComparisonHTML renders a week comparison as a standalone page
+func renderComparisonHTML(c WeekComparison) []byte {
+	var buf bytes.Buffer
+	title := html.EscapeString(fmt.Sprintf("Week of %s vs week of %s", c.WeekA, c.WeekB))
+
+	fmt.Fprintf(&buf, "<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>%s</title>\n", title)
+	buf.WriteString(`<style>
+    body { font-family: -apple-system, BlinkMacSystemFont, sans-serif; color: #222; margin: 40px; }
+    table { border-collapse: collapse; min-width: 480px; }
+    th, td { text-align: left; padding: 6px 12px; border-bottom: 1px solid #eee; }
+    .improved { color: #34C759; } .regressed { color: #FF3B30; }
+    .unchanged, .removed { color: #888; } .new { color: #007AFF; }
+</style></head><body>
+`)
+	fmt.Fprintf(&buf, "<h1>%s</h1>\n", title)
+	fmt.Fprintf(&buf, "<p>Weekly average: %.0f%% → %.0f%%</p>\n", c.AverageA, c.AverageB)
+
+	buf.WriteString("<table>\n<tr><th>Task</th><th>Before</th><th>After</th><th>Change</th></tr>\n")
+	for _, t := range c.Tasks {
+		fmt.Fprintf(&buf, "<tr><td>%s</td><td>%.0f%%</td><td>%.0f%%</td><td class=\"%s\">%s</td></tr>\n",
+			html.EscapeString(t.TaskName), t.ProgressA, t.ProgressB, t.Status, t.Status)
+	}
+	buf.WriteString("</table>\n</body></html>\n")
+
+	return buf.Bytes()
+}
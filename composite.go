@@ -0,0 +1,181 @@
+package main
+
+import (
+	"errors"
+)
+
+// Operators of a Composite
+const (
+	compositeAnd = "and" // Done when every part is done
+	compositeOr  = "or"  // Done when any part is done
+)
+
+// Composite derives a binary task's value from whether other tasks
+// succeeded, e.g. "Healthy day" = Exercise AND No sugar. Parts may be of
+// any type but value tasks; count and duration parts succeed on reaching
+// their target.
+type Composite struct {
+	Op    string   `json:"op"`    // "and" or "or"
+	Parts []string `json:"parts"` // Task IDs
+}
+
+// SetTaskComposite turns a task into a composite of other tasks, combined
+// with op ("and" or "or"). No parts turns it back into a manual task,
+// keeping the values already derived. The task becomes binary.
+func (a *App) SetTaskComposite(taskID string, op string, parts []string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	i := a.templateIndexLocked(taskID)
+	if i < 0 {
+		return errors.New("task not found")
+	}
+	t := a.data.Templates[i]
+
+	if len(parts) == 0 {
+		if t.Composite == nil {
+			return nil
+		}
+		a.data.Templates[i].Composite = nil
+		a.audit("SetTaskComposite", "", taskID, t.Composite, nil)
+		return a.saveDataLocked()
+	}
+
+	if op != compositeAnd && op != compositeOr {
+		return errors.New(`operator must be "and" or "or"`)
+	}
+	if t.Source != nil {
+		return errors.New("task is already computed from a signal")
+	}
+	seen := make(map[string]bool)
+	for _, id := range parts {
+		if id == taskID {
+			return errors.New("a composite task can't include itself")
+		}
+		if seen[id] {
+			return errors.New("each task can only be included once")
+		}
+		seen[id] = true
+		part, ok := a.findTemplateLocked(id)
+		if !ok {
+			return errors.New("task not found")
+		}
+		if part.Composite != nil {
+			return errors.New("composite tasks can't include other composite tasks")
+		}
+		if isValueTask(part) {
+			return errors.New("value tasks have no success to combine")
+		}
+	}
+	for _, other := range a.data.Templates {
+		if other.Composite != nil && other.ID != taskID {
+			for _, id := range other.Composite.Parts {
+				if id == taskID {
+					return errors.New("task is part of a composite task")
+				}
+			}
+		}
+	}
+
+	composite := &Composite{Op: op, Parts: append([]string(nil), parts...)}
+	a.rememberLocked("SetTaskComposite")
+	a.data.Templates[i].Composite = composite
+	a.data.Templates[i].Type = "binary"
+	a.data.Templates[i].Target = 0
+	a.audit("SetTaskComposite", "", taskID, t.Composite, composite)
+	return a.saveDataLocked()
+}
+
+// evaluateCompositeLocked returns a composite task's value on date, or
+// false when none of its parts applies or has a value yet (must hold lock)
+func (a *App) evaluateCompositeLocked(task TaskTemplate, date string) (int, bool) {
+	values := a.data.Days[date]
+	recorded, applied, done := false, 0, 0
+	for _, id := range task.Composite.Parts {
+		part, ok := a.findTemplateLocked(id)
+		if !ok || !a.taskAppliesLocked(part, date) {
+			continue
+		}
+		value, ok := values[id]
+		recorded = recorded || ok
+		applied++
+		if compositePartDone(part, date, value) {
+			done++
+		}
+	}
+	if !recorded {
+		return 0, false
+	}
+
+	holds := done > 0
+	if task.Composite.Op == compositeAnd {
+		holds = done == applied
+	}
+	if holds {
+		return 1, true
+	}
+	return 0, true
+}
+
+// compositePartDone reports whether a part counts as done: count and
+// duration tasks with a daily goal need to reach it
+func compositePartDone(part TaskTemplate, date string, value int) bool {
+	if isCounterTask(part) && part.Target > 0 && part.Taper == nil {
+		return value >= part.Target
+	}
+	return taskSucceeded(part, date, value)
+}
+
+// recomputeCompositesLocked refreshes every composite task's value from
+// its parts, on every date with values. Called before each save, so
+// composites follow whichever way their parts were changed (must hold
+// lock).
+func (a *App) recomputeCompositesLocked() {
+	composites := []TaskTemplate{}
+	for _, t := range a.data.Templates {
+		if t.Composite != nil && t.DeletedAt == nil {
+			composites = append(composites, t)
+		}
+	}
+	if len(composites) == 0 {
+		return
+	}
+
+	for date, tasks := range a.data.Days {
+		for _, task := range composites {
+			if !a.taskAppliesLocked(task, date) {
+				continue
+			}
+			value, ok := a.evaluateCompositeLocked(task, date)
+			if ok {
+				tasks[task.ID] = value
+			} else {
+				delete(tasks, task.ID)
+			}
+		}
+		if len(tasks) == 0 {
+			delete(a.data.Days, date)
+		}
+	}
+}
+
+// removeCompositePartLocked drops a purged task from composites; a
+// composite left with no parts becomes a manual task (must hold lock)
+func (a *App) removeCompositePartLocked(id string) {
+	for i, t := range a.data.Templates {
+		if t.Composite == nil {
+			continue
+		}
+		parts := []string{}
+		for _, part := range t.Composite.Parts {
+			if part != id {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) == 0 {
+			a.data.Templates[i].Composite = nil
+		} else {
+			a.data.Templates[i].Composite = &Composite{Op: t.Composite.Op, Parts: parts}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Data file names, plain and gzip-compressed
+const (
+	dataFileName           = "data.json"
+	compressedDataFileName = "data.json.gz"
+)
+
+// gzipMagic starts every gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// dataFileNameLocked returns the data file name the settings ask for
+// (must hold lock)
+func (a *App) dataFileNameLocked() string {
+	if a.settings.CompressData {
+		return compressedDataFileName
+	}
+	return dataFileName
+}
+
+// dataFileIn returns the data file to use in dir: the one the settings ask
+// for, or the other one when only that exists, e.g. when settings were
+// reset. Saves keep the file's format until the setting is changed
+// (must hold lock).
+func (a *App) dataFileIn(dir string) string {
+	path := filepath.Join(dir, a.dataFileNameLocked())
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		other := filepath.Join(dir, dataFileName)
+		if path == other {
+			other = filepath.Join(dir, compressedDataFileName)
+		}
+		if _, err := os.Stat(other); err == nil {
+			return other
+		}
+	}
+	return path
+}
+
+// isCompressedPath reports whether a data file is written compressed
+func isCompressedPath(path string) bool {
+	return strings.HasSuffix(path, ".gz")
+}
+
+// isCompressed reports whether data is gzip-compressed
+func isCompressed(data []byte) bool {
+	return bytes.HasPrefix(data, gzipMagic)
+}
+
+// compressData gzips data
+func compressData(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressData reverses compressData; other data is returned unchanged
+func decompressData(data []byte) ([]byte, error) {
+	if !isCompressed(data) {
+		return data, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// GetDataCompression reports whether data.json is saved gzip-compressed
+func (a *App) GetDataCompression() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return isCompressedPath(a.dataPath)
+}
+
+// SetDataCompression saves the data as data.json.gz, or back as plain
+// data.json, and removes the other file. Compressed data is written
+// without indentation, typically a tenth of the size.
+func (a *App) SetDataCompression(enabled bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.locked {
+		return errDataLocked
+	}
+	if _, ok := a.store.(*jsonStore); !ok {
+		return errors.New("compression needs data.json storage")
+	}
+	if isCompressedPath(a.dataPath) == enabled {
+		if a.settings.CompressData != enabled {
+			a.settings.CompressData = enabled
+			return a.saveSettingsLocked()
+		}
+		return nil
+	}
+
+	name := dataFileName
+	if enabled {
+		name = compressedDataFileName
+	}
+	oldPath, oldSum := a.dataPath, a.dataSum
+	a.dataPath, a.dataSum = filepath.Join(filepath.Dir(oldPath), name), ""
+	if err := a.store.save(&a.data); err != nil {
+		a.dataPath, a.dataSum = oldPath, oldSum
+		return err
+	}
+	if err := os.Remove(oldPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		println("Error removing old data file:", err.Error())
+	}
+
+	a.settings.CompressData = enabled
+	a.audit("SetDataCompression", "", "", !enabled, enabled)
+	return a.saveSettingsLocked()
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// importSync is the ImportData strategy that merges day values entry by
+// entry using their stamps, the same way on every device
+const importSync = "sync"
+
+// ValueStamp records the last write of a day value so copies of the data
+// from different devices merge deterministically. Most values are
+// last-writer-wins registers; count and duration values are counters whose
+// increments and decrements are kept per device, so check-offs made on two
+// devices both survive a merge.
+type ValueStamp struct {
+	Value   int            `json:"value"`
+	At      int64          `json:"at"`                // Unix milliseconds of the last write; 0 for values from before stamps
+	Device  string         `json:"device"`            // Device that wrote it; "" for values from before stamps
+	Deleted bool           `json:"deleted,omitempty"` // The value was removed
+	Up      map[string]int `json:"up,omitempty"`      // Counters: total increments per device
+	Down    map[string]int `json:"down,omitempty"`    // Counters: total decrements per device
+}
+
+// isCounterTask reports whether a task's values merge as counters
+func isCounterTask(task TaskTemplate) bool {
+	switch taskTypeOf(task) {
+	case "count", "duration":
+		return true
+	}
+	return false
+}
+
+// counterValue returns a counter's value from its per-device totals
+func (s ValueStamp) counterValue() int {
+	total := 0
+	for _, n := range s.Up {
+		total += n
+	}
+	for _, n := range s.Down {
+		total -= n
+	}
+	return total
+}
+
+// newerThan orders writes by time, then device, then value, so every
+// device picks the same winner
+func (s ValueStamp) newerThan(other ValueStamp) bool {
+	if s.At != other.At {
+		return s.At > other.At
+	}
+	if s.Device != other.Device {
+		return s.Device > other.Device
+	}
+	return s.Value > other.Value
+}
+
+// baselineStamp stamps a value written before stamps existed. Baselines
+// share device "" so the same value on two devices isn't counted twice.
+func baselineStamp(value int, counter bool) ValueStamp {
+	s := ValueStamp{Value: value}
+	if counter {
+		s.Up = map[string]int{"": value}
+	}
+	return s
+}
+
+// mergeStamps combines two writes of the same day value
+func mergeStamps(mine, theirs ValueStamp, counter bool) ValueStamp {
+	newer := mine
+	if theirs.newerThan(mine) {
+		newer = theirs
+	}
+	if !counter || mine.Up == nil || theirs.Up == nil {
+		return newer
+	}
+
+	merged := ValueStamp{At: newer.At, Device: newer.Device, Deleted: newer.Deleted, Up: make(map[string]int), Down: make(map[string]int)}
+	for _, side := range []ValueStamp{mine, theirs} {
+		for device, n := range side.Up {
+			merged.Up[device] = max(merged.Up[device], n)
+		}
+		for device, n := range side.Down {
+			merged.Down[device] = max(merged.Down[device], n)
+		}
+	}
+	merged.Value = merged.counterValue()
+	return merged
+}
+
+// deviceIDLocked returns this machine's ID for stamps, creating it on first
+// use (must hold lock)
+func (a *App) deviceIDLocked() string {
+	if a.settings.DeviceID == "" {
+		a.settings.DeviceID = uuid.New().String()
+		if err := a.saveSettingsLocked(); err != nil {
+			println("Error saving device ID:", err.Error())
+		}
+	}
+	return a.settings.DeviceID
+}
+
+// dayChange is a day value written or removed since the last save
+type dayChange struct {
+	Date    string
+	TaskID  string
+	Value   int
+	Deleted bool
+}
+
+// stampDaysLocked stamps day values that changed since the last save and
+// marks removed ones deleted, returning what changed. It runs on every
+// save, so no mutation has to remember to stamp (must hold lock).
+func (a *App) stampDaysLocked() []dayChange {
+	changes := []dayChange{}
+	if a.data.Stamps == nil {
+		a.data.Stamps = make(map[string]map[string]ValueStamp)
+	}
+	now := time.Now().UnixMilli()
+	device := ""
+
+	counters := make(map[string]bool)
+	for _, t := range a.data.Templates {
+		counters[t.ID] = isCounterTask(t)
+	}
+
+	for date, tasks := range a.data.Days {
+		for id, value := range tasks {
+			prev, ok := a.data.Stamps[date][id]
+			if ok && !prev.Deleted && prev.Value == value {
+				continue
+			}
+			if device == "" {
+				device = a.deviceIDLocked()
+			}
+
+			stamp := ValueStamp{Value: value, At: now, Device: device}
+			if counters[id] {
+				stamp.Up, stamp.Down = maps.Clone(prev.Up), maps.Clone(prev.Down)
+				if stamp.Up == nil {
+					stamp.Up = make(map[string]int)
+				}
+				if delta := value - prev.counterValue(); delta > 0 {
+					stamp.Up[device] += delta
+				} else if delta < 0 {
+					if stamp.Down == nil {
+						stamp.Down = make(map[string]int)
+					}
+					stamp.Down[device] -= delta
+				}
+			}
+			if a.data.Stamps[date] == nil {
+				a.data.Stamps[date] = make(map[string]ValueStamp)
+			}
+			a.data.Stamps[date][id] = stamp
+			changes = append(changes, dayChange{Date: date, TaskID: id, Value: value})
+		}
+	}
+
+	for date, stamps := range a.data.Stamps {
+		for id, prev := range stamps {
+			if _, ok := a.data.Days[date][id]; ok || prev.Deleted {
+				continue
+			}
+			if device == "" {
+				device = a.deviceIDLocked()
+			}
+			prev.Deleted, prev.At, prev.Device = true, now, device
+			stamps[id] = prev
+			changes = append(changes, dayChange{Date: date, TaskID: id, Deleted: true})
+		}
+	}
+	return changes
+}
+
+// migrateStampsLocked stamps existing day values as baselines. Stamps kept
+// across a restore of older data are left alone (must hold lock).
+func (a *App) migrateStampsLocked() error {
+	if a.data.Stamps != nil {
+		return nil
+	}
+
+	counters := make(map[string]bool)
+	for _, t := range a.data.Templates {
+		counters[t.ID] = isCounterTask(t)
+	}
+
+	a.data.Stamps = make(map[string]map[string]ValueStamp)
+	for date, tasks := range a.data.Days {
+		a.data.Stamps[date] = make(map[string]ValueStamp)
+		for id, value := range tasks {
+			a.data.Stamps[date][id] = baselineStamp(value, counters[id])
+		}
+	}
+	return nil
+}
+
+// syncDaysLocked merges another device's day values into this one's by
+// their stamps, returning how many values changed here (must hold lock)
+func (a *App) syncDaysLocked(other PlannerData) int {
+	counters := make(map[string]bool)
+	for _, t := range a.data.Templates {
+		counters[t.ID] = isCounterTask(t)
+	}
+
+	// Data from before stamps: every value is a baseline
+	theirs := other.Stamps
+	if theirs == nil {
+		theirs = make(map[string]map[string]ValueStamp)
+		for date, tasks := range other.Days {
+			theirs[date] = make(map[string]ValueStamp)
+			for id, value := range tasks {
+				theirs[date][id] = baselineStamp(value, counters[id])
+			}
+		}
+	}
+
+	if a.data.Stamps == nil {
+		a.data.Stamps = make(map[string]map[string]ValueStamp)
+	}
+	changed := 0
+	for date, stamps := range theirs {
+		// The archived day's values and stamps take part in the merge
+		if err := a.pullArchivedDayLocked(date); err != nil {
+			println("Error reading archived day:", err.Error())
+			continue
+		}
+		for id, stamp := range stamps {
+			merged := stamp
+			if mine, ok := a.data.Stamps[date][id]; ok {
+				merged = mergeStamps(mine, stamp, counters[id])
+			}
+			if a.data.Stamps[date] == nil {
+				a.data.Stamps[date] = make(map[string]ValueStamp)
+			}
+			a.data.Stamps[date][id] = merged
+
+			value, exists := a.data.Days[date][id]
+			switch {
+			case merged.Deleted && exists:
+				delete(a.data.Days[date], id)
+				changed++
+			case !merged.Deleted && (!exists || value != merged.Value):
+				if a.data.Days[date] == nil {
+					a.data.Days[date] = make(DayTasks)
+				}
+				a.data.Days[date][id] = merged.Value
+				changed++
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestMergeStamps(t *testing.T) {
+	tests := []struct {
+		name    string
+		mine    ValueStamp
+		theirs  ValueStamp
+		counter bool
+		want    int
+		deleted bool
+	}{
+		{
+			name:   "newer write wins",
+			mine:   ValueStamp{Value: 1, At: 10, Device: "a"},
+			theirs: ValueStamp{Value: 0, At: 20, Device: "b"},
+			want:   0,
+		},
+		{
+			name:   "older write loses",
+			mine:   ValueStamp{Value: 1, At: 20, Device: "a"},
+			theirs: ValueStamp{Value: 0, At: 10, Device: "b"},
+			want:   1,
+		},
+		{
+			name:   "same time is settled by device",
+			mine:   ValueStamp{Value: 7, At: 10, Device: "a"},
+			theirs: ValueStamp{Value: 3, At: 10, Device: "b"},
+			want:   3,
+		},
+		{
+			name:    "concurrent increments both count",
+			mine:    ValueStamp{Value: 3, At: 10, Device: "a", Up: map[string]int{"": 2, "a": 1}},
+			theirs:  ValueStamp{Value: 5, At: 20, Device: "b", Up: map[string]int{"": 2, "b": 3}},
+			counter: true,
+			want:    6,
+		},
+		{
+			name:    "increment and decrement on different devices",
+			mine:    ValueStamp{Value: 4, At: 10, Device: "a", Up: map[string]int{"": 3, "a": 1}},
+			theirs:  ValueStamp{Value: 1, At: 20, Device: "b", Up: map[string]int{"": 3}, Down: map[string]int{"b": 2}},
+			counter: true,
+			want:    2,
+		},
+		{
+			name:    "a device's own history isn't counted twice",
+			mine:    ValueStamp{Value: 3, At: 20, Device: "a", Up: map[string]int{"a": 3}},
+			theirs:  ValueStamp{Value: 2, At: 10, Device: "a", Up: map[string]int{"a": 2}},
+			counter: true,
+			want:    3,
+		},
+		{
+			name:    "newer delete beats older update",
+			mine:    ValueStamp{Value: 1, At: 20, Device: "a", Deleted: true},
+			theirs:  ValueStamp{Value: 1, At: 10, Device: "b"},
+			want:    1,
+			deleted: true,
+		},
+		{
+			name:   "newer update beats older delete",
+			mine:   ValueStamp{Value: 1, At: 10, Device: "a", Deleted: true},
+			theirs: ValueStamp{Value: 0, At: 20, Device: "b"},
+			want:   0,
+		},
+		{
+			name:    "newer delete of a counter keeps the merged total",
+			mine:    ValueStamp{Value: 2, At: 20, Device: "a", Deleted: true, Up: map[string]int{"a": 2}},
+			theirs:  ValueStamp{Value: 1, At: 10, Device: "b", Up: map[string]int{"b": 1}},
+			counter: true,
+			want:    3,
+			deleted: true,
+		},
+		{
+			name:    "equal baselines aren't added together",
+			mine:    baselineStamp(3, true),
+			theirs:  baselineStamp(3, true),
+			counter: true,
+			want:    3,
+		},
+		{
+			name:    "baseline and a later increment",
+			mine:    baselineStamp(3, true),
+			theirs:  ValueStamp{Value: 4, At: 10, Device: "b", Up: map[string]int{"": 3, "b": 1}},
+			counter: true,
+			want:    4,
+		},
+		{
+			name:   "non-counter baselines keep the larger value",
+			mine:   baselineStamp(0, false),
+			theirs: baselineStamp(1, false),
+			want:   1,
+		},
+		{
+			name:    "counter without per-device totals falls back to the newer write",
+			mine:    ValueStamp{Value: 5, At: 10, Device: "a"},
+			theirs:  ValueStamp{Value: 2, At: 20, Device: "b", Up: map[string]int{"b": 2}},
+			counter: true,
+			want:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, order := range [][2]ValueStamp{{tt.mine, tt.theirs}, {tt.theirs, tt.mine}} {
+				got := mergeStamps(order[0], order[1], tt.counter)
+				if got.Value != tt.want || got.Deleted != tt.deleted {
+					t.Fatalf("mergeStamps(%+v, %+v) = value %d deleted %v, want value %d deleted %v",
+						order[0], order[1], got.Value, got.Deleted, tt.want, tt.deleted)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncDaysLocked(t *testing.T) {
+	templates := []TaskTemplate{
+		{ID: "read", Name: "Read", Type: "count"},
+		{ID: "walk", Name: "Walk", Type: "binary"},
+	}
+
+	tests := []struct {
+		name        string
+		mine        map[string]DayTasks
+		mineStamps  map[string]map[string]ValueStamp
+		other       PlannerData
+		want        map[string]DayTasks
+		wantChanged int
+	}{
+		{
+			name: "concurrent increments from two devices",
+			mine: map[string]DayTasks{"2024-01-01": {"read": 3}},
+			mineStamps: map[string]map[string]ValueStamp{"2024-01-01": {
+				"read": {Value: 3, At: 10, Device: "a", Up: map[string]int{"": 2, "a": 1}},
+			}},
+			other: PlannerData{
+				Days: map[string]DayTasks{"2024-01-01": {"read": 4}},
+				Stamps: map[string]map[string]ValueStamp{"2024-01-01": {
+					"read": {Value: 4, At: 20, Device: "b", Up: map[string]int{"": 2, "b": 2}},
+				}},
+			},
+			want:        map[string]DayTasks{"2024-01-01": {"read": 5}},
+			wantChanged: 1,
+		},
+		{
+			name: "newer delete removes the value here",
+			mine: map[string]DayTasks{"2024-01-01": {"walk": 1}},
+			mineStamps: map[string]map[string]ValueStamp{"2024-01-01": {
+				"walk": {Value: 1, At: 10, Device: "a"},
+			}},
+			other: PlannerData{
+				Days: map[string]DayTasks{"2024-01-01": {}},
+				Stamps: map[string]map[string]ValueStamp{"2024-01-01": {
+					"walk": {Value: 1, At: 20, Device: "b", Deleted: true},
+				}},
+			},
+			want:        map[string]DayTasks{"2024-01-01": {}},
+			wantChanged: 1,
+		},
+		{
+			name: "newer update survives an older delete",
+			mine: map[string]DayTasks{"2024-01-01": {"walk": 1}},
+			mineStamps: map[string]map[string]ValueStamp{"2024-01-01": {
+				"walk": {Value: 1, At: 20, Device: "a"},
+			}},
+			other: PlannerData{
+				Days: map[string]DayTasks{"2024-01-01": {}},
+				Stamps: map[string]map[string]ValueStamp{"2024-01-01": {
+					"walk": {Value: 1, At: 10, Device: "b", Deleted: true},
+				}},
+			},
+			want:        map[string]DayTasks{"2024-01-01": {"walk": 1}},
+			wantChanged: 0,
+		},
+		{
+			name: "unstamped data merges as baselines",
+			mine: map[string]DayTasks{"2024-01-01": {"read": 2}},
+			mineStamps: map[string]map[string]ValueStamp{"2024-01-01": {
+				"read": baselineStamp(2, true),
+			}},
+			other: PlannerData{
+				Days: map[string]DayTasks{
+					"2024-01-01": {"read": 2},
+					"2024-01-02": {"walk": 1},
+				},
+			},
+			want: map[string]DayTasks{
+				"2024-01-01": {"read": 2},
+				"2024-01-02": {"walk": 1},
+			},
+			wantChanged: 1,
+		},
+		{
+			name: "baseline and increments made since",
+			mine: map[string]DayTasks{"2024-01-01": {"read": 3}},
+			mineStamps: map[string]map[string]ValueStamp{"2024-01-01": {
+				"read": {Value: 3, At: 10, Device: "a", Up: map[string]int{"": 2, "a": 1}},
+			}},
+			other: PlannerData{
+				Days: map[string]DayTasks{"2024-01-01": {"read": 2}},
+			},
+			want:        map[string]DayTasks{"2024-01-01": {"read": 3}},
+			wantChanged: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &App{data: PlannerData{Templates: templates, Days: tt.mine, Stamps: tt.mineStamps}}
+
+			if changed := a.syncDaysLocked(tt.other); changed != tt.wantChanged {
+				t.Fatalf("changed %d values, want %d", changed, tt.wantChanged)
+			}
+			if !maps.EqualFunc(a.data.Days, tt.want, maps.Equal) {
+				t.Fatalf("days = %v, want %v", a.data.Days, tt.want)
+			}
+
+			// Merging the same data again changes nothing
+			if changed := a.syncDaysLocked(tt.other); changed != 0 {
+				t.Fatalf("second merge changed %d values", changed)
+			}
+		})
+	}
+}
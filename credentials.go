@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// credentialsPath is where passwords for remote services are kept: next to
+// settings.json but apart from it, so settings can be shown in diagnostics
+// or copied between machines without them. The file is only readable by
+// its owner and never leaves this machine.
+func (a *App) credentialsPath() string {
+	return filepath.Join(filepath.Dir(a.settingsPath), "credentials.json")
+}
+
+// credentialsLocked reads the saved credentials, keyed by service (must hold lock)
+func (a *App) credentialsLocked() map[string]string {
+	credentials := make(map[string]string)
+	data, err := os.ReadFile(a.credentialsPath())
+	if err != nil {
+		return credentials
+	}
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		println("Error reading credentials:", err.Error())
+	}
+	return credentials
+}
+
+// credentialLocked returns the saved secret for a service, or "" (must hold lock)
+func (a *App) credentialLocked(service string) string {
+	return a.credentialsLocked()[service]
+}
+
+// setCredentialLocked saves the secret for a service; "" removes it (must hold lock)
+func (a *App) setCredentialLocked(service, secret string) error {
+	credentials := a.credentialsLocked()
+	if secret == "" {
+		if _, ok := credentials[service]; !ok {
+			return nil
+		}
+		delete(credentials, service)
+	} else {
+		credentials[service] = secret
+	}
+
+	data, err := json.MarshalIndent(credentials, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := a.atomicWriteFile(a.credentialsPath(), data); err != nil {
+		return err
+	}
+	return os.Chmod(a.credentialsPath(), 0o600)
+}
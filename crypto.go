@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// encryptedMagic prefixes every file written by encryptWithKey
+var encryptedMagic = []byte("PLANENC1")
+
+const (
+	saltSize = 16
+	keySize  = 32
+)
+
+// newSalt returns random bytes for key derivation
+func newSalt() ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// deriveKey derives an AES-256 key from a passphrase using Argon2id
+func deriveKey(passphrase string, salt []byte) []byte {
+	return argon2.IDKey([]byte(passphrase), salt, 1, 64*1024, 4, keySize)
+}
+
+// encryptWithKey seals plaintext with AES-GCM.
+// Output layout: magic | salt | nonce | ciphertext. The salt is stored so the
+// key can be re-derived from the passphrase on another machine.
+func encryptWithKey(key, salt, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(encryptedMagic)+len(salt)+len(nonce)+len(plaintext)+gcm.Overhead())
+	out = append(out, encryptedMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plaintext, encryptedMagic), nil
+}
+
+// isEncrypted reports whether data was produced by encryptWithKey
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedMagic)
+}
+
+// embeddedSalt returns the salt in the header of data produced by
+// encryptWithKey, or nil when data is too short to have one
+func embeddedSalt(data []byte) []byte {
+	if !isEncrypted(data) || len(data) < len(encryptedMagic)+saltSize {
+		return nil
+	}
+	return data[len(encryptedMagic) : len(encryptedMagic)+saltSize]
+}
+
+// decryptWithPassphrase opens data produced by encryptWithKey
+func decryptWithPassphrase(passphrase string, data []byte) ([]byte, error) {
+	salt := embeddedSalt(data)
+	if salt == nil {
+		return nil, errors.New("not an encrypted PLAN file")
+	}
+	return decryptWithKey(deriveKey(passphrase, salt), data)
+}
+
+// decryptWithKey opens data produced by encryptWithKey using an already derived key
+func decryptWithKey(key, data []byte) ([]byte, error) {
+	if !isEncrypted(data) {
+		return nil, errors.New("not an encrypted PLAN file")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	header := len(encryptedMagic) + saltSize
+	if len(data) < header+gcm.NonceSize() {
+		return nil, errors.New("encrypted file is truncated")
+	}
+	nonce := data[header : header+gcm.NonceSize()]
+	plaintext, err := gcm.Open(nil, nonce, data[header+gcm.NonceSize():], encryptedMagic)
+	if err != nil {
+		return nil, errors.New("wrong passphrase or corrupted file")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,516 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvDateLayouts are tried in order when no dateFormat option is given
+var csvDateLayouts = []string{"2006-01-02", "2006/01/02", "01/02/2006", "1/2/2006", "02.01.2006", "Jan 2, 2006", "2 Jan 2006"}
+
+// CSVImportReport describes what ImportCSV did, or would do on a dry run
+type CSVImportReport struct {
+	DryRun      bool              `json:"dryRun"`
+	Rows        int               `json:"rows"` // Rows with a readable date
+	From        string            `json:"from"`
+	To          string            `json:"to"`
+	Columns     map[string]string `json:"columns"`     // column -> task name it fills
+	NewTasks    []string          `json:"newTasks"`    // Tasks created for unmatched columns
+	Backdated   []string          `json:"backdated"`   // Existing tasks whose start moved back to cover the history
+	Values      int               `json:"values"`      // Values written
+	Overwritten int               `json:"overwritten"` // Of which replaced a different recorded value
+	Warnings    []string          `json:"warnings"`
+	Errors      []CSVRowError     `json:"errors"` // The rows and cells behind the warnings
+}
+
+// CSVRowError is a row, or one cell of it, that couldn't be imported
+type CSVRowError struct {
+	Line    int    `json:"line"`
+	Column  string `json:"column,omitempty"` // Empty when the whole row was left out
+	Value   string `json:"value"`
+	Message string `json:"message"`
+}
+
+// csvValueFormats are how a column's cells can be read
+var csvValueFormats = []string{"auto", "boolean", "number", "duration"}
+
+// csvColumn is one task column of an import
+type csvColumn struct {
+	index  int
+	name   string
+	taskID string // Empty until created for a new task
+	values map[string]int
+	format string // One of csvValueFormats
+	counts bool   // Some value is above 1, so a new task counts rather than ticks
+}
+
+// ImportCSV imports a spreadsheet of past completions: one row per date and
+// one column per task. Columns are matched to tasks by name; unmatched
+// columns become new tasks. Options:
+//   - "dryRun": "true" reports what would happen without changing anything
+//   - "dateColumn": header of the date column (default: the first column)
+//   - "dateFormat": Go layout of the dates, e.g. "02/01/2006" (default: common formats)
+//   - "ignore": comma-separated headers to leave out ("Score" always is)
+//   - "map:<header>": name or ID of an existing task the column fills
+//   - "format:<header>": how the column's cells are read (see parseCSVValue)
+//
+// PreviewCSV suggests these from the file. Cells may be numbers, or yes/no
+// words like ✓, x, true and done. Empty cells are left alone; cells and
+// rows that can't be read are reported in Errors and skipped.
+func (a *App) ImportCSV(path string, options map[string]string) (CSVImportReport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return CSVImportReport{}, err
+	}
+	defer f.Close()
+
+	reader, header, err := openCSV(f)
+	if err != nil {
+		return CSVImportReport{}, err
+	}
+
+	report := CSVImportReport{
+		DryRun:    options["dryRun"] == "true",
+		Columns:   make(map[string]string),
+		NewTasks:  []string{},
+		Backdated: []string{},
+		Warnings:  []string{},
+		Errors:    []CSVRowError{},
+	}
+	fail := func(line int, column, value, message string) {
+		report.Errors = append(report.Errors, CSVRowError{Line: line, Column: column, Value: value, Message: message})
+		if column != "" {
+			message = column + ": " + message
+		}
+		report.Warnings = append(report.Warnings, fmt.Sprintf("line %d: %s %q", line, message, value))
+	}
+
+	dateIndex := 0
+	if name := options["dateColumn"]; name != "" {
+		dateIndex = -1
+		for i, h := range header {
+			if strings.EqualFold(h, name) {
+				dateIndex = i
+			}
+		}
+		if dateIndex < 0 {
+			return report, fmt.Errorf("no column named %q", name)
+		}
+	}
+	layouts := csvDateLayouts
+	if layout := options["dateFormat"]; layout != "" {
+		layouts = []string{layout}
+	}
+
+	ignored := map[string]bool{"score": true}
+	for _, name := range strings.Split(options["ignore"], ",") {
+		ignored[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	columns := []*csvColumn{}
+	for i, h := range header {
+		if i == dateIndex || h == "" || ignored[strings.ToLower(h)] {
+			continue
+		}
+		format := options["format:"+h]
+		if format == "" {
+			format = "auto"
+		}
+		if !slices.Contains(csvValueFormats, format) {
+			return report, fmt.Errorf("unknown value format %q for %s", format, h)
+		}
+		columns = append(columns, &csvColumn{index: i, name: h, format: format, values: make(map[string]int)})
+	}
+	if len(columns) == 0 {
+		return report, errors.New("file has no task columns")
+	}
+
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return report, err
+		}
+		if dateIndex >= len(record) {
+			continue
+		}
+		date, ok := parseCSVDate(strings.TrimSpace(record[dateIndex]), layouts)
+		if !ok {
+			fail(line, "", record[dateIndex], "unreadable date")
+			continue
+		}
+		report.Rows++
+		if report.From == "" || date < report.From {
+			report.From = date
+		}
+		if date > report.To {
+			report.To = date
+		}
+
+		for _, col := range columns {
+			if col.index >= len(record) {
+				continue
+			}
+			cell := strings.TrimSpace(record[col.index])
+			if cell == "" {
+				continue
+			}
+			value, ok := parseCSVValue(cell, col.format)
+			if !ok {
+				fail(line, col.name, cell, "unreadable "+strings.TrimPrefix(col.format+" value", "auto "))
+				continue
+			}
+			col.values[date] = value
+			if value > 1 {
+				col.counts = true
+			}
+		}
+	}
+	if report.Rows == 0 {
+		return report, errors.New("no rows with a readable date")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	byName := make(map[string]TaskTemplate)
+	byID := make(map[string]TaskTemplate)
+	for _, t := range a.data.Templates {
+		if t.DeletedAt == nil {
+			byName[strings.ToLower(t.Name)] = t
+		}
+		byID[t.ID] = t
+	}
+	for _, col := range columns {
+		target := col.name
+		if mapped := options["map:"+col.name]; mapped != "" {
+			target = mapped
+		}
+		task, ok := byID[target]
+		if !ok {
+			task, ok = byName[strings.ToLower(target)]
+		}
+		if ok {
+			if isAutoTask(task) || isValueTask(task) {
+				return report, fmt.Errorf("%s can't be imported into: its values aren't set by hand", task.Name)
+			}
+			col.taskID = task.ID
+			report.Columns[col.name] = task.Name
+		} else if len(col.values) > 0 {
+			report.Columns[col.name] = target
+			report.NewTasks = append(report.NewTasks, target)
+		}
+	}
+	sort.Strings(report.NewTasks)
+
+	if !report.DryRun {
+		if _, err := a.preChangeBackupLocked("import"); err != nil {
+			return report, err
+		}
+		a.rememberLocked("ImportCSV")
+	}
+
+	for _, col := range columns {
+		if len(col.values) == 0 {
+			continue
+		}
+		first := report.To
+		for date := range col.values {
+			if date < first {
+				first = date
+			}
+		}
+
+		if col.taskID == "" {
+			if !report.DryRun {
+				taskType, unit := "binary", ""
+				if col.format == "duration" {
+					taskType, unit = "duration", "min"
+				} else if col.counts {
+					taskType = "count"
+				}
+				task := a.addTaskLocked(report.Columns[col.name], taskType, unit)
+				a.data.Templates[len(a.data.Templates)-1].CreatedAt = first
+				col.taskID = task.ID
+				a.audit("ImportCSV", "", task.ID, nil, task.Name)
+			}
+		} else if i := a.templateIndexLocked(col.taskID); i >= 0 && a.data.Templates[i].CreatedAt > first {
+			report.Backdated = append(report.Backdated, a.data.Templates[i].Name)
+			if !report.DryRun {
+				a.audit("ImportCSV", "", col.taskID, a.data.Templates[i].CreatedAt, first)
+				a.data.Templates[i].CreatedAt = first
+			}
+		}
+
+		for date, value := range col.values {
+			report.Values++
+			if col.taskID == "" {
+				continue
+			}
+			if err := a.pullArchivedDayLocked(date); err != nil {
+				return report, err
+			}
+			if old, ok := a.data.Days[date][col.taskID]; ok && old != value {
+				report.Overwritten++
+			}
+			if report.DryRun {
+				continue
+			}
+			if a.data.Days[date] == nil {
+				a.data.Days[date] = make(DayTasks)
+			}
+			a.data.Days[date][col.taskID] = value
+		}
+	}
+
+	if report.DryRun {
+		return report, nil
+	}
+	a.rebuildRecordsLocked()
+	a.audit("ImportCSV", report.From+".."+report.To, "", nil, report.Values)
+	if err := a.saveDataLocked(); err != nil {
+		return report, err
+	}
+	a.emitDataChanged("")
+	return report, nil
+}
+
+// csvPreviewRows is how many rows PreviewCSV returns
+const csvPreviewRows = 10
+
+// CSVPreview is what PreviewCSV found in a file, for mapping its columns
+// before importing
+type CSVPreview struct {
+	Headers    []string           `json:"headers"`
+	Rows       [][]string         `json:"rows"`      // The first rows, as written
+	TotalRows  int                `json:"totalRows"` // Rows after the header
+	DateColumn string             `json:"dateColumn"`
+	DateFormat string             `json:"dateFormat,omitempty"` // Layout most dates fit; empty when none does
+	Columns    []CSVPreviewColumn `json:"columns"`              // Every column but the date column
+}
+
+// CSVPreviewColumn is a column and the mapping suggested for it
+type CSVPreviewColumn struct {
+	Header   string `json:"header"`
+	Format   string `json:"format"`             // Value format the cells look like
+	TaskID   string `json:"taskId,omitempty"`   // Existing task with the column's name
+	TaskName string `json:"taskName,omitempty"` // Empty when a new task would be created
+	Ignored  bool   `json:"ignored,omitempty"`  // Left out unless mapped, like "Score"
+}
+
+// PreviewCSV reads a spreadsheet without importing it and suggests the
+// ImportCSV options for it: the date column and format, each column's value
+// format and the task it matches by name
+func (a *App) PreviewCSV(path string) (CSVPreview, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return CSVPreview{}, err
+	}
+	defer f.Close()
+
+	reader, header, err := openCSV(f)
+	if err != nil {
+		return CSVPreview{}, err
+	}
+	preview := CSVPreview{Headers: header, Rows: [][]string{}, Columns: []CSVPreviewColumn{}}
+	records := [][]string{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return preview, err
+		}
+		preview.TotalRows++
+		records = append(records, record)
+		if len(preview.Rows) < csvPreviewRows {
+			preview.Rows = append(preview.Rows, record)
+		}
+	}
+	cells := func(i int) []string {
+		column := []string{}
+		for _, record := range records {
+			if i < len(record) {
+				if cell := strings.TrimSpace(record[i]); cell != "" {
+					column = append(column, cell)
+				}
+			}
+		}
+		return column
+	}
+
+	// The date column is the first one whose cells mostly fit one layout,
+	// falling back to the first column as ImportCSV does
+	dateIndex := 0
+	for i := range header {
+		if layout := csvDateLayout(cells(i)); layout != "" {
+			dateIndex, preview.DateFormat = i, layout
+			break
+		}
+	}
+	preview.DateColumn = header[dateIndex]
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for i, h := range header {
+		if i == dateIndex || h == "" {
+			continue
+		}
+		column := CSVPreviewColumn{Header: h, Format: csvValueFormat(cells(i)), Ignored: strings.EqualFold(h, "score")}
+		for _, t := range a.data.Templates {
+			if t.DeletedAt == nil && strings.EqualFold(t.Name, h) {
+				column.TaskID, column.TaskName = t.ID, t.Name
+				break
+			}
+		}
+		preview.Columns = append(preview.Columns, column)
+	}
+	return preview, nil
+}
+
+// openCSV reads a spreadsheet's header row, cleaned of spaces and a byte
+// order mark, leaving the reader at the first row
+func openCSV(r io.Reader) (*csv.Reader, []string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.New("file has no header row")
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+	return reader, header, nil
+}
+
+// csvDateLayout returns the one of csvDateLayouts most cells fit, or ""
+// when it's no more than half of them
+func csvDateLayout(cells []string) string {
+	best, fitting := "", 0
+	for _, layout := range csvDateLayouts {
+		n := 0
+		for _, cell := range cells {
+			if _, ok := parseCSVDate(cell, []string{layout}); ok {
+				n++
+			}
+		}
+		if n > fitting {
+			best, fitting = layout, n
+		}
+	}
+	if fitting*2 <= len(cells) {
+		return ""
+	}
+	return best
+}
+
+// csvValueFormat guesses the value format of a column's cells: durations
+// when written with hours or units, numbers when some are above 1,
+// otherwise booleans; "auto" when some cell doesn't fit the guess
+func csvValueFormat(cells []string) string {
+	format := "boolean"
+	for _, cell := range cells {
+		cell = strings.ToLower(cell)
+		if strings.Contains(cell, ":") || strings.HasSuffix(cell, "h") || strings.HasSuffix(cell, "m") {
+			format = "duration"
+		} else if value, ok := parseCSVValue(cell, "number"); ok && value > 1 && format == "boolean" {
+			format = "number"
+		}
+	}
+	for _, cell := range cells {
+		if _, ok := parseCSVValue(cell, format); !ok {
+			return "auto"
+		}
+	}
+	return format
+}
+
+// templateIndexLocked returns the index of a task in Templates, or -1 (must hold lock)
+func (a *App) templateIndexLocked(id string) int {
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseCSVDate reads a date in the first layout that fits
+func parseCSVDate(s string, layouts []string) (string, bool) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01-02"), true
+		}
+	}
+	return "", false
+}
+
+// parseCSVValue reads a cell in a column's format:
+//   - "auto": as parseCSVCell
+//   - "boolean": yes/no words, or numbers where anything above 0 is done
+//   - "number": numbers only, rounded
+//   - "duration": minutes, as "90", "1:30" or "1h30m"
+func parseCSVValue(cell string, format string) (int, bool) {
+	switch format {
+	case "boolean":
+		value, ok := parseCSVCell(cell)
+		if ok && value > 0 {
+			value = 1
+		}
+		return value, ok
+	case "number":
+		n, err := strconv.ParseFloat(cell, 64)
+		if err != nil || n < 0 || math.IsNaN(n) || math.IsInf(n, 0) {
+			return 0, false
+		}
+		return int(math.Round(n)), true
+	case "duration":
+		return parseCSVMinutes(cell)
+	}
+	return parseCSVCell(cell)
+}
+
+// parseCSVMinutes reads a duration cell as minutes
+func parseCSVMinutes(cell string) (int, bool) {
+	if hours, minutes, ok := strings.Cut(cell, ":"); ok {
+		h, err1 := strconv.Atoi(hours)
+		m, err2 := strconv.Atoi(minutes)
+		if err1 != nil || err2 != nil || h < 0 || m < 0 || m >= 60 {
+			return 0, false
+		}
+		return h*60 + m, true
+	}
+	if d, err := time.ParseDuration(strings.ReplaceAll(cell, " ", "")); err == nil && d >= 0 {
+		return int(math.Round(d.Minutes())), true
+	}
+	return parseCSVValue(cell, "number")
+}
+
+// parseCSVCell reads a spreadsheet cell as a day value
+func parseCSVCell(cell string) (int, bool) {
+	switch strings.ToLower(cell) {
+	case "✓", "✔", "x", "y", "yes", "true", "done":
+		return 1, true
+	case "✗", "✘", "-", "n", "no", "false":
+		return 0, true
+	}
+	n, err := strconv.ParseFloat(cell, 64)
+	if err != nil || n < 0 || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, false
+	}
+	return int(math.Round(n)), true
+}
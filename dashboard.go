@@ -0,0 +1,158 @@
+package main
+
+import (
+	"time"
+)
+
+// dashboardExportWeeks is how many past weeks are checked for pending exports,
+// matching the frontend's history export
+const dashboardExportWeeks = 52
+
+// maxStreakLookbackDays caps how far back per-task streaks are traced
+const maxStreakLookbackDays = 3650
+
+// TaskStreak is a single task's current streak
+type TaskStreak struct {
+	TaskID   string `json:"taskId"`
+	TaskName string `json:"taskName"`
+	Streak   int    `json:"streak"`
+	AtRisk   bool   `json:"atRisk"` // Streak ends unless the task is done today
+}
+
+// Dashboard bundles everything the main screen needs in one round trip
+type Dashboard struct {
+	Today               string          `json:"today"`
+	TodayTasks          []TaskTemplate  `json:"todayTasks"`
+	TodayValues         map[string]int  `json:"todayValues"`
+	TodayProvisional    map[string]bool `json:"todayProvisional"` // Prefilled, not yet recorded
+	WeekStart           string          `json:"weekStart"`
+	WeekPercentages     []float64       `json:"weekPercentages"`
+	CurrentStreak       int             `json:"currentStreak"`
+	LongestStreak       int             `json:"longestStreak"`
+	TaskStreaks         []TaskStreak    `json:"taskStreaks"`
+	AtRiskStreaks       []TaskStreak    `json:"atRiskStreaks"`
+	PendingExports      []string        `json:"pendingExports"` // Week starts not yet exported
+	UnreadNotifications []Notification  `json:"unreadNotifications"`
+}
+
+// GetDashboard returns the main screen's data in a single call
+func (a *App) GetDashboard() Dashboard {
+	now := time.Now()
+	today := now.Format("2006-01-02")
+	weekStart := weekStartOf(now)
+
+	// One lock for everything, so the snapshot is consistent; the longest
+	// streak and per-task streaks reach into archived years
+	defer a.lockDays("", "")()
+
+	weekPercentages, _, _ := a.weeklyAverageLocked(weekStart)
+	streaks := a.streaksLocked("")
+
+	dashboard := Dashboard{
+		Today:               today,
+		TodayTasks:          a.getTasksForDateLocked(today),
+		TodayValues:         make(map[string]int),
+		WeekStart:           weekStart.Format("2006-01-02"),
+		WeekPercentages:     weekPercentages,
+		CurrentStreak:       streaks.current,
+		LongestStreak:       streaks.longest,
+		TaskStreaks:         []TaskStreak{},
+		AtRiskStreaks:       []TaskStreak{},
+		PendingExports:      a.pendingExportsLocked(now),
+		UnreadNotifications: a.unreadNotificationsLocked(),
+	}
+
+	for i := range dashboard.TodayTasks {
+		if dashboard.TodayTasks[i].Type == "" {
+			dashboard.TodayTasks[i].Type = "binary"
+		}
+	}
+	a.sortTasksLocked(dashboard.TodayTasks)
+
+	for k, v := range a.data.Days[today] {
+		dashboard.TodayValues[k] = v
+	}
+	dashboard.TodayProvisional = a.prefillLocked(today, dashboard.TodayTasks, dashboard.TodayValues)
+
+	for _, task := range dashboard.TodayTasks {
+		// Weekly-quota tasks aren't expected daily, so day streaks don't apply
+		if isFrequencyTask(task) {
+			continue
+		}
+
+		streak, doneToday := a.currentStreakLocked(task, now)
+		if streak == 0 {
+			continue
+		}
+
+		skippedToday := a.taskSkippedLocked(today, task.ID)
+		entry := TaskStreak{TaskID: task.ID, TaskName: task.Name, Streak: streak, AtRisk: !doneToday && !skippedToday}
+		dashboard.TaskStreaks = append(dashboard.TaskStreaks, entry)
+		if entry.AtRisk {
+			dashboard.AtRiskStreaks = append(dashboard.AtRiskStreaks, entry)
+		}
+	}
+
+	return dashboard
+}
+
+// taskStreakLocked counts consecutive successful days for a task ending on
+// `through` (inclusive). Days the task doesn't apply to or was skipped on
+// are passed over; days without data end the streak (must hold lock).
+func (a *App) taskStreakLocked(task TaskTemplate, through string) int {
+	streak, _ := a.streakSpanLocked(task, through)
+	return streak
+}
+
+// streakSpanLocked is taskStreakLocked that also returns the first day of
+// the streak ("" when there is no streak) (must hold lock)
+func (a *App) streakSpanLocked(task TaskTemplate, through string) (streak int, first string) {
+	day, err := time.Parse("2006-01-02", through)
+	if err != nil {
+		return 0, ""
+	}
+
+	for i := 0; i < maxStreakLookbackDays; i++ {
+		dateKey := day.Format("2006-01-02")
+		if dateKey < task.CreatedAt {
+			break
+		}
+
+		if a.taskAppliesLocked(task, dateKey) && !a.dayExcludedLocked(dateKey) && !a.taskSkippedLocked(dateKey, task.ID) {
+			dayTasks, ok := a.data.Days[dateKey]
+			if !ok || !taskSucceeded(task, dateKey, dayTasks[task.ID]) {
+				break
+			}
+			streak++
+			first = dateKey
+		}
+
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return streak, first
+}
+
+// pendingExportsLocked returns past week starts with data that haven't been
+// exported yet, newest first (must hold lock)
+func (a *App) pendingExportsLocked(now time.Time) []string {
+	pending := []string{}
+	thisWeek := weekStartOf(now)
+
+	for i := 1; i <= dashboardExportWeeks; i++ {
+		start := thisWeek.AddDate(0, 0, -7*i)
+		key := start.Format("2006-01-02")
+		if _, exported := a.data.ExportHistory[key]; exported {
+			continue
+		}
+
+		for d := 0; d < 7; d++ {
+			if _, ok := a.dayScoreLocked(start.AddDate(0, 0, d).Format("2006-01-02")); ok {
+				pending = append(pending, key)
+				break
+			}
+		}
+	}
+
+	return pending
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// weekStartOf returns the Monday of the week containing t, matching the
+// frontend's Monday-start weeks
+func weekStartOf(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// canonicalWeekStart maps any date to the start of its week, so the same
+// week is always keyed the same way
+func canonicalWeekStart(date string) (string, error) {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", errors.New("invalid date")
+	}
+	return weekStartOf(t).Format("2006-01-02"), nil
+}
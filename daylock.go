@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxEditWindowDays is the longest edit window that can be set
+const maxEditWindowDays = 365
+
+// GetEditWindow returns how many days back can still be edited; 0 means
+// every day can
+func (a *App) GetEditWindow() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.data.EditWindowDays
+}
+
+// SetEditWindow makes days more than days before today read-only, so missed
+// days can't be fixed afterwards. 1 leaves today and yesterday editable;
+// 0 turns locking off.
+func (a *App) SetEditWindow(days int) error {
+	if days < 0 || days > maxEditWindowDays {
+		return fmt.Errorf("edit window must be between 0 and %d days", maxEditWindowDays)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.audit("SetEditWindow", "", "", a.data.EditWindowDays, days)
+	a.data.EditWindowDays = days
+	return a.saveDataLocked()
+}
+
+// IsDayLocked reports whether a date is outside the edit window and hasn't
+// been unlocked
+func (a *App) IsDayLocked(date string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.checkEditableLocked(date) != nil
+}
+
+// UnlockDayForEditing lets a locked day be edited until the app quits. The
+// unlock is recorded in the audit log, so overrides stay visible.
+func (a *App) UnlockDayForEditing(date string) error {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return errors.New("invalid date")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.unlockedDays[date] || !a.dayOutsideEditWindowLocked(date) {
+		return nil
+	}
+	if a.unlockedDays == nil {
+		a.unlockedDays = make(map[string]bool)
+	}
+	a.unlockedDays[date] = true
+	a.auditNow("UnlockDayForEditing", date, "", false, true)
+	return nil
+}
+
+// LockDayAgain undoes UnlockDayForEditing before the app quits
+func (a *App) LockDayAgain(date string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.unlockedDays[date] {
+		delete(a.unlockedDays, date)
+		a.auditNow("LockDayAgain", date, "", true, false)
+	}
+}
+
+// dayOutsideEditWindowLocked reports whether date is older than the edit
+// window, counting from the day check-offs currently go to (must hold lock)
+func (a *App) dayOutsideEditWindowLocked(date string) bool {
+	if a.data.EditWindowDays <= 0 {
+		return false
+	}
+	today, err := time.Parse("2006-01-02", a.checkInDateLocked(time.Now()))
+	if err != nil {
+		return false
+	}
+	cutoff := today.AddDate(0, 0, -a.data.EditWindowDays).Format("2006-01-02")
+	return date < cutoff
+}
+
+// checkEditableLocked returns an error when a day's values are locked
+// (must hold lock)
+func (a *App) checkEditableLocked(date string) error {
+	if a.dayOutsideEditWindowLocked(date) && !a.unlockedDays[date] {
+		return fmt.Errorf("days older than %d days are locked; unlock the day to edit it", a.data.EditWindowDays)
+	}
+	return nil
+}
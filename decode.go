@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// QuarantinedEntry is a saved value that couldn't be read. It is kept in
+// data.json as-is so nothing is lost, but left out of Days and every report.
+type QuarantinedEntry struct {
+	Date   string          `json:"date"`
+	TaskID string          `json:"taskId,omitempty"` // Empty when the whole day was unreadable
+	Raw    json.RawMessage `json:"raw"`
+	Reason string          `json:"reason"`
+	Found  string          `json:"found"` // RFC3339 time the entry was set aside
+}
+
+// plannerDataWire mirrors PlannerData with Days kept raw so each day and
+// value can be decoded (and rejected) individually. The outer Days field
+// shadows the embedded one during unmarshalling.
+type plannerDataWire struct {
+	PlannerData
+	Days map[string]json.RawMessage `json:"days"`
+}
+
+// isPlannerDataFormat reports whether data looks like the current format.
+// Unmarshalling old-format data into PlannerData succeeds with empty fields,
+// so we look for known top-level keys instead.
+func isPlannerDataFormat(data []byte) bool {
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal(data, &root); err != nil {
+		return false
+	}
+	for _, key := range []string{"version", "templates", "days", "exportPath", "exportHistory"} {
+		if _, ok := root[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// decodePlannerData decodes the current data format. Day values that can't
+// be read are returned as quarantined entries instead of being dropped.
+// ok is false when data isn't in the current format at all.
+func decodePlannerData(data []byte) (decoded PlannerData, quarantined []QuarantinedEntry, ok bool) {
+	if !isPlannerDataFormat(data) {
+		return PlannerData{}, nil, false
+	}
+
+	var wire plannerDataWire
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return PlannerData{}, nil, false
+	}
+
+	found := time.Now().Format(time.RFC3339)
+	reject := func(date, taskID string, raw json.RawMessage, reason string) {
+		quarantined = append(quarantined, QuarantinedEntry{
+			Date:   date,
+			TaskID: taskID,
+			Raw:    append(json.RawMessage(nil), raw...),
+			Reason: reason,
+			Found:  found,
+		})
+	}
+
+	days := make(map[string]DayTasks)
+	for date, rawDay := range wire.Days {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			reject(date, "", rawDay, "invalid date")
+			continue
+		}
+
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(rawDay, &values); err != nil {
+			reject(date, "", rawDay, "day is not an object")
+			continue
+		}
+
+		dayTasks := make(DayTasks)
+		for id, raw := range values {
+			value, err := decodeDayValue(raw)
+			if err != nil {
+				reject(date, id, raw, err.Error())
+				continue
+			}
+			dayTasks[id] = value
+		}
+		days[date] = dayTasks
+	}
+
+	// Default type for older templates.
+	for i := range wire.Templates {
+		if wire.Templates[i].Type == "" {
+			wire.Templates[i].Type = "binary"
+		}
+	}
+
+	decoded = wire.PlannerData
+	decoded.Days = days
+	if decoded.ExportHistory == nil {
+		decoded.ExportHistory = make(map[string]string)
+	}
+	return decoded, quarantined, true
+}
+
+// decodeDayValue reads a single day value. Older files stored booleans;
+// current files store whole numbers.
+func decodeDayValue(raw json.RawMessage) (int, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return 0, errors.New("unreadable value")
+	}
+
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, errors.New("not a whole number")
+		}
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			return 0, errors.New("number out of range")
+		}
+		return int(n), nil
+	case nil:
+		return 0, errors.New("null value")
+	}
+	return 0, errors.New("unsupported value type")
+}
+
+// quarantineLocked adds newly rejected entries to the quarantine, skipping
+// ones already kept from an earlier load, and tells the user
+// (must hold lock; caller saves)
+func (a *App) quarantineLocked(entries []QuarantinedEntry) {
+	known := make(map[string]bool)
+	for _, q := range a.data.Quarantine {
+		known[q.Date+"/"+q.TaskID] = true
+	}
+
+	added := 0
+	for _, q := range entries {
+		if known[q.Date+"/"+q.TaskID] {
+			continue
+		}
+		a.data.Quarantine = append(a.data.Quarantine, q)
+		known[q.Date+"/"+q.TaskID] = true
+		added++
+	}
+
+	if added > 0 {
+		println("Quarantined unreadable entries:", added)
+		a.notifyLocked("data", "Some saved values couldn't be read",
+			fmt.Sprintf("%d entries were set aside and left out of your stats. Nothing was deleted.", added))
+	}
+}
+
+// GetQuarantine returns saved entries that couldn't be read
+func (a *App) GetQuarantine() []QuarantinedEntry {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return append([]QuarantinedEntry{}, a.data.Quarantine...)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// FuzzDecodePlannerData feeds decodePlannerData malformed data files. It
+// must never panic, and anything it accepts must save and load again.
+func FuzzDecodePlannerData(f *testing.F) {
+	seeds := []string{
+		// Current format
+		`{"version":4,"templates":[{"id":"t1","name":"Read","type":"count","order":0}],"days":{"2024-01-01":{"t1":3}},"exportHistory":{}}`,
+		// Older templates without a type, and boolean values
+		`{"templates":[{"id":"t1","name":"Read"}],"days":{"2024-01-01":{"t1":true,"t2":false}}}`,
+		// Legacy format: four fixed tasks per day
+		`{"2024-01-01":[true,false,true,false]}`,
+		// Truncated
+		`{"version":4,"templates":[{"id":"t1","na`,
+		`{"days":{"2024-01-01":{"t1":`,
+		// Wrong types
+		`{"version":"four","days":[]}`,
+		`{"templates":{},"days":{"2024-01-01":[1,2]}}`,
+		`{"days":{"not-a-date":{"t1":1},"2024-01-02":"x","2024-01-03":{"t1":1.5,"t2":null,"t3":"1","t4":1e20}}}`,
+		`{"days":null,"exportHistory":null}`,
+		`[]`,
+		`null`,
+		``,
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoded, quarantined, ok := decodePlannerData(data)
+		if !ok {
+			if len(quarantined) > 0 {
+				t.Fatalf("rejected data returned %d quarantined entries", len(quarantined))
+			}
+			return
+		}
+		for _, q := range quarantined {
+			if _, kept := decoded.Days[q.Date][q.TaskID]; kept && q.TaskID != "" {
+				t.Fatalf("quarantined %s/%s is still in Days", q.Date, q.TaskID)
+			}
+		}
+
+		encoded, err := json.Marshal(decoded)
+		if err != nil {
+			t.Fatalf("decoded data doesn't marshal: %v", err)
+		}
+		again, requarantined, ok := decodePlannerData(encoded)
+		if !ok {
+			t.Fatalf("re-marshalled data isn't readable: %s", encoded)
+		}
+		if len(requarantined) > 0 {
+			t.Fatalf("re-marshalled data quarantined %d entries", len(requarantined))
+		}
+		if len(again.Days) != len(decoded.Days) {
+			t.Fatalf("re-marshalled data has %d days, want %d", len(again.Days), len(decoded.Days))
+		}
+	})
+}
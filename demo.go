@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// demoWeeks is how much sample history demo mode starts with
+const demoWeeks = 12
+
+// demoPresets seed the demo's tasks
+var demoPresets = []string{defaultPresetID, "fitness-starter"}
+
+// demoState is the real state put aside while demo mode is on
+type demoState struct {
+	dir            string // Throwaway directory for the demo's settings, backups and audit log
+	data           PlannerData
+	settings       LocalSettings
+	settingsPath   string
+	dataPath       string
+	store          dataStore
+	dataKey        []byte
+	dataSalt       []byte
+	dataPassphrase string
+	locked         bool
+	undoStack      []undoStep
+	redoStack      []undoStep
+	review         *ReviewState
+	dataSum        string
+	journalMetaSum string
+	cloudSession   *oauthSession
+}
+
+// memoryStore keeps the demo's data in memory only
+type memoryStore struct {
+	saved []byte
+}
+
+func (s *memoryStore) path() string {
+	return "(demo)"
+}
+
+func (s *memoryStore) load() (PlannerData, []QuarantinedEntry, error) {
+	data, quarantined, ok := decodePlannerData(s.saved)
+	if !ok {
+		return PlannerData{}, nil, errors.New("no demo data")
+	}
+	return data, quarantined, nil
+}
+
+func (s *memoryStore) save(data *PlannerData) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	s.saved = encoded
+	return nil
+}
+
+func (s *memoryStore) snapshot() ([]byte, error) {
+	return s.saved, nil
+}
+
+func (s *memoryStore) close() error {
+	return nil
+}
+
+// IsDemoMode reports whether the app is showing sample data
+func (a *App) IsDemoMode() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.demo != nil
+}
+
+// StartDemoMode puts the real data aside and shows sample data instead, to
+// show the app without showing your habits. Everything done in demo mode
+// is thrown away by ExitDemoMode. Local settings are put aside too, so
+// sync, backups and scheduled exports don't run on the sample data.
+func (a *App) StartDemoMode() error {
+	if a.replayPath != "" {
+		return errors.New("demo mode isn't available while replaying")
+	}
+
+	a.mu.Lock()
+	if a.demo != nil {
+		a.mu.Unlock()
+		return errors.New("demo mode is already on")
+	}
+	dir, err := os.MkdirTemp("", "plan-demo-*")
+	if err != nil {
+		a.mu.Unlock()
+		return err
+	}
+
+	a.demo = &demoState{
+		dir:            dir,
+		data:           a.data,
+		settings:       a.settings,
+		settingsPath:   a.settingsPath,
+		dataPath:       a.dataPath,
+		store:          a.store,
+		dataKey:        a.dataKey,
+		dataSalt:       a.dataSalt,
+		dataPassphrase: a.dataPassphrase,
+		locked:         a.locked,
+		undoStack:      a.undoStack,
+		redoStack:      a.redoStack,
+		review:         a.review,
+		dataSum:        a.dataSum,
+		journalMetaSum: a.journalMetaSum,
+		cloudSession:   a.cloudSession,
+	}
+	a.settings = LocalSettings{DeviceID: "demo"}
+	a.settingsPath = filepath.Join(dir, "settings.json")
+	a.dataPath = filepath.Join(dir, "data.json")
+	a.store = &memoryStore{}
+	a.dataKey, a.dataSalt, a.dataPassphrase, a.locked = nil, nil, "", false
+	a.undoStack, a.redoStack, a.review = nil, nil, nil
+	a.dataSum, a.journalMetaSum, a.cloudSession = "", "", nil
+	a.seedDemoDataLocked()
+	a.rememberSavedDaysLocked()
+	err = a.saveDataLocked()
+	a.mu.Unlock()
+
+	a.notifyDataDirChanged()
+	a.emitDataChanged("")
+	a.refreshMenu()
+	return err
+}
+
+// ExitDemoMode throws the demo away and goes back to the real data, as it
+// was when demo mode started
+func (a *App) ExitDemoMode() error {
+	a.mu.Lock()
+	demo := a.demo
+	if demo == nil {
+		a.mu.Unlock()
+		return errors.New("demo mode is not on")
+	}
+
+	a.data = demo.data
+	a.settings = demo.settings
+	a.settingsPath = demo.settingsPath
+	a.dataPath = demo.dataPath
+	a.store = demo.store
+	a.dataKey, a.dataSalt, a.dataPassphrase, a.locked = demo.dataKey, demo.dataSalt, demo.dataPassphrase, demo.locked
+	a.undoStack, a.redoStack, a.review = demo.undoStack, demo.redoStack, demo.review
+	a.dataSum, a.journalMetaSum, a.cloudSession = demo.dataSum, demo.journalMetaSum, demo.cloudSession
+	a.saveStatus = SaveStatus{}
+	a.demo = nil
+	a.forgetYearArchives()
+	a.rememberSavedDaysLocked()
+	a.mu.Unlock()
+
+	os.RemoveAll(demo.dir)
+	a.notifyDataDirChanged()
+	a.emitDataChanged("")
+	a.refreshMenu()
+	return nil
+}
+
+// seedDemoDataLocked replaces the data with sample data: the demo presets'
+// tasks with a few weeks of plausible, mostly-kept history (must hold lock;
+// caller saves)
+func (a *App) seedDemoDataLocked() {
+	a.forgetYearArchives()
+	a.data = PlannerData{
+		Version:            schemaVersion,
+		Templates:          []TaskTemplate{},
+		Days:               make(map[string]DayTasks),
+		ExportHistory:      make(map[string]string),
+		SeenChangesVersion: latestChangeVersion(),
+	}
+	for _, id := range demoPresets {
+		a.applyPresetLocked(id)
+	}
+
+	// A fixed seed shows the same demo every time
+	random := rand.New(rand.NewPCG(1, 2))
+	today := time.Now()
+	start := today.AddDate(0, 0, -7*demoWeeks)
+	for i := range a.data.Templates {
+		a.data.Templates[i].CreatedAt = start.Format("2006-01-02")
+	}
+
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		tasks := make(DayTasks)
+		for _, task := range a.data.Templates {
+			// Habits get more reliable over the weeks
+			kept := random.Float64() < 0.55+0.35*day.Sub(start).Hours()/today.Sub(start).Hours()
+			switch {
+			case isValueTask(task):
+				if a.data.Measurements == nil {
+					a.data.Measurements = make(map[string]map[string]float64)
+				}
+				if a.data.Measurements[date] == nil {
+					a.data.Measurements[date] = make(map[string]float64)
+				}
+				a.data.Measurements[date][task.ID] = float64(12+random.IntN(6)) / 2
+				tasks[task.ID] = 1
+			case task.Target > 0:
+				if kept {
+					tasks[task.ID] = task.Target + random.IntN(task.Target/4+1)
+				} else {
+					tasks[task.ID] = random.IntN(task.Target)
+				}
+			case kept:
+				tasks[task.ID] = 1
+			}
+		}
+		a.data.Days[date] = tasks
+	}
+}
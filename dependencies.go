@@ -0,0 +1,181 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// dependencyWarningEvent is emitted with a []DependencyWarning when a task
+// is completed before its prerequisite
+const dependencyWarningEvent = "plan:dependency-warning"
+
+// DependencyNode is a task in the dependency graph
+type DependencyNode struct {
+	TaskID     string   `json:"taskId"`
+	TaskName   string   `json:"taskName"`
+	Requires   string   `json:"requires,omitempty"` // Prerequisite task ID
+	Dependents []string `json:"dependents"`         // Tasks that require this one
+	Depth      int      `json:"depth"`              // Prerequisites above this task in its chain
+}
+
+// DependencyWarning reports a task done on a day its prerequisite wasn't
+type DependencyWarning struct {
+	Date         string `json:"date"`
+	TaskID       string `json:"taskId"`
+	TaskName     string `json:"taskName"`
+	RequiresID   string `json:"requiresId"`
+	RequiresName string `json:"requiresName"`
+}
+
+// SetTaskPrerequisite makes a task require another one to be done first
+// ("" removes the prerequisite). Chains that loop back are rejected.
+func (a *App) SetTaskPrerequisite(id string, requiresID string) error {
+	if id == requiresID {
+		return errors.New("a task cannot require itself")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	index := -1
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return errors.New("task not found")
+	}
+
+	if requiresID != "" {
+		if _, ok := a.findTemplateLocked(requiresID); !ok {
+			return errors.New("prerequisite task not found")
+		}
+		// Walk up from the prerequisite; reaching id would close a loop
+		seen := map[string]bool{}
+		for next := requiresID; next != ""; {
+			if next == id {
+				return errors.New("prerequisite would create a cycle")
+			}
+			if seen[next] {
+				break
+			}
+			seen[next] = true
+			t, ok := a.findTemplateLocked(next)
+			if !ok {
+				break
+			}
+			next = t.Requires
+		}
+	}
+
+	old := a.data.Templates[index].Requires
+	a.data.Templates[index].Requires = requiresID
+	a.audit("SetTaskPrerequisite", "", id, old, requiresID)
+	return a.saveDataLocked()
+}
+
+// GetDependencyGraph returns active tasks with their prerequisites and
+// dependents, prerequisites before the tasks that need them
+func (a *App) GetDependencyGraph() []DependencyNode {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	tasks := []TaskTemplate{}
+	for _, t := range a.data.Templates {
+		if t.DeletedAt == nil && !t.Archived {
+			tasks = append(tasks, t)
+		}
+	}
+	a.sortTasksLocked(tasks)
+
+	active := make(map[string]TaskTemplate)
+	for _, t := range tasks {
+		active[t.ID] = t
+	}
+
+	nodes := []DependencyNode{}
+	index := make(map[string]int)
+	for _, t := range tasks {
+		requires := ""
+		if _, ok := active[t.Requires]; ok {
+			requires = t.Requires
+		}
+		index[t.ID] = len(nodes)
+		nodes = append(nodes, DependencyNode{TaskID: t.ID, TaskName: t.Name, Requires: requires, Dependents: []string{}})
+	}
+
+	for i, node := range nodes {
+		if node.Requires != "" {
+			parent := index[node.Requires]
+			nodes[parent].Dependents = append(nodes[parent].Dependents, node.TaskID)
+		}
+		for next := node.Requires; next != "" && nodes[i].Depth < len(nodes); next = nodes[index[next]].Requires {
+			nodes[i].Depth++
+		}
+	}
+
+	// Stable by depth keeps the display order within each level
+	ordered := make([]DependencyNode, 0, len(nodes))
+	for depth := 0; len(ordered) < len(nodes); depth++ {
+		for _, node := range nodes {
+			if node.Depth == depth {
+				ordered = append(ordered, node)
+			}
+		}
+	}
+	return ordered
+}
+
+// GetDependencyWarnings lists tasks done on a date whose prerequisite wasn't
+func (a *App) GetDependencyWarnings(date string) []DependencyWarning {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.dependencyWarningsLocked(date, nil)
+}
+
+// dependencyWarningsLocked checks done tasks on a date against their
+// prerequisites. With taskIDs set, only those tasks are checked (must hold lock).
+func (a *App) dependencyWarningsLocked(date string, taskIDs []string) []DependencyWarning {
+	warnings := []DependencyWarning{}
+	only := make(map[string]bool)
+	for _, id := range taskIDs {
+		only[id] = true
+	}
+
+	values := a.data.Days[date]
+	for _, task := range a.getTasksForDateLocked(date) {
+		if task.Requires == "" || (len(only) > 0 && !only[task.ID]) {
+			continue
+		}
+		if _, recorded := values[task.ID]; !recorded || !taskSucceeded(task, date, values[task.ID]) {
+			continue
+		}
+		prereq, ok := a.findTemplateLocked(task.Requires)
+		if !ok || !a.taskAppliesLocked(prereq, date) || a.taskSkippedLocked(date, prereq.ID) {
+			continue
+		}
+		if _, recorded := values[prereq.ID]; recorded && taskSucceeded(prereq, date, values[prereq.ID]) {
+			continue
+		}
+		warnings = append(warnings, DependencyWarning{
+			Date:         date,
+			TaskID:       task.ID,
+			TaskName:     task.Name,
+			RequiresID:   prereq.ID,
+			RequiresName: prereq.Name,
+		})
+	}
+
+	return warnings
+}
+
+// emitDependencyWarnings tells the frontend about tasks done out of order
+func (a *App) emitDependencyWarnings(warnings []DependencyWarning) {
+	if a.ctx != nil && len(warnings) > 0 {
+		runtime.EventsEmit(a.ctx, dependencyWarningEvent, warnings)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+)
+
+// GetDiagnostics returns troubleshooting information about storage and background jobs
+func (a *App) GetDiagnostics() map[string]interface{} {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := map[string]interface{}{
+		"dataPath":      a.dataPath,
+		"settingsPath":  a.settingsPath,
+		"dataFileSize":  int64(0),
+		"templateCount": len(a.data.Templates),
+		"dayCount":      len(a.data.Days),
+		"quarantined":   len(a.data.Quarantine),
+		"storage":       storageJSON,
+		"schemaVersion": a.data.Version,
+	}
+	if _, ok := a.store.(*sqliteStore); ok {
+		result["storage"] = storageSQLite
+	}
+
+	if info, err := os.Stat(a.dataPath); err == nil {
+		result["dataFileSize"] = info.Size()
+	}
+
+	if backup := a.settings.SecondaryBackup; backup != nil {
+		result["secondaryBackupDir"] = backup.Dir
+		result["secondaryBackupLastSuccess"] = backup.LastSuccess
+		result["secondaryBackupLastError"] = backup.LastError
+	}
+
+	if cleanup := a.tempCleanup; cleanup != nil {
+		result["tempFilesCleanedAt"] = cleanup.At
+		result["tempFilesRemoved"] = cleanup.Removed
+		result["tempFilesNotRemoved"] = cleanup.Failed
+	}
+
+	return result
+}
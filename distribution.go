@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// maxDistributionBuckets bounds how many buckets a value histogram has,
+// besides the one for 0
+const maxDistributionBuckets = 10
+
+// ValueBucket is one bar of a value histogram: days with a value from From
+// to To (inclusive)
+type ValueBucket struct {
+	From  int    `json:"from"`
+	To    int    `json:"to"`
+	Label string `json:"label"` // e.g. "0", "1–10"
+	Days  int    `json:"days"`
+}
+
+// ValueDistribution is a histogram of a task's daily values
+type ValueDistribution struct {
+	TaskID  string        `json:"taskId"`
+	Unit    string        `json:"unit,omitempty"`
+	From    string        `json:"from"`
+	To      string        `json:"to"`
+	Buckets []ValueBucket `json:"buckets"` // The first holds days at 0
+	Days    int           `json:"days"`    // Days counted
+	Mean    float64       `json:"mean"`
+	Median  float64       `json:"median"`
+	Max     int           `json:"max"`
+}
+
+// GetValueDistribution counts how often a count, duration or scale task
+// reached each range of values between from and to (inclusive). Days the
+// task applied without a recorded value count as 0; excluded and future
+// days are left out.
+func (a *App) GetValueDistribution(taskID string, from string, to string) (ValueDistribution, error) {
+	r, err := newDateRange(from, to)
+	if err != nil {
+		return ValueDistribution{}, err
+	}
+
+	defer a.lockDays(r.From, r.To)()
+
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		return ValueDistribution{}, errors.New("task not found")
+	}
+	switch taskTypeOf(task) {
+	case "count", "duration", "scale":
+	default:
+		return ValueDistribution{}, errors.New("only count, duration and scale tasks have a value distribution")
+	}
+
+	dist := ValueDistribution{TaskID: taskID, Unit: task.Unit, From: r.From, To: r.To, Buckets: []ValueBucket{}}
+	values := []int{}
+	today := time.Now().Format("2006-01-02")
+	start, _ := time.Parse("2006-01-02", r.From)
+	end, _ := time.Parse("2006-01-02", r.To)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		if date > today || !a.taskAppliesLocked(task, date) || a.dayExcludedLocked(date) {
+			continue
+		}
+		values = append(values, a.data.Days[date][taskID])
+	}
+	if len(values) == 0 {
+		return dist, nil
+	}
+
+	sort.Ints(values)
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	dist.Days = len(values)
+	dist.Max = values[len(values)-1]
+	dist.Mean = float64(total) / float64(len(values))
+	if mid := len(values) / 2; len(values)%2 == 1 {
+		dist.Median = float64(values[mid])
+	} else {
+		dist.Median = float64(values[mid-1]+values[mid]) / 2
+	}
+
+	dist.Buckets = append(dist.Buckets, ValueBucket{From: 0, To: 0, Label: "0"})
+	width := bucketWidth(dist.Max)
+	for low := 1; low <= dist.Max; low += width {
+		high := low + width - 1
+		label := fmt.Sprintf("%d–%d", low, high)
+		if width == 1 {
+			label = fmt.Sprint(low)
+		}
+		dist.Buckets = append(dist.Buckets, ValueBucket{From: low, To: high, Label: label})
+	}
+	for _, v := range values {
+		i := 0
+		if v > 0 {
+			i = (v-1)/width + 1
+		}
+		dist.Buckets[i].Days++
+	}
+	return dist, nil
+}
+
+// bucketWidth picks a round bucket width (1, 2, 5, 10, 20, 50, ...) so
+// values up to top fit in at most maxDistributionBuckets buckets
+func bucketWidth(top int) int {
+	for scale := 1; ; scale *= 10 {
+		for _, step := range []int{1, 2, 5} {
+			if width := step * scale; (top+width-1)/width <= maxDistributionBuckets {
+				return width
+			}
+		}
+	}
+}
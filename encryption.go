@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// minPassphraseLength is the shortest passphrase accepted for encryption
+const minPassphraseLength = 8
+
+// errDataLocked is returned while encrypted data hasn't been unlocked
+var errDataLocked = errors.New("data is locked")
+
+// errOtherKey is returned for a file encrypted with another passphrase than
+// the data's, or while the data isn't encrypted. It opens with its
+// passphrase.
+var errOtherKey = errors.New("file was encrypted separately; enter its passphrase")
+
+// sealLocked encrypts data with the unlocked key when encryption is on,
+// and returns it unchanged otherwise (must hold lock)
+func (a *App) sealLocked(data []byte) ([]byte, error) {
+	if a.dataKey == nil {
+		return data, nil
+	}
+	return encryptWithKey(a.dataKey, a.dataSalt, data)
+}
+
+// openLocked decrypts data sealed with the unlocked key and decompresses
+// it if it was saved compressed; plain data is returned unchanged. Files
+// sealed with another salt, such as backups from before encryption was
+// turned off and on or files from another device, are opened with the
+// passphrase and the salt in their own header; errOtherKey means the
+// passphrase differs (must hold lock).
+func (a *App) openLocked(data []byte) ([]byte, error) {
+	if !isEncrypted(data) {
+		return decompressData(data)
+	}
+	if a.locked {
+		return nil, errDataLocked
+	}
+	var plaintext []byte
+	var err error
+	switch {
+	case a.dataKey != nil && bytes.Equal(embeddedSalt(data), a.dataSalt):
+		plaintext, err = decryptWithKey(a.dataKey, data)
+	case a.dataPassphrase != "":
+		if plaintext, err = decryptWithPassphrase(a.dataPassphrase, data); err != nil {
+			err = errOtherKey
+		}
+	default:
+		err = errOtherKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decompressData(plaintext)
+}
+
+// openWithPassphraseLocked is openLocked for files that may have been
+// encrypted separately: with a passphrase, the key is derived from the
+// salt in the file's own header (must hold lock)
+func (a *App) openWithPassphraseLocked(data []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" || !isEncrypted(data) {
+		return a.openLocked(data)
+	}
+	plaintext, err := decryptWithPassphrase(passphrase, data)
+	if err != nil {
+		return nil, err
+	}
+	return decompressData(plaintext)
+}
+
+// IsEncrypted reports whether data.json is encrypted with a passphrase
+func (a *App) IsEncrypted() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.dataKey != nil || a.locked
+}
+
+// IsLocked reports whether the data is encrypted and waiting for Unlock
+func (a *App) IsLocked() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.locked
+}
+
+// EnableEncryption encrypts data.json and its backups with AES-GCM, using
+// a key derived from passphrase. The passphrase can't be recovered: without
+// it the data is lost. Exports and the audit log are not encrypted.
+func (a *App) EnableEncryption(passphrase string) error {
+	if len([]rune(passphrase)) < minPassphraseLength {
+		return errors.New("passphrase must be at least 8 characters")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.locked {
+		return errDataLocked
+	}
+	if a.dataKey != nil {
+		return errors.New("data is already encrypted")
+	}
+	if _, ok := a.store.(*jsonStore); !ok {
+		return errors.New("encryption needs data.json storage")
+	}
+
+	salt, err := newSalt()
+	if err != nil {
+		return err
+	}
+	if err := a.readYearArchivesLocked(); err != nil {
+		return err
+	}
+	logs := a.readSealedFilesLocked()
+	a.dataKey, a.dataSalt, a.dataPassphrase = deriveKey(passphrase, salt), salt, passphrase
+	// The logs are sealed before the save adds this change to them
+	err = a.rewriteSealedFilesLocked(logs)
+	if err == nil {
+		a.audit("EnableEncryption", "", "", false, true)
+		err = a.saveDataLocked()
+	}
+	if err != nil {
+		a.dataKey, a.dataSalt, a.dataPassphrase = nil, nil, ""
+		a.restoreSealedFilesLocked(logs)
+		return err
+	}
+	if err := a.rewriteYearArchivesLocked(); err != nil {
+		return err
+	}
+	if err := a.resetHistoryLocked(); err != nil {
+		return err
+	}
+	return a.sealBackupsLocked()
+}
+
+// sealBackupsLocked encrypts backups written before encryption was turned
+// on, so no plain copy is left behind (must hold lock)
+func (a *App) sealBackupsLocked() error {
+	paths, err := filepath.Glob(filepath.Join(a.backupsDir(), "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil || isEncrypted(content) {
+			continue
+		}
+		sealed, err := a.sealLocked(content)
+		if err != nil {
+			return err
+		}
+		if err := a.atomicWriteFile(path, sealed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DisableEncryption writes data.json in plain text again. The passphrase
+// is asked for so an unlocked, unattended app can't be decrypted for good.
+func (a *App) DisableEncryption(passphrase string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.locked {
+		return errDataLocked
+	}
+	if a.dataKey == nil {
+		return nil
+	}
+	if string(deriveKey(passphrase, a.dataSalt)) != string(a.dataKey) {
+		return errors.New("wrong passphrase")
+	}
+
+	if err := a.readYearArchivesLocked(); err != nil {
+		return err
+	}
+	logs := a.readSealedFilesLocked()
+	key, salt := a.dataKey, a.dataSalt
+	a.dataKey, a.dataSalt, a.dataPassphrase = nil, nil, ""
+	err := a.rewriteSealedFilesLocked(logs)
+	if err == nil {
+		a.audit("DisableEncryption", "", "", true, false)
+		err = a.saveDataLocked()
+	}
+	if err != nil {
+		a.dataKey, a.dataSalt, a.dataPassphrase = key, salt, passphrase
+		a.restoreSealedFilesLocked(logs)
+		return err
+	}
+	a.historyMu.Lock()
+	a.historySealed = false
+	a.historyMu.Unlock()
+	return a.rewriteYearArchivesLocked()
+}
+
+// Lock forgets the key and clears the data from memory until Unlock is
+// called with the passphrase
+func (a *App) Lock() error {
+	a.mu.Lock()
+	if a.dataKey == nil {
+		a.mu.Unlock()
+		return errors.New("data is not encrypted")
+	}
+
+	a.dataKey, a.dataSalt, a.dataPassphrase = nil, nil, ""
+	a.locked = true
+	a.review = nil
+	a.clearUndoLocked()
+	a.forgetYearArchives()
+	a.data = PlannerData{
+		Templates:     []TaskTemplate{},
+		Days:          make(map[string]DayTasks),
+		ExportHistory: make(map[string]string),
+	}
+	a.mu.Unlock()
+
+	a.emitDataChanged("")
+	a.refreshMenu()
+	return nil
+}
+
+// Unlock decrypts the data with passphrase and loads it
+func (a *App) Unlock(passphrase string) error {
+	a.mu.Lock()
+	if !a.locked {
+		a.mu.Unlock()
+		return nil
+	}
+
+	sealed, err := os.ReadFile(a.dataPath)
+	if err != nil {
+		a.mu.Unlock()
+		return err
+	}
+	plaintext, err := decryptWithPassphrase(passphrase, sealed)
+	if err == nil {
+		plaintext, err = decompressData(plaintext)
+	}
+	if err != nil {
+		a.mu.Unlock()
+		return err
+	}
+	decoded, quarantined, ok := decodePlannerData(plaintext)
+	if !ok {
+		a.mu.Unlock()
+		return errors.New("unreadable data file")
+	}
+
+	salt := append([]byte{}, sealed[len(encryptedMagic):len(encryptedMagic)+saltSize]...)
+	a.dataKey, a.dataSalt, a.dataPassphrase = deriveKey(passphrase, salt), salt, passphrase
+	a.dataSum = checksum(sealed)
+	a.locked = false
+	a.data = decoded
+	if len(quarantined) > 0 {
+		a.quarantineLocked(quarantined)
+	}
+	a.migrateDataLocked()
+	a.recoverJournalLocked()
+	a.rememberSavedDaysLocked()
+	a.mu.Unlock()
+
+	a.emitDataChanged("")
+	a.refreshMenu()
+	return nil
+}
+
+// encodeDataLocked returns the data as written to data.json, encrypted
+// when encryption is on (must hold lock)
+func (a *App) encodeDataLocked() ([]byte, error) {
+	encoded, err := json.MarshalIndent(a.data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return a.sealLocked(encoded)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// changeFlushInterval is how long data changed events are held back, about
+// an animation frame, so a burst of changes (imports, bulk edits, a sync)
+// reaches the frontend as one event
+const changeFlushInterval = 16 * time.Millisecond
+
+// DataChange summarises the changes behind one data changed event, so the
+// frontend can refresh only the views they touch
+type DataChange struct {
+	All   bool     `json:"all"`            // Some change wasn't tied to a date; reload everything
+	Dates []string `json:"dates"`          // Dates whose data changed, oldest first
+	From  string   `json:"from,omitempty"` // Earliest changed date
+	To    string   `json:"to,omitempty"`   // Latest changed date
+	Count int      `json:"count"`          // Changes coalesced into this event
+}
+
+// pendingChanges collects data changes until the next flush
+type pendingChanges struct {
+	mu     sync.Mutex
+	dates  map[string]bool
+	all    bool // Some change wasn't tied to one date
+	count  int  // Changes since the last flush
+	queued bool // A flush is scheduled
+}
+
+// emitDataChanged tells the frontend that data for date changed ("" for
+// anything). Changes are coalesced into one event that lists the dates
+// changed since the last one, or says everything should be reloaded.
+func (a *App) emitDataChanged(date string) {
+	if a.ctx == nil {
+		return
+	}
+
+	c := &a.changes
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.count++
+	if date == "" {
+		c.all = true
+	} else {
+		if c.dates == nil {
+			c.dates = make(map[string]bool)
+		}
+		c.dates[date] = true
+	}
+	if c.queued {
+		return
+	}
+	c.queued = true
+	time.AfterFunc(changeFlushInterval, a.flushDataChanged)
+}
+
+// flushDataChanged emits one event for every change collected
+func (a *App) flushDataChanged() {
+	c := &a.changes
+	c.mu.Lock()
+	payload := DataChange{All: c.all, Dates: []string{}, Count: c.count}
+	for date := range c.dates {
+		payload.Dates = append(payload.Dates, date)
+	}
+	c.dates, c.all, c.count, c.queued = nil, false, 0, false
+	c.mu.Unlock()
+
+	sort.Strings(payload.Dates)
+	if len(payload.Dates) > 0 {
+		payload.From, payload.To = payload.Dates[0], payload.Dates[len(payload.Dates)-1]
+	}
+	runtime.EventsEmit(a.ctx, dataChangedEvent, payload)
+}
@@ -0,0 +1,568 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportFormat describes an export format offered to the frontend
+type ExportFormat struct {
+	ID        string `json:"id"`
+	Label     string `json:"label"`
+	Extension string `json:"extension"`
+}
+
+// exportProvider renders planner data for a date range in one format.
+// render runs with the read lock held.
+type exportProvider struct {
+	ExportFormat
+	render func(a *App, table exportTable, options map[string]string) ([]byte, error)
+}
+
+// exportProviders is the registry of export formats, in display order.
+// Add a provider here to make a new format available through Export.
+var exportProviders = []exportProvider{
+	{ExportFormat{ID: "html", Label: "Web page", Extension: "html"}, renderHTMLExport},
+	{ExportFormat{ID: "pdf", Label: "PDF document", Extension: "pdf"}, renderPDFExport},
+	{ExportFormat{ID: "csv", Label: "CSV spreadsheet", Extension: "csv"}, renderCSVExport},
+	{ExportFormat{ID: "xlsx", Label: "Excel workbook", Extension: "xlsx"}, renderXLSXExport},
+	{ExportFormat{ID: "md", Label: "Markdown", Extension: "md"}, renderMarkdownExport},
+	{ExportFormat{ID: "ics", Label: "Calendar (iCalendar)", Extension: "ics"}, renderICSExport},
+}
+
+// findExportProvider looks up a registered format by ID
+func findExportProvider(format string) (exportProvider, bool) {
+	for _, p := range exportProviders {
+		if p.ID == format {
+			return p, true
+		}
+	}
+	return exportProvider{}, false
+}
+
+// GetAvailableFormats returns the registered export formats
+func (a *App) GetAvailableFormats() []ExportFormat {
+	formats := make([]ExportFormat, 0, len(exportProviders))
+	for _, p := range exportProviders {
+		formats = append(formats, p.ExportFormat)
+	}
+	return formats
+}
+
+// Export renders a scope of planner data in the given format and saves it to
+// the export folder, returning the saved path.
+//
+// Scopes: "week:2006-01-02" (week start), "month:2006-01", "year:2006",
+// "range:2006-01-02..2006-01-31" or "all".
+// Options: "filename" overrides the file name; for html, "content" saves
+// pre-rendered HTML from the frontend as-is; "focus": "true" adds a focus
+// session summary to html and md exports. Plain html exports also draw the
+// saved custom charts.
+func (a *App) Export(format string, scope string, options map[string]string) (string, error) {
+	provider, ok := findExportProvider(format)
+	if !ok {
+		return "", errors.New("unknown export format")
+	}
+
+	a.mu.RLock()
+	from, to, err := a.exportScopeLocked(scope)
+	a.mu.RUnlock()
+	if err != nil {
+		return "", err
+	}
+	unlock := a.lockDays(from, to)
+	content, err := provider.render(a, a.exportTableLocked(from, to), options)
+	unlock()
+	if err != nil {
+		return "", err
+	}
+
+	filename := options["filename"]
+	if filename == "" {
+		filename = fmt.Sprintf("plan-%s-to-%s.%s", from, to, provider.Extension)
+	}
+	return a.writeExportFile(filepath.Base(filename), content)
+}
+
+// exportScopeLocked resolves a scope string to an inclusive date range (must hold lock)
+func (a *App) exportScopeLocked(scope string) (from, to string, err error) {
+	kind, value, _ := strings.Cut(scope, ":")
+	switch kind {
+	case "week":
+		start, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return "", "", errors.New("invalid week")
+		}
+		return start.Format("2006-01-02"), start.AddDate(0, 0, 6).Format("2006-01-02"), nil
+	case "month":
+		start, err := time.Parse("2006-01", value)
+		if err != nil {
+			return "", "", errors.New("invalid month")
+		}
+		return start.Format("2006-01-02"), start.AddDate(0, 1, -1).Format("2006-01-02"), nil
+	case "year":
+		start, err := time.Parse("2006", value)
+		if err != nil {
+			return "", "", errors.New("invalid year")
+		}
+		return start.Format("2006-01-02"), start.AddDate(1, 0, -1).Format("2006-01-02"), nil
+	case "range":
+		rawFrom, rawTo, _ := strings.Cut(value, "..")
+		r, err := newDateRange(rawFrom, rawTo)
+		if err != nil {
+			return "", "", err
+		}
+		return r.From, r.To, nil
+	case "all":
+		// Archived years are older than anything left in Days
+		if len(a.data.ArchivedYears) > 0 {
+			from = fmt.Sprintf("%04d-01-01", a.data.ArchivedYears[0])
+		}
+		for date := range a.data.Days {
+			if from == "" || date < from {
+				from = date
+			}
+			if date > to {
+				to = date
+			}
+		}
+		if from == "" {
+			return "", "", errors.New("nothing to export")
+		}
+		return from, to, nil
+	}
+	return "", "", errors.New("invalid export scope")
+}
+
+// exportTable is the tabular view of planner data shared by export formats
+type exportTable struct {
+	From   string
+	To     string
+	Dates  []string
+	Tasks  []TaskTemplate
+	Values map[string]map[string]int // date -> taskID -> value, only where the task applies
+
+	Measurements map[string]map[string]float64 // date -> taskID -> value task reading
+
+	Scores map[string]float64 // date -> day score, for scored days only
+
+	GoalsHit []StreakGoalHit // Streak goals reached within the range
+
+	LifetimeGoals []lifetimeGoalYear // Set for exports of a whole calendar year
+
+	Special map[string][]string // date -> names of special days on it
+
+	SkippedDays map[string]string // date -> reason, for days skipped as a whole
+}
+
+// exportTableLocked collects every task that applies in the range and its
+// values per day (must hold lock)
+func (a *App) exportTableLocked(from, to string) exportTable {
+	table := exportTable{
+		From:         from,
+		To:           to,
+		Values:       make(map[string]map[string]int),
+		Measurements: a.data.Measurements,
+		Scores:       make(map[string]float64),
+	}
+
+	start, _ := time.Parse("2006-01-02", from)
+	end, _ := time.Parse("2006-01-02", to)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		table.Dates = append(table.Dates, d.Format("2006-01-02"))
+	}
+
+	seen := make(map[string]bool)
+	for _, date := range table.Dates {
+		values := make(map[string]int)
+		for _, task := range a.getTasksForDateLocked(date) {
+			values[task.ID] = a.data.Days[date][task.ID]
+			if !seen[task.ID] {
+				seen[task.ID] = true
+				table.Tasks = append(table.Tasks, task)
+			}
+		}
+		table.Values[date] = values
+		if score, ok := a.dayScoreLocked(date); ok {
+			table.Scores[date] = score
+		}
+	}
+	a.sortTasksLocked(table.Tasks)
+	table.GoalsHit = a.streakGoalHitsLocked(from, to)
+	if strings.HasSuffix(from, "-01-01") && to == from[:4]+"-12-31" {
+		table.LifetimeGoals = a.lifetimeGoalYearsLocked(start, to)
+	}
+	table.Special = a.specialDayNamesLocked(from, to)
+	table.SkippedDays = a.skippedDaysInRangeLocked(from, to)
+
+	return table
+}
+
+// cell formats a task's value on a date; empty when the task doesn't apply
+func (t exportTable) cell(date string, task TaskTemplate) string {
+	value, ok := t.Values[date][task.ID]
+	if !ok {
+		return ""
+	}
+	if _, skipped := t.SkippedDays[date]; skipped && value == 0 {
+		return "–"
+	}
+	switch taskTypeOf(task) {
+	case "value":
+		if reading, ok := t.Measurements[date][task.ID]; ok {
+			return strconv.FormatFloat(reading, 'f', -1, 64) + unitSuffix(task.Unit)
+		}
+		return ""
+	case "scale":
+		if value == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%d/%d", value, scaleMaxOf(task))
+	case "binary", "negative":
+		if taskSucceeded(task, date, value) {
+			return "✓"
+		}
+		return "✗"
+	}
+	return strconv.Itoa(value) + unitSuffix(task.Unit)
+}
+
+// dateLabel formats a date with any special days on it, and whether it
+// was skipped
+func (t exportTable) dateLabel(date string) string {
+	names := append([]string{}, t.Special[date]...)
+	if note := t.skippedNote(date); note != "" {
+		names = append(names, note)
+	}
+	if len(names) > 0 {
+		return date + " (" + strings.Join(names, ", ") + ")"
+	}
+	return date
+}
+
+// skippedNote describes a day skipped as a whole; empty for other days
+func (t exportTable) skippedNote(date string) string {
+	reason, ok := t.SkippedDays[date]
+	if !ok {
+		return ""
+	}
+	if reason == "" {
+		return "skipped"
+	}
+	return "skipped: " + reason
+}
+
+// score formats a date's day score; empty when the day isn't scored, and
+// "skipped" for days skipped as a whole
+func (t exportTable) score(date string) string {
+	score, ok := t.Scores[date]
+	if !ok {
+		if _, skipped := t.SkippedDays[date]; skipped {
+			return "skipped"
+		}
+		return ""
+	}
+	return strconv.FormatFloat(score, 'f', 0, 64) + "%"
+}
+
+// renderCSVExport writes one row per day and one column per task
+func renderCSVExport(a *App, table exportTable, options map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"Date"}
+	for _, task := range table.Tasks {
+		header = append(header, task.Name)
+	}
+	header = append(header, "Score")
+	w.Write(header)
+
+	for _, date := range table.Dates {
+		row := []string{date}
+		for _, task := range table.Tasks {
+			if reading, ok := table.Measurements[date][task.ID]; ok && isValueTask(task) {
+				row = append(row, strconv.FormatFloat(reading, 'f', -1, 64))
+			} else if value, ok := table.Values[date][task.ID]; ok && !isValueTask(task) {
+				row = append(row, strconv.Itoa(value))
+			} else {
+				row = append(row, "")
+			}
+		}
+		row = append(row, table.score(date))
+		w.Write(row)
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// renderMarkdownExport writes the range as a Markdown table
+func renderMarkdownExport(a *App, table exportTable, options map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# PLAN %s – %s\n\n", table.From, table.To)
+
+	buf.WriteString("| Date |")
+	for _, task := range table.Tasks {
+		buf.WriteString(" " + strings.ReplaceAll(task.Name, "|", "\\|") + " |")
+	}
+	buf.WriteString(" Score |\n|---|")
+	for range table.Tasks {
+		buf.WriteString("---|")
+	}
+	buf.WriteString("---|\n")
+
+	for _, date := range table.Dates {
+		buf.WriteString("| " + strings.ReplaceAll(table.dateLabel(date), "|", "\\|") + " |")
+		for _, task := range table.Tasks {
+			buf.WriteString(" " + table.cell(date, task) + " |")
+		}
+		buf.WriteString(" " + table.score(date) + " |\n")
+	}
+
+	if len(table.GoalsHit) > 0 {
+		buf.WriteString("\n## Streak goals reached\n\n")
+		for _, hit := range table.GoalsHit {
+			fmt.Fprintf(&buf, "- **%s**: %d days on %s\n", hit.TaskName, hit.Goal, hit.Date)
+		}
+	}
+
+	if len(table.LifetimeGoals) > 0 {
+		buf.WriteString("\n## Lifetime goals\n\n")
+		for _, goal := range table.LifetimeGoals {
+			fmt.Fprintf(&buf, "- **%s**: %s\n", goal.Name, goal.summary())
+		}
+	}
+
+	if options["focus"] == "true" {
+		focus := a.focusReportLocked(table.From, table.To)
+		fmt.Fprintf(&buf, "\n## Focus\n\n%d sessions, %d minutes (average %.0f min)\n\n",
+			focus.Sessions, focus.TotalMinutes, focus.AverageMinutes)
+		for _, tf := range focus.Tasks {
+			fmt.Fprintf(&buf, "- %s: %d min in %d sessions\n", tf.TaskName, tf.Minutes, tf.Sessions)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// renderHTMLExport saves pre-rendered report HTML when given, otherwise a
+// plain table of the range
+func renderHTMLExport(a *App, table exportTable, options map[string]string) ([]byte, error) {
+	if content := options["content"]; content != "" {
+		return []byte(content), nil
+	}
+
+	var buf bytes.Buffer
+	title := html.EscapeString(fmt.Sprintf("PLAN %s – %s", table.From, table.To))
+	fmt.Fprintf(&buf, "<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>%s</title></head><body>\n", title)
+	fmt.Fprintf(&buf, "<h1>%s</h1>\n<table>\n<tr><th>Date</th>", title)
+	for _, task := range table.Tasks {
+		fmt.Fprintf(&buf, "<th>%s</th>", html.EscapeString(task.Name))
+	}
+	buf.WriteString("<th>Score</th></tr>\n")
+
+	for _, date := range table.Dates {
+		if _, skipped := table.SkippedDays[date]; skipped {
+			fmt.Fprintf(&buf, "<tr class=\"skipped\"><td><em>%s</em></td>", html.EscapeString(table.dateLabel(date)))
+		} else if len(table.Special[date]) > 0 {
+			fmt.Fprintf(&buf, "<tr class=\"special\"><td><strong>%s</strong></td>", html.EscapeString(table.dateLabel(date)))
+		} else {
+			fmt.Fprintf(&buf, "<tr><td>%s</td>", date)
+		}
+		for _, task := range table.Tasks {
+			fmt.Fprintf(&buf, "<td>%s</td>", html.EscapeString(table.cell(date, task)))
+		}
+		fmt.Fprintf(&buf, "<td>%s</td></tr>\n", table.score(date))
+	}
+
+	buf.WriteString("</table>\n")
+
+	described := false
+	for _, task := range table.Tasks {
+		if task.Description == "" {
+			continue
+		}
+		if !described {
+			buf.WriteString("<h2>Tasks</h2>\n<dl>\n")
+			described = true
+		}
+		fmt.Fprintf(&buf, "<dt>%s</dt><dd>%s</dd>\n", html.EscapeString(task.Name), html.EscapeString(task.Description))
+	}
+	if described {
+		buf.WriteString("</dl>\n")
+	}
+
+	if len(table.GoalsHit) > 0 {
+		buf.WriteString("<h2>Streak goals reached</h2>\n<ul>\n")
+		for _, hit := range table.GoalsHit {
+			fmt.Fprintf(&buf, "<li><strong>%s</strong>: %d days on %s</li>\n", html.EscapeString(hit.TaskName), hit.Goal, hit.Date)
+		}
+		buf.WriteString("</ul>\n")
+	}
+
+	if len(table.LifetimeGoals) > 0 {
+		buf.WriteString("<h2>Lifetime goals</h2>\n<ul>\n")
+		for _, goal := range table.LifetimeGoals {
+			fmt.Fprintf(&buf, "<li><strong>%s</strong>: %s</li>\n", html.EscapeString(goal.Name), html.EscapeString(goal.summary()))
+		}
+		buf.WriteString("</ul>\n")
+	}
+
+	if options["focus"] == "true" {
+		focus := a.focusReportLocked(table.From, table.To)
+		fmt.Fprintf(&buf, "<h2>Focus</h2>\n<p>%d sessions, %d minutes (average %.0f min)</p>\n<ul>\n",
+			focus.Sessions, focus.TotalMinutes, focus.AverageMinutes)
+		for _, tf := range focus.Tasks {
+			fmt.Fprintf(&buf, "<li>%s: %d min in %d sessions</li>\n", html.EscapeString(tf.TaskName), tf.Minutes, tf.Sessions)
+		}
+		buf.WriteString("</ul>\n")
+	}
+
+	charts, err := a.renderChartsLocked(table)
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(charts)
+
+	buf.WriteString("</body></html>\n")
+	return buf.Bytes(), nil
+}
+
+// renderICSExport writes one all-day event per tracked day summarizing what
+// was completed
+func renderICSExport(a *App, table exportTable, options map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	buf.WriteString("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//PLAN//Planner Export//EN\r\n")
+	for _, date := range table.Dates {
+		if _, recorded := a.data.Days[date]; !recorded || len(table.Values[date]) == 0 {
+			continue
+		}
+
+		done := []string{}
+		for _, task := range table.Tasks {
+			if value, ok := table.Values[date][task.ID]; ok && taskSucceeded(task, date, value) {
+				done = append(done, task.Name)
+			}
+		}
+
+		day, _ := time.Parse("2006-01-02", date)
+		buf.WriteString("BEGIN:VEVENT\r\n")
+		fmt.Fprintf(&buf, "UID:plan-%s@plan\r\n", day.Format("20060102"))
+		fmt.Fprintf(&buf, "DTSTAMP:%s\r\n", stamp)
+		fmt.Fprintf(&buf, "DTSTART;VALUE=DATE:%s\r\n", day.Format("20060102"))
+		fmt.Fprintf(&buf, "DTEND;VALUE=DATE:%s\r\n", day.AddDate(0, 0, 1).Format("20060102"))
+		fmt.Fprintf(&buf, "SUMMARY:PLAN %d/%d done\r\n", len(done), len(table.Values[date]))
+		if len(done) > 0 {
+			fmt.Fprintf(&buf, "DESCRIPTION:%s\r\n", icsEscape(strings.Join(done, "\n")))
+		}
+		buf.WriteString("END:VEVENT\r\n")
+	}
+	buf.WriteString("END:VCALENDAR\r\n")
+
+	return buf.Bytes(), nil
+}
+
+// ExportICS saves an iCalendar file with one all-day entry per task
+// completed on each date of a scope (as for Export), to show completions in
+// a calendar app. kind is "event" (VEVENT) or "todo" (completed VTODO).
+// Entries keep the same UID across exports, so importing again updates
+// them instead of adding duplicates. Returns the saved path.
+func (a *App) ExportICS(scope string, kind string) (string, error) {
+	if kind != "event" && kind != "todo" {
+		return "", errors.New(`kind must be "event" or "todo"`)
+	}
+
+	a.mu.RLock()
+	from, to, err := a.exportScopeLocked(scope)
+	a.mu.RUnlock()
+	if err != nil {
+		return "", err
+	}
+	unlock := a.lockDays(from, to)
+	table := a.exportTableLocked(from, to)
+
+	var buf bytes.Buffer
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	buf.WriteString("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//PLAN//Completed Tasks//EN\r\n")
+	for _, date := range table.Dates {
+		if _, recorded := a.data.Days[date]; !recorded {
+			continue
+		}
+		day, _ := time.Parse("2006-01-02", date)
+		for _, task := range table.Tasks {
+			value, ok := table.Values[date][task.ID]
+			if !ok || isValueTask(task) || !taskSucceeded(task, date, value) {
+				continue
+			}
+			summary := task.Name
+			switch taskTypeOf(task) {
+			case "binary":
+			case "negative":
+				summary = "Avoided: " + task.Name
+			default:
+				summary += " (" + table.cell(date, task) + ")"
+			}
+
+			component := "VEVENT"
+			if kind == "todo" {
+				component = "VTODO"
+			}
+			fmt.Fprintf(&buf, "BEGIN:%s\r\n", component)
+			fmt.Fprintf(&buf, "UID:plan-%s-%s@plan\r\n", task.ID, day.Format("20060102"))
+			fmt.Fprintf(&buf, "DTSTAMP:%s\r\n", stamp)
+			fmt.Fprintf(&buf, "DTSTART;VALUE=DATE:%s\r\n", day.Format("20060102"))
+			if kind == "todo" {
+				fmt.Fprintf(&buf, "DUE;VALUE=DATE:%s\r\n", day.AddDate(0, 0, 1).Format("20060102"))
+				buf.WriteString("STATUS:COMPLETED\r\n")
+				fmt.Fprintf(&buf, "COMPLETED:%s\r\n", day.UTC().Format("20060102T150405Z"))
+			} else {
+				fmt.Fprintf(&buf, "DTEND;VALUE=DATE:%s\r\n", day.AddDate(0, 0, 1).Format("20060102"))
+				buf.WriteString("TRANSP:TRANSPARENT\r\n")
+			}
+			fmt.Fprintf(&buf, "SUMMARY:%s\r\n", icsEscape(summary))
+			if task.Description != "" {
+				fmt.Fprintf(&buf, "DESCRIPTION:%s\r\n", icsEscape(task.Description))
+			}
+			fmt.Fprintf(&buf, "END:%s\r\n", component)
+		}
+	}
+	buf.WriteString("END:VCALENDAR\r\n")
+	unlock()
+
+	return a.writeExportFile(fmt.Sprintf("plan-completed-%s-to-%s.ics", from, to), buf.Bytes())
+}
+
+// icsEscape escapes text for an iCalendar property value
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// writeExportFile saves content into the PLAN_Exports folder under the
+// configured export path (Downloads by default)
+func (a *App) writeExportFile(filename string, content []byte) (string, error) {
+	a.mu.RLock()
+	finalDir, err := a.exportFolderLocked()
+	a.mu.RUnlock()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(finalDir, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(finalDir, filename)
+	if err := a.atomicWriteFile(path, content); err != nil {
+		return "", err
+	}
+	return path, nil
+}
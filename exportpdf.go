@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// PDF export layout, in points: landscape A4 with the table in small type
+const (
+	pdfPageWidth  = 842
+	pdfPageHeight = 595
+	pdfMargin     = 36
+	pdfFontSize   = 8
+	pdfRowHeight  = 14
+	pdfTitleSize  = 14
+)
+
+// pdfDocument collects the content streams of a PDF's pages. Text is set
+// in the standard Helvetica fonts, so nothing has to be embedded.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	y     float64 // Baseline of the next line on the current page
+}
+
+// newPage starts a page and moves to its top
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// text draws a line of text with its baseline at (x, y)
+func (d *pdfDocument) text(x, y float64, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %g Tf %g %g Td (%s) Tj ET\n", font, size, x, y, pdfString(s))
+}
+
+// line draws a horizontal rule from x1 to x2 at y
+func (d *pdfDocument) line(x1, x2, y float64) {
+	fmt.Fprintf(d.pages[len(d.pages)-1], "0.5 w %g %g m %g %g l S\n", x1, y, x2, y)
+}
+
+// nextLine moves down one line, starting a new page when this one is full.
+// It reports whether a page was started.
+func (d *pdfDocument) nextLine(height float64) bool {
+	d.y -= height
+	if d.y < pdfMargin {
+		d.newPage()
+		d.y -= height
+		return true
+	}
+	return false
+}
+
+// bytes writes the document: a catalog, the page tree, two fonts and each
+// page with its content stream
+func (d *pdfDocument) bytes() []byte {
+	kids := []string{}
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+2*i))
+	}
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	}
+	for i, page := range d.pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Contents %d 0 R /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> >>",
+				pdfPageWidth, pdfPageHeight, 6+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+	return pdfFile(objects)
+}
+
+// pdfFit shortens text to about width points at the given font size,
+// using Helvetica's average character width
+func pdfFit(s string, width, size float64) string {
+	max := int(width / (size * 0.5))
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	if max < 2 {
+		return ""
+	}
+	return string(runes[:max-1]) + "…"
+}
+
+// pdfCell is a table cell for the PDF, with check marks the standard fonts
+// don't have spelled out
+func pdfCell(table exportTable, date string, task TaskTemplate) string {
+	return strings.NewReplacer("✓", "Yes", "✗", "No").Replace(table.cell(date, task))
+}
+
+// renderPDFExport writes the range as a printable table, one row per day
+// and one column per task, followed by any streak and lifetime goals
+func renderPDFExport(a *App, table exportTable, options map[string]string) ([]byte, error) {
+	d := &pdfDocument{}
+	d.newPage()
+	d.y -= pdfTitleSize
+	d.text(pdfMargin, d.y, pdfTitleSize, true, fmt.Sprintf("PLAN %s – %s", table.From, table.To))
+	d.y -= pdfRowHeight
+
+	// The date column fits special day names; tasks share the rest
+	dateWidth := 150.0
+	scoreWidth := 40.0
+	taskWidth := 0.0
+	if len(table.Tasks) > 0 {
+		taskWidth = (pdfPageWidth - 2*pdfMargin - dateWidth - scoreWidth) / float64(len(table.Tasks))
+	}
+	header := func() {
+		d.nextLine(pdfRowHeight)
+		d.text(pdfMargin, d.y, pdfFontSize, true, "Date")
+		for i, task := range table.Tasks {
+			x := pdfMargin + dateWidth + float64(i)*taskWidth
+			d.text(x, d.y, pdfFontSize, true, pdfFit(task.Name, taskWidth-4, pdfFontSize))
+		}
+		d.text(pdfPageWidth-pdfMargin-scoreWidth, d.y, pdfFontSize, true, "Score")
+		d.line(pdfMargin, pdfPageWidth-pdfMargin, d.y-4)
+	}
+
+	header()
+	for _, date := range table.Dates {
+		if d.nextLine(pdfRowHeight) {
+			d.y += pdfRowHeight
+			header()
+			d.nextLine(pdfRowHeight)
+		}
+		_, skipped := table.SkippedDays[date]
+		d.text(pdfMargin, d.y, pdfFontSize, len(table.Special[date]) > 0 && !skipped, pdfFit(table.dateLabel(date), dateWidth-4, pdfFontSize))
+		for i, task := range table.Tasks {
+			x := pdfMargin + dateWidth + float64(i)*taskWidth
+			d.text(x, d.y, pdfFontSize, false, pdfFit(pdfCell(table, date, task), taskWidth-4, pdfFontSize))
+		}
+		d.text(pdfPageWidth-pdfMargin-scoreWidth, d.y, pdfFontSize, false, table.score(date))
+	}
+
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		d.nextLine(pdfRowHeight)
+		d.nextLine(pdfRowHeight)
+		d.text(pdfMargin, d.y, pdfFontSize+2, true, title)
+		for _, line := range lines {
+			d.nextLine(pdfRowHeight)
+			d.text(pdfMargin, d.y, pdfFontSize, false, pdfFit(line, pdfPageWidth-2*pdfMargin, pdfFontSize))
+		}
+	}
+
+	goals := []string{}
+	for _, hit := range table.GoalsHit {
+		goals = append(goals, fmt.Sprintf("%s: %d days on %s", hit.TaskName, hit.Goal, hit.Date))
+	}
+	section("Streak goals reached", goals)
+
+	lifetime := []string{}
+	for _, goal := range table.LifetimeGoals {
+		lifetime = append(lifetime, goal.Name+": "+goal.summary())
+	}
+	section("Lifetime goals", lifetime)
+
+	return d.bytes(), nil
+}
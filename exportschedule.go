@@ -0,0 +1,205 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// exportScheduleCheckInterval is how often scheduled exports are checked
+	exportScheduleCheckInterval = time.Hour
+	// exportRunHistory is the number of runs remembered per rule
+	exportRunHistory = 20
+)
+
+// ExportRun is one execution of a scheduled export
+type ExportRun struct {
+	Time   string `json:"time"` // RFC3339
+	Period string `json:"period"`
+	Path   string `json:"path,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ExportRule schedules an export of the last completed week, month or year.
+// Rules live in local settings since they write to this machine's export folder.
+type ExportRule struct {
+	ID      string `json:"id"`
+	Format  string `json:"format"`  // Any format from GetAvailableFormats
+	Cadence string `json:"cadence"` // "weekly", "monthly" or "yearly"
+	// Day the rule runs on: weekday for weekly rules (0 = Sunday), day of
+	// month for monthly rules (1-28). Yearly rules run on January 1.
+	Day        int         `json:"day"`
+	LastPeriod string      `json:"lastPeriod,omitempty"` // Scope of the last successful export
+	History    []ExportRun `json:"history,omitempty"`    // Newest last
+}
+
+// GetExportSchedule returns the scheduled export rules
+func (a *App) GetExportSchedule() []ExportRule {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return append([]ExportRule{}, a.settings.ExportSchedule...)
+}
+
+// SetExportSchedule replaces the scheduled export rules. Rules keep their
+// history when their ID is kept; rules without an ID are new.
+func (a *App) SetExportSchedule(rules []ExportRule) error {
+	for _, r := range rules {
+		if _, ok := findExportProvider(r.Format); !ok {
+			return fmt.Errorf("unknown export format %q", r.Format)
+		}
+		switch r.Cadence {
+		case "weekly":
+			if r.Day < 0 || r.Day > 6 {
+				return errors.New("weekly rules need a weekday between 0 and 6")
+			}
+		case "monthly":
+			if r.Day < 1 || r.Day > 28 {
+				return errors.New("monthly rules need a day between 1 and 28")
+			}
+		case "yearly":
+		default:
+			return fmt.Errorf("unknown cadence %q", r.Cadence)
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	previous := make(map[string]ExportRule)
+	for _, r := range a.settings.ExportSchedule {
+		previous[r.ID] = r
+	}
+
+	schedule := make([]ExportRule, 0, len(rules))
+	for _, r := range rules {
+		if old, ok := previous[r.ID]; ok && r.ID != "" {
+			r.LastPeriod = old.LastPeriod
+			r.History = old.History
+		} else {
+			r.ID = uuid.New().String()
+			r.LastPeriod = ""
+			r.History = nil
+		}
+		schedule = append(schedule, r)
+	}
+
+	a.audit("SetExportSchedule", "", "", len(a.settings.ExportSchedule), len(schedule))
+	a.settings.ExportSchedule = schedule
+	return a.saveSettingsLocked()
+}
+
+// dueExportScope returns the scope a rule should export now, or "" when the
+// rule isn't due: its day in the current period hasn't come yet or the
+// previous period was already exported
+func dueExportScope(r ExportRule, now time.Time) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var scope string
+	var runsFrom time.Time
+	switch r.Cadence {
+	case "weekly":
+		thisWeek := weekStartOf(today)
+		scope = "week:" + thisWeek.AddDate(0, 0, -7).Format("2006-01-02")
+		runsFrom = thisWeek.AddDate(0, 0, (r.Day+6)%7)
+	case "monthly":
+		thisMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		scope = "month:" + thisMonth.AddDate(0, -1, 0).Format("2006-01")
+		runsFrom = thisMonth.AddDate(0, 0, r.Day-1)
+	case "yearly":
+		scope = fmt.Sprintf("year:%04d", today.Year()-1)
+		runsFrom = time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return ""
+	}
+
+	if today.Before(runsFrom) || r.LastPeriod == scope {
+		return ""
+	}
+	return scope
+}
+
+// runScheduledExports runs due export rules until the app exits
+func (a *App) runScheduledExports() {
+	a.runDueExports()
+
+	ticker := time.NewTicker(exportScheduleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.runDueExports()
+		}
+	}
+}
+
+// runDueExports exports every due rule, records the run and notifies on failure
+func (a *App) runDueExports() {
+	type dueRule struct {
+		id, format, scope string
+	}
+
+	now := time.Now()
+	a.mu.RLock()
+	due := []dueRule{}
+	for _, r := range a.settings.ExportSchedule {
+		if scope := dueExportScope(r, now); scope != "" {
+			due = append(due, dueRule{r.ID, r.Format, scope})
+		}
+	}
+	a.mu.RUnlock()
+
+	for _, d := range due {
+		// Export takes its own locks
+		path, err := a.Export(d.format, d.scope, map[string]string{})
+		a.recordExportRun(d.id, d.format, d.scope, path, err)
+	}
+}
+
+// recordExportRun stores the outcome of a scheduled export in its rule's history
+func (a *App) recordExportRun(ruleID, format, scope, path string, exportErr error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	run := ExportRun{Time: time.Now().Format(time.RFC3339), Period: scope, Path: path}
+	if exportErr != nil {
+		run.Error = exportErr.Error()
+	}
+
+	for i := range a.settings.ExportSchedule {
+		r := &a.settings.ExportSchedule[i]
+		if r.ID != ruleID {
+			continue
+		}
+
+		// Only notify on the first failure for a period, not every hour
+		notify := exportErr != nil
+		if notify && len(r.History) > 0 {
+			last := r.History[len(r.History)-1]
+			notify = last.Period != scope || last.Error == ""
+		}
+
+		r.History = append(r.History, run)
+		if len(r.History) > exportRunHistory {
+			r.History = r.History[len(r.History)-exportRunHistory:]
+		}
+		if exportErr == nil {
+			r.LastPeriod = scope
+		}
+
+		if notify {
+			a.notifyLocked("export", "Scheduled export failed",
+				fmt.Sprintf("The %s export of %s couldn't be saved: %v", format, scope, exportErr))
+			a.saveDataLocked()
+		}
+		break
+	}
+
+	if err := a.saveSettingsLocked(); err != nil {
+		println("Error saving export schedule:", err.Error())
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+// xlsxParts are the fixed parts of a one-sheet workbook; the sheet itself
+// is written by renderXLSXExport
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="PLAN" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`},
+}
+
+// renderXLSXExport writes an Excel workbook with one row per day and one
+// column per task, like the CSV export but with numbers stored as numbers
+func renderXLSXExport(a *App, table exportTable, options map[string]string) ([]byte, error) {
+	var sheet bytes.Buffer
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	header := []string{"Date"}
+	for _, task := range table.Tasks {
+		header = append(header, task.Name)
+	}
+	header = append(header, "Score (%)")
+	sheet.WriteString(`<row r="1">`)
+	for col, name := range header {
+		writeXLSXString(&sheet, col, 1, name)
+	}
+	sheet.WriteString(`</row>`)
+
+	for i, date := range table.Dates {
+		row := i + 2
+		fmt.Fprintf(&sheet, `<row r="%d">`, row)
+		writeXLSXString(&sheet, 0, row, table.dateLabel(date))
+		for col, task := range table.Tasks {
+			if reading, ok := table.Measurements[date][task.ID]; ok && isValueTask(task) {
+				writeXLSXNumber(&sheet, col+1, row, strconv.FormatFloat(reading, 'f', -1, 64))
+			} else if value, ok := table.Values[date][task.ID]; ok && !isValueTask(task) {
+				writeXLSXNumber(&sheet, col+1, row, strconv.Itoa(value))
+			}
+		}
+		if score, ok := table.Scores[date]; ok {
+			writeXLSXNumber(&sheet, len(table.Tasks)+1, row, strconv.FormatFloat(score, 'f', 0, 64))
+		}
+		sheet.WriteString(`</row>`)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, part := range xlsxParts {
+		f, err := w.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	f, err := w.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(sheet.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// xlsxCellRef returns a cell's A1-style reference; col is 0-based, row 1-based
+func xlsxCellRef(col, row int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name + strconv.Itoa(row)
+}
+
+// writeXLSXString writes a text cell, stored inline so the workbook needs
+// no shared strings part
+func writeXLSXString(buf *bytes.Buffer, col, row int, text string) {
+	fmt.Fprintf(buf, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, xlsxCellRef(col, row))
+	xml.EscapeText(buf, []byte(text))
+	buf.WriteString(`</t></is></c>`)
+}
+
+// writeXLSXNumber writes a number cell
+func writeXLSXNumber(buf *bytes.Buffer, col, row int, number string) {
+	fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, xlsxCellRef(col, row), number)
+}
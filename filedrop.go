@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// fileDroppedEvent is emitted with a []DropPreview when files are dropped
+// onto the window, so the frontend can ask before importing
+const fileDroppedEvent = "plan:file-dropped"
+
+// Formats recognised in dropped files
+const (
+	dropFormatBackup   = "backup"   // A PLAN data file or backup
+	dropFormatCSV      = "csv"      // "date,value" rows for a signal
+	dropFormatPlanPack = "planpack" // A shareable bundle of task definitions
+	dropFormatUnknown  = "unknown"
+)
+
+// DropPreview describes what importing a dropped file would do
+type DropPreview struct {
+	Path     string   `json:"path"`
+	FileName string   `json:"fileName"`
+	Format   string   `json:"format"`
+	Summary  string   `json:"summary"`
+	Tasks    []string `json:"tasks,omitempty"` // Task names the file carries
+	Days     int      `json:"days"`            // Dated rows or tracked days
+	From     string   `json:"from,omitempty"`
+	To       string   `json:"to,omitempty"`
+	Error    string   `json:"error,omitempty"` // Set when the file can't be imported
+}
+
+// handleFileDrop previews dropped files and hands them to the frontend
+func (a *App) handleFileDrop(_, _ int, paths []string) {
+	previews := []DropPreview{}
+	for _, path := range paths {
+		previews = append(previews, a.PreviewDroppedFile(path))
+	}
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, fileDroppedEvent, previews)
+	}
+}
+
+// PreviewDroppedFile detects a file's format and summarizes its contents
+// without changing any data
+func (a *App) PreviewDroppedFile(path string) DropPreview {
+	preview := DropPreview{Path: path, FileName: filepath.Base(path), Format: dropFormatUnknown}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		preview.Error = err.Error()
+		return preview
+	}
+
+	preview.Format = detectDropFormat(path, data)
+	switch preview.Format {
+	case dropFormatBackup:
+		backup, _, _ := decodePlannerData(data)
+		for _, t := range backup.Templates {
+			if t.DeletedAt == nil {
+				preview.Tasks = append(preview.Tasks, t.Name)
+			}
+		}
+		dates := make([]string, 0, len(backup.Days))
+		for date := range backup.Days {
+			dates = append(dates, date)
+		}
+		preview.Days, preview.From, preview.To = dateSpan(dates)
+		preview.Summary = fmt.Sprintf("Backup with %d tasks and %d tracked days", len(preview.Tasks), preview.Days)
+
+	case dropFormatCSV:
+		values, err := readSignalCSV(bytes.NewReader(data))
+		if err != nil {
+			preview.Error = err.Error()
+			return preview
+		}
+		dates := make([]string, 0, len(values))
+		for date := range values {
+			dates = append(dates, date)
+		}
+		preview.Days, preview.From, preview.To = dateSpan(dates)
+		preview.Summary = fmt.Sprintf("CSV with readings on %d dates", preview.Days)
+
+	case dropFormatPlanPack:
+		var pack Preset
+		if err := json.Unmarshal(data, &pack); err != nil {
+			preview.Error = "unreadable plan pack"
+			return preview
+		}
+		for _, pt := range pack.Tasks {
+			preview.Tasks = append(preview.Tasks, pt.Name)
+		}
+		preview.Summary = fmt.Sprintf("Plan pack %q with %d tasks", pack.Name, len(pack.Tasks))
+
+	default:
+		preview.Error = "unrecognised file format"
+	}
+
+	return preview
+}
+
+// ImportDroppedFile imports a previewed file with the importer for its
+// format. CSV files need options["signal"] naming the signal to fill.
+// Returns how many tasks or dates were added.
+func (a *App) ImportDroppedFile(path string, options map[string]string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	switch detectDropFormat(path, data) {
+	case dropFormatBackup:
+		backup, _, ok := decodePlannerData(data)
+		if !ok {
+			return 0, errors.New("unreadable backup")
+		}
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		added := a.mergeMissingLocked(backup)
+		if added == 0 {
+			return 0, nil
+		}
+		a.audit("ImportDroppedFile", "", "", nil, filepath.Base(path))
+		return added, a.saveDataLocked()
+
+	case dropFormatCSV:
+		return a.ImportSignalCSV(path, options["signal"])
+
+	case dropFormatPlanPack:
+		var pack Preset
+		if err := json.Unmarshal(data, &pack); err != nil {
+			return 0, errors.New("unreadable plan pack")
+		}
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		created := a.addPresetTasksLocked(pack)
+		if len(created) == 0 {
+			return 0, nil
+		}
+		for _, task := range created {
+			a.audit("ImportDroppedFile", "", task.ID, nil, task.Name)
+		}
+		return len(created), a.saveDataLocked()
+	}
+
+	return 0, errors.New("unrecognised file format")
+}
+
+// detectDropFormat identifies a dropped file by extension, falling back to
+// its contents for JSON
+func detectDropFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".planpack":
+		return dropFormatPlanPack
+	case ".csv":
+		return dropFormatCSV
+	}
+	if isPlannerDataFormat(data) {
+		return dropFormatBackup
+	}
+	return dropFormatUnknown
+}
+
+// mergeMissingLocked copies tasks and day values from another data set that
+// aren't present here, never overwriting existing entries. Returns the
+// number of tasks and days added (must hold lock; caller saves).
+func (a *App) mergeMissingLocked(other PlannerData) int {
+	added := 0
+
+	known := make(map[string]bool)
+	for _, t := range a.data.Templates {
+		known[t.ID] = true
+	}
+	for _, t := range other.Templates {
+		if !known[t.ID] {
+			a.data.Templates = append(a.data.Templates, t)
+			added++
+		}
+	}
+
+	if a.data.Days == nil {
+		a.data.Days = make(map[string]DayTasks)
+	}
+	for date, tasks := range other.Days {
+		if err := a.pullArchivedDayLocked(date); err != nil {
+			println("Error reading archived day:", err.Error())
+			continue
+		}
+		if _, exists := a.data.Days[date]; exists {
+			continue
+		}
+		a.data.Days[date] = make(DayTasks)
+		for id, value := range tasks {
+			a.data.Days[date][id] = value
+		}
+		added++
+	}
+
+	if added > 0 {
+		a.rebuildRecordsLocked()
+	}
+	return added
+}
+
+// readSignalCSV reads "date,value" rows, summing values on the same date.
+// Headers and malformed rows are skipped.
+func readSignalCSV(r io.Reader) (map[string]float64, error) {
+	values := make(map[string]float64)
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			continue
+		}
+
+		date := strings.TrimSpace(record[0])
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			continue
+		}
+		values[date] += value
+	}
+	return values, nil
+}
+
+// dateSpan returns how many dates there are and the first and last of them
+func dateSpan(dates []string) (count int, from string, to string) {
+	if len(dates) == 0 {
+		return 0, "", ""
+	}
+	sort.Strings(dates)
+	return len(dates), dates[0], dates[len(dates)-1]
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// lockFileName is the file locked next to the data while PLAN has it open.
+// data.json itself can't be locked, since atomic writes replace it.
+const lockFileName = "plan.lock"
+
+// errDataInUse is returned by saves while another PLAN process holds the
+// data directory's lock
+var errDataInUse = errors.New("the data is open in another PLAN window or program; changes here are not saved")
+
+// dataLock is an advisory lock held on a data directory
+type dataLock struct {
+	dir  string
+	file *os.File
+}
+
+// holdDataLockLocked makes sure this process holds the lock of the current
+// data directory, taking it if needed, e.g. after the directory moved or
+// another process let go of it. Demo mode keeps the real directory's lock,
+// since its data never reaches a file (must hold lock).
+func (a *App) holdDataLockLocked() error {
+	if a.dataPath == "" {
+		return nil
+	}
+	if _, ok := a.store.(*memoryStore); ok {
+		return nil
+	}
+	dir := filepath.Dir(a.dataPath)
+	if a.dataLock != nil && a.dataLock.dir == dir {
+		return nil
+	}
+	a.releaseDataLockLocked()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, lockFileName), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return errDataInUse
+	}
+	a.dataLock = &dataLock{dir: dir, file: f}
+	return nil
+}
+
+// releaseDataLockLocked lets go of the data directory's lock (must hold lock)
+func (a *App) releaseDataLockLocked() {
+	if a.dataLock == nil {
+		return
+	}
+	unlockFile(a.dataLock.file)
+	a.dataLock.file.Close()
+	a.dataLock = nil
+}
+
+// IsDataInUse reports whether another PLAN process holds the data, so
+// changes made here can't be saved until it's closed
+func (a *App) IsDataInUse() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return errors.Is(a.holdDataLockLocked(), errDataInUse)
+}
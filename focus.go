@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// FocusSession is one completed timer run on a duration task
+type FocusSession struct {
+	TaskID  string `json:"taskId"`
+	Date    string `json:"date"`  // Day the session was credited to
+	Start   string `json:"start"` // RFC3339
+	End     string `json:"end"`   // RFC3339
+	Minutes int    `json:"minutes"`
+	Count   int    `json:"count,omitempty"` // Sessions folded into this one by maintenance
+}
+
+// count returns how many timer runs the session stands for
+func (s FocusSession) count() int {
+	if s.Count > 1 {
+		return s.Count
+	}
+	return 1
+}
+
+// TaskFocus is one task's focus totals in a focus report
+type TaskFocus struct {
+	TaskID   string `json:"taskId"`
+	TaskName string `json:"taskName"`
+	Sessions int    `json:"sessions"`
+	Minutes  int    `json:"minutes"`
+}
+
+// FocusReport summarizes focus sessions over a date range
+type FocusReport struct {
+	From           string      `json:"from"`
+	To             string      `json:"to"`
+	Sessions       int         `json:"sessions"`
+	TotalMinutes   int         `json:"totalMinutes"`
+	AverageMinutes float64     `json:"averageMinutes"` // Average session length
+	BestDay        string      `json:"bestDay,omitempty"`
+	BestDayMinutes int         `json:"bestDayMinutes"`
+	Tasks          []TaskFocus `json:"tasks"` // Most focused first
+}
+
+// GetFocusReport summarizes timer sessions between from and to (inclusive):
+// session count, minutes per task, average session length and best day
+func (a *App) GetFocusReport(from string, to string) (FocusReport, error) {
+	r, err := newDateRange(from, to)
+	if err != nil {
+		return FocusReport{}, errors.New("invalid date range")
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.focusReportLocked(r.From, r.To), nil
+}
+
+// focusReportLocked builds a focus report for an inclusive range (must hold lock)
+func (a *App) focusReportLocked(from, to string) FocusReport {
+	report := FocusReport{From: from, To: to, Tasks: []TaskFocus{}}
+
+	byTask := make(map[string]*TaskFocus)
+	byDay := make(map[string]int)
+	for _, s := range a.data.FocusSessions {
+		if s.Date < from || s.Date > to {
+			continue
+		}
+		report.Sessions += s.count()
+		report.TotalMinutes += s.Minutes
+		byDay[s.Date] += s.Minutes
+
+		tf, ok := byTask[s.TaskID]
+		if !ok {
+			tf = &TaskFocus{TaskID: s.TaskID}
+			if task, found := a.findTemplateLocked(s.TaskID); found {
+				tf.TaskName = task.Name
+			}
+			byTask[s.TaskID] = tf
+		}
+		tf.Sessions += s.count()
+		tf.Minutes += s.Minutes
+	}
+
+	if report.Sessions > 0 {
+		report.AverageMinutes = float64(report.TotalMinutes) / float64(report.Sessions)
+	}
+	for day, minutes := range byDay {
+		if minutes > report.BestDayMinutes || (minutes == report.BestDayMinutes && day < report.BestDay) {
+			report.BestDay = day
+			report.BestDayMinutes = minutes
+		}
+	}
+
+	for _, tf := range byTask {
+		report.Tasks = append(report.Tasks, *tf)
+	}
+	sort.Slice(report.Tasks, func(i, j int) bool {
+		if report.Tasks[i].Minutes != report.Tasks[j].Minutes {
+			return report.Tasks[i].Minutes > report.Tasks[j].Minutes
+		}
+		return report.Tasks[i].TaskName < report.Tasks[j].TaskName
+	})
+
+	return report
+}
+
+// recordFocusSessionLocked logs a finished timer run (must hold lock; caller saves)
+func (a *App) recordFocusSessionLocked(taskID, date string, start, end time.Time, minutes int) {
+	a.data.FocusSessions = append(a.data.FocusSessions, FocusSession{
+		TaskID:  taskID,
+		Date:    date,
+		Start:   start.Format(time.RFC3339),
+		End:     end.Format(time.RFC3339),
+		Minutes: minutes,
+	})
+}
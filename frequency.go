@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// FrequencyProgress is a weekly-quota task's progress for one week
+type FrequencyProgress struct {
+	TaskID string `json:"taskId"`
+	Done   int    `json:"done"`
+	Quota  int    `json:"quota"`
+	Met    bool   `json:"met"`
+}
+
+// isFrequencyTask reports whether a task is scored against a weekly quota
+// instead of every day
+func isFrequencyTask(t TaskTemplate) bool {
+	return t.TimesPerWeek > 0
+}
+
+// SetTaskFrequency sets how many times per week a task should be done.
+// 0 makes it a daily task again. A weekly quota replaces a monthly cadence.
+func (a *App) SetTaskFrequency(id string, timesPerWeek int) error {
+	if timesPerWeek < 0 || timesPerWeek > 7 {
+		return errors.New("times per week must be between 0 and 7")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			a.data.Templates[i].TimesPerWeek = timesPerWeek
+			if timesPerWeek > 0 {
+				a.data.Templates[i].DayOfMonth = 0
+			}
+			a.audit("SetTaskFrequency", "", id, t.TimesPerWeek, timesPerWeek)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("task not found")
+}
+
+// frequencyProgressLocked counts successful days per weekly-quota task in
+// the 7 days from start (must hold lock)
+func (a *App) frequencyProgressLocked(start time.Time) []FrequencyProgress {
+	byTask := make(map[string]*FrequencyProgress)
+	order := []string{}
+
+	for i := 0; i < 7; i++ {
+		dateKey := start.AddDate(0, 0, i).Format("2006-01-02")
+		if a.dayExcludedLocked(dateKey) {
+			continue
+		}
+
+		for _, task := range a.getTasksForDateLocked(dateKey) {
+			if !isFrequencyTask(task) {
+				continue
+			}
+			p, ok := byTask[task.ID]
+			if !ok {
+				p = &FrequencyProgress{TaskID: task.ID, Quota: task.TimesPerWeek}
+				byTask[task.ID] = p
+				order = append(order, task.ID)
+			}
+			if dayTasks, ok := a.data.Days[dateKey]; ok && taskSucceeded(task, dateKey, dayTasks[task.ID]) {
+				p.Done++
+			}
+		}
+	}
+
+	sort.Strings(order)
+	result := make([]FrequencyProgress, 0, len(order))
+	for _, id := range order {
+		p := byTask[id]
+		p.Met = p.Done >= p.Quota
+		result = append(result, *p)
+	}
+	return result
+}
+
+// frequencyPercentage scores a weekly-quota task, capped at 100%
+func frequencyPercentage(p FrequencyProgress) float64 {
+	if p.Quota == 0 || p.Done >= p.Quota {
+		return 100.0
+	}
+	return float64(p.Done) / float64(p.Quota) * 100.0
+}
@@ -13,8 +13,19 @@ import { YearlyReport } from './components/YearlyReport';
 import { TaskSettings } from './components/TaskSettings';
 import { initializeTheme, toggleTheme, Theme } from './store/theme';
 import { GetStreaks } from '../wailsjs/go/main/App';
+import { EventsOn } from '../wailsjs/runtime/runtime';
 import './App.css';
 
+interface PlannerNotification {
+    id: string;
+    kind: string;
+    title: string;
+    body?: string;
+}
+
+// How long an in-app notification stays on screen
+const TOAST_DURATION_MS = 8000;
+
 interface StreakData {
     currentStreak: number;
     longestStreak: number;
@@ -28,6 +39,7 @@ function App() {
     const [isSettingsOpen, setIsSettingsOpen] = useState(false);
     const [streaks, setStreaks] = useState<StreakData>({ currentStreak: 0, longestStreak: 0, totalPerfectDays: 0 });
     const [showStreakPopup, setShowStreakPopup] = useState(false);
+    const [toasts, setToasts] = useState<PlannerNotification[]>([]);
 
     useEffect(() => {
         const initialTheme = initializeTheme();
@@ -51,6 +63,31 @@ function App() {
         loadStreaks();
     }, [refreshKey]);
 
+    // Show notifications from the backend (reminders, backups, summaries) as
+    // system notifications when allowed, and always in the app
+    useEffect(() => {
+        if ('Notification' in window && window.Notification.permission === 'default') {
+            window.Notification.requestPermission().catch(() => undefined);
+        }
+        return EventsOn('plan:notification', (n: PlannerNotification) => {
+            if ('Notification' in window && window.Notification.permission === 'granted') {
+                try {
+                    new window.Notification(n.title, { body: n.body, tag: n.id });
+                } catch (error) {
+                    console.error('Failed to show notification:', error);
+                }
+            }
+            setToasts(prev => [...prev, n]);
+            setTimeout(() => {
+                setToasts(prev => prev.filter(t => t.id !== n.id));
+            }, TOAST_DURATION_MS);
+        });
+    }, []);
+
+    const dismissToast = (id: string) => {
+        setToasts(prev => prev.filter(t => t.id !== id));
+    };
+
     const handleThemeToggle = () => {
         const newTheme = toggleTheme();
         setTheme(newTheme);
@@ -181,6 +218,21 @@ function App() {
                 onClose={() => setIsSettingsOpen(false)}
                 onTasksChanged={handleTasksChanged}
             />
+
+            {/* Notifications */}
+            {toasts.length > 0 && (
+                <div className="toast-stack" role="status" aria-live="polite">
+                    {toasts.map(toast => (
+                        <div key={toast.id} className="toast animate-pop-in">
+                            <div className="toast-text">
+                                <span className="toast-title">{toast.title}</span>
+                                {toast.body && <span className="toast-body">{toast.body}</span>}
+                            </div>
+                            <button className="toast-close" onClick={() => dismissToast(toast.id)} aria-label="Dismiss">×</button>
+                        </div>
+                    ))}
+                </div>
+            )}
         </div>
     );
 }
@@ -35,17 +35,19 @@ export const DayColumn: React.FC<DayColumnProps> = ({ date, tasks, taskValues, o
                 {tasks.map((task) => {
                     const value = taskValues[task.id] || 0;
                     const isCount = task.type === 'count';
+                    const readOnly = !!task.readOnly;
+                    const title = readOnly ? `${task.name} (computed automatically)` : task.name;
 
                     if (isCount) {
                         // Count-type task: show stepper with optional unit
                         return (
-                            <div key={task.id} className={`task-item task-count ${value > 0 ? 'is-completed' : ''}`} title={task.name}>
+                            <div key={task.id} className={`task-item task-count ${value > 0 ? 'is-completed' : ''} ${readOnly ? 'is-readonly' : ''}`} title={title}>
                                 <span className="task-label">{task.name}</span>
                                 <div className="count-stepper">
                                     <button 
                                         className="stepper-btn minus"
                                         onClick={() => onTaskChange(task.id, value - 1)}
-                                        disabled={value === 0}
+                                        disabled={readOnly || value === 0}
                                         aria-label="Decrease"
                                     >
                                         −
@@ -57,6 +59,7 @@ export const DayColumn: React.FC<DayColumnProps> = ({ date, tasks, taskValues, o
                                     <button 
                                         className="stepper-btn plus"
                                         onClick={() => onTaskChange(task.id, value + 1)}
+                                        disabled={readOnly}
                                         aria-label="Increase"
                                     >
                                         +
@@ -68,10 +71,11 @@ export const DayColumn: React.FC<DayColumnProps> = ({ date, tasks, taskValues, o
 
                     // Binary-type task: show checkbox
                     return (
-                        <label key={task.id} className="task-item" title={task.name}>
+                        <label key={task.id} className={`task-item ${readOnly ? 'is-readonly' : ''}`} title={title}>
                             <input
                                 type="checkbox"
                                 checked={value > 0}
+                                disabled={readOnly}
                                 onChange={() => onTaskChange(task.id, value > 0 ? 0 : 1)}
                                 className="task-checkbox"
                             />
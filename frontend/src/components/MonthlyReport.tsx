@@ -6,7 +6,7 @@
 
 import React, { useState, useEffect } from 'react';
 import { GetMonthlyReport } from '../../wailsjs/go/main/App';
-import { exportToHTML } from '../store/exportUtils';
+import { exportToHTML, ReportAnnotation, annotationMarkersIn, annotationLabel } from '../store/exportUtils';
 import './MonthlyReport.css';
 
 interface MonthlyReportProps {
@@ -19,6 +19,7 @@ export const MonthlyReport: React.FC<MonthlyReportProps> = ({ year, month, refre
     const [weeklyAverages, setWeeklyAverages] = useState<number[]>([]);
     const [monthName, setMonthName] = useState<string>('');
     const [trendDirection, setTrendDirection] = useState<string>('stable');
+    const [annotations, setAnnotations] = useState<ReportAnnotation[]>([]);
     const [isLoading, setIsLoading] = useState(true);
     const [isExporting, setIsExporting] = useState(false);
     const [exportMessage, setExportMessage] = useState<string>('');
@@ -36,6 +37,7 @@ export const MonthlyReport: React.FC<MonthlyReportProps> = ({ year, month, refre
                 setWeeklyAverages(report.weeklyAverages as number[] || []);
                 setMonthName(report.monthName as string || '');
                 setTrendDirection(report.trendDirection as string || 'stable');
+                setAnnotations(report.annotations as ReportAnnotation[] || []);
             } catch (error) {
                 console.error('Failed to load monthly report:', error);
             } finally {
@@ -60,7 +62,8 @@ export const MonthlyReport: React.FC<MonthlyReportProps> = ({ year, month, refre
                 monthName,
                 year,
                 weeklyAverages,
-                trendDirection
+                trendDirection,
+                annotations
             });
             setExportMessage(`Saved to Downloads`);
             setTimeout(() => setExportMessage(''), 3000);
@@ -80,6 +83,21 @@ export const MonthlyReport: React.FC<MonthlyReportProps> = ({ year, month, refre
         }
     };
 
+    // The report's weeks are 7-day blocks from the 1st of the month
+    const daysInMonth = new Date(year, month, 0).getDate();
+    const dateInMonth = (day: number) =>
+        `${year}-${String(month).padStart(2, '0')}-${String(day).padStart(2, '0')}`;
+
+    const getWeekMarkers = (index: number) => {
+        const first = index * 7 + 1;
+        const last = Math.min(first + 6, daysInMonth);
+        return annotationMarkersIn(annotations, dateInMonth(first), dateInMonth(last)).map(marker => ({
+            ...marker,
+            // Centre of the marker's day within the block
+            left: ((parseInt(marker.date.slice(8), 10) - first + 0.5) / 7) * 100
+        }));
+    };
+
     const getOverallAverage = () => {
         if (weeklyAverages.length === 0) return 0;
         const sum = weeklyAverages.reduce((a, b) => a + b, 0);
@@ -123,6 +141,18 @@ export const MonthlyReport: React.FC<MonthlyReportProps> = ({ year, month, refre
                             opacity: average > 0 ? 0.3 + (average / 100) * 0.7 : 0.1
                         }}
                     >
+                        <div className="annotation-markers">
+                            {getWeekMarkers(index).map(marker => (
+                                <span
+                                    key={marker.annotation.date + marker.annotation.text}
+                                    className="annotation-marker"
+                                    style={{ left: `${marker.left}%` }}
+                                    title={annotationLabel(marker.annotation)}
+                                >
+                                    ◆
+                                </span>
+                            ))}
+                        </div>
                         <span className="week-label">Week {index + 1}</span>
                         <span className="week-value">{Math.round(average)}%</span>
                     </div>
@@ -12,7 +12,7 @@ import {
     formatDateKey,
     getWeekStart
 } from '../store/plannerStore';
-import { generateWeeklyHTML } from '../store/exportUtils';
+import { generateWeeklyHTML, ReportAnnotation } from '../store/exportUtils';
 import {
     GetTaskTemplates,
     AddTask,
@@ -187,7 +187,8 @@ export const TaskSettings: React.FC<TaskSettingsProps> = ({ isOpen, onClose, onT
                         const html = generateWeeklyHTML({
                             dateRange: rangeStr,
                             dailyPercentages: report.dailyPercentages as number[] || [],
-                            weeklyAverage: report.weeklyAverage as number || 0
+                            weeklyAverage: report.weeklyAverage as number || 0,
+                            annotations: report.annotations as ReportAnnotation[] || []
                         });
 
                         const filename = `PLAN-Weekly-${weekKey}.html`;
@@ -23,10 +23,20 @@ import {
     SaveHTMLExport,
     MarkWeekExported
 } from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
 import { generateWeeklyHTML } from '../store/exportUtils';
 import { DayColumn } from './DayColumn';
 import './WeeklyPlanner.css';
 
+// Payload of the plan:data-changed event
+interface DataChange {
+    all: boolean;
+    dates: string[];
+    from?: string;
+    to?: string;
+    count: number;
+}
+
 interface WeeklyPlannerProps {
     currentDate: Date;
     onDataChange?: () => void;
@@ -48,6 +58,30 @@ export const WeeklyPlanner: React.FC<WeeklyPlannerProps> = ({
     const [weekData, setWeekData] = useState<Map<string, Record<string, number>>>(new Map());
     const [isLoading, setIsLoading] = useState(true);
 
+    // Fetch templates and week data, cast for the grid
+    const fetchWeek = useCallback(async () => {
+        // Load templates and week data in parallel
+        const [templatesData, weekTaskData] = await Promise.all([
+            GetTaskTemplates(),
+            LoadWeek(weekStartKey)
+        ]);
+
+        // Cast templates to local TaskTemplate type
+        const newTemplates = (templatesData || []).map((t: any) => ({
+            ...t,
+            type: t?.type === 'count' ? 'count' : 'binary'
+        })) as TaskTemplate[];
+
+        // Store numeric values directly
+        const newWeekData = new Map<string, Record<string, number>>();
+        weekDates.forEach(date => {
+            const key = formatDateKey(date);
+            newWeekData.set(key, weekTaskData[key] || {});
+        });
+
+        return { newTemplates, newWeekData };
+    }, [weekStartKey]);
+
     // Load templates and week data
     useEffect(() => {
         let cancelled = false;
@@ -55,29 +89,10 @@ export const WeeklyPlanner: React.FC<WeeklyPlannerProps> = ({
         const loadData = async () => {
             setIsLoading(true);
             try {
-                // Load templates and week data in parallel
-                const [templatesData, weekTaskData] = await Promise.all([
-                    GetTaskTemplates(),
-                    LoadWeek(weekStartKey)
-                ]);
-
+                const { newTemplates, newWeekData } = await fetchWeek();
                 if (cancelled) return;
 
-                // Cast templates to local TaskTemplate type
-                setTemplates(
-                    (templatesData || []).map((t: any) => ({
-                        ...t,
-                        type: t?.type === 'count' ? 'count' : 'binary'
-                    })) as TaskTemplate[]
-                );
-
-                // Store numeric values directly
-                const newWeekData = new Map<string, Record<string, number>>();
-                weekDates.forEach(date => {
-                    const key = formatDateKey(date);
-                    newWeekData.set(key, weekTaskData[key] || {});
-                });
-
+                setTemplates(newTemplates);
                 setWeekData(newWeekData);
             } catch (error) {
                 console.error('Failed to load data:', error);
@@ -93,7 +108,33 @@ export const WeeklyPlanner: React.FC<WeeklyPlannerProps> = ({
         return () => {
             cancelled = true;
         };
-    }, [weekStartKey, refreshKey]);
+    }, [fetchWeek, refreshKey]);
+
+    // Reload quietly when data changes outside this view (sync, imports,
+    // the tray menu, another window). The event lists the changed dates,
+    // or sets `all` when a change wasn't tied to one.
+    useEffect(() => {
+        const weekKeys = weekDates.map(formatDateKey);
+        let cancelled = false;
+
+        const unsubscribe = EventsOn('plan:data-changed', async (change: DataChange) => {
+            if (!change.all && !change.dates.some(date => weekKeys.includes(date))) return;
+            try {
+                const { newTemplates, newWeekData } = await fetchWeek();
+                if (cancelled) return;
+
+                setTemplates(newTemplates);
+                setWeekData(newWeekData);
+            } catch (error) {
+                console.error('Failed to reload data:', error);
+            }
+        });
+
+        return () => {
+            cancelled = true;
+            unsubscribe();
+        };
+    }, [fetchWeek]);
 
     // Auto-export logic: Check if previous week needs exporting
     useEffect(() => {
@@ -7,7 +7,7 @@
 import React, { useState, useEffect } from 'react';
 import { formatDateKey, getWeekStart, formatWeekRange, getWeekDates } from '../store/plannerStore';
 import { GetWeeklyReport } from '../../wailsjs/go/main/App';
-import { exportToHTML } from '../store/exportUtils';
+import { exportToHTML, ReportAnnotation } from '../store/exportUtils';
 import './WeeklyReport.css';
 
 interface WeeklyReportProps {
@@ -20,6 +20,7 @@ const WEEKDAYS = ['Mon', 'Tue', 'Wed', 'Thu', 'Fri', 'Sat', 'Sun'];
 export const WeeklyReport: React.FC<WeeklyReportProps> = ({ currentDate, refreshKey = 0 }) => {
     const [dailyPercentages, setDailyPercentages] = useState<number[]>([0, 0, 0, 0, 0, 0, 0]);
     const [weeklyAverage, setWeeklyAverage] = useState<number>(0);
+    const [annotations, setAnnotations] = useState<ReportAnnotation[]>([]);
     const [isLoading, setIsLoading] = useState(true);
     const [isExporting, setIsExporting] = useState(false);
     const [exportMessage, setExportMessage] = useState<string>('');
@@ -39,6 +40,7 @@ export const WeeklyReport: React.FC<WeeklyReportProps> = ({ currentDate, refresh
 
                 setDailyPercentages(report.dailyPercentages as number[] || [0, 0, 0, 0, 0, 0, 0]);
                 setWeeklyAverage(report.weeklyAverage as number || 0);
+                setAnnotations(report.annotations as ReportAnnotation[] || []);
             } catch (error) {
                 console.error('Failed to load weekly report:', error);
             } finally {
@@ -62,7 +64,8 @@ export const WeeklyReport: React.FC<WeeklyReportProps> = ({ currentDate, refresh
             const path = await exportToHTML('weekly', {
                 dateRange,
                 dailyPercentages,
-                weeklyAverage
+                weeklyAverage,
+                annotations
             });
             setExportMessage(`Saved to Downloads`);
             setTimeout(() => setExportMessage(''), 3000);
@@ -6,7 +6,7 @@
 
 import React, { useState, useEffect } from 'react';
 import { GetYearlyReport } from '../../wailsjs/go/main/App';
-import { exportToHTML } from '../store/exportUtils';
+import { exportToHTML, ReportAnnotation, annotationMarkersIn, annotationLabel } from '../store/exportUtils';
 import './YearlyReport.css';
 
 interface YearlyReportProps {
@@ -20,6 +20,7 @@ export const YearlyReport: React.FC<YearlyReportProps> = ({ year, refreshKey = 0
     const [monthlyAverages, setMonthlyAverages] = useState<number[]>(new Array(12).fill(0));
     const [mostConsistentMonth, setMostConsistentMonth] = useState<number>(0);
     const [yearTotal, setYearTotal] = useState<number>(0);
+    const [annotations, setAnnotations] = useState<ReportAnnotation[]>([]);
     const [isLoading, setIsLoading] = useState(true);
     const [isExporting, setIsExporting] = useState(false);
     const [exportMessage, setExportMessage] = useState<string>('');
@@ -37,6 +38,7 @@ export const YearlyReport: React.FC<YearlyReportProps> = ({ year, refreshKey = 0
                 setMonthlyAverages(report.monthlyAverages as number[] || new Array(12).fill(0));
                 setMostConsistentMonth(report.mostConsistentMonth as number || 0);
                 setYearTotal(report.yearTotal as number || 0);
+                setAnnotations(report.annotations as ReportAnnotation[] || []);
             } catch (error) {
                 console.error('Failed to load yearly report:', error);
             } finally {
@@ -61,7 +63,8 @@ export const YearlyReport: React.FC<YearlyReportProps> = ({ year, refreshKey = 0
                 year,
                 monthlyAverages,
                 mostConsistentMonth,
-                yearTotal
+                yearTotal,
+                annotations
             });
             setExportMessage(`Saved to Downloads`);
             setTimeout(() => setExportMessage(''), 3000);
@@ -75,6 +78,16 @@ export const YearlyReport: React.FC<YearlyReportProps> = ({ year, refreshKey = 0
 
     const hasAnyData = monthlyAverages.some(avg => avg > 0);
 
+    const getMonthMarkers = (index: number) => {
+        const mm = String(index + 1).padStart(2, '0');
+        const daysInMonth = new Date(year, index + 1, 0).getDate();
+        return annotationMarkersIn(annotations, `${year}-${mm}-01`, `${year}-${mm}-${daysInMonth}`).map(marker => ({
+            ...marker,
+            // Centre of the marker's day within the bar
+            left: ((parseInt(marker.date.slice(8), 10) - 0.5) / daysInMonth) * 100
+        }));
+    };
+
     return (
         <div className={`yearly-report ${isLoading ? 'is-loading' : ''}`}>
             <div className="report-header">
@@ -112,6 +125,18 @@ export const YearlyReport: React.FC<YearlyReportProps> = ({ year, refreshKey = 0
                             key={index}
                             className={`month-bar-wrapper ${isConsistent ? 'is-consistent' : ''}`}
                         >
+                            <div className="annotation-markers">
+                                {getMonthMarkers(index).map(marker => (
+                                    <span
+                                        key={marker.annotation.date + marker.annotation.text}
+                                        className="annotation-marker"
+                                        style={{ left: `${marker.left}%` }}
+                                        title={annotationLabel(marker.annotation)}
+                                    >
+                                        ◆
+                                    </span>
+                                ))}
+                            </div>
                             <div className="month-bar-track">
                                 <div
                                     className="month-bar-fill"
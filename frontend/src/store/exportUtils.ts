@@ -11,6 +11,69 @@ export interface ExportData {
     data: any;
 }
 
+export interface ReportAnnotation {
+    date: string;
+    endDate?: string;
+    text: string;
+}
+
+export interface AnnotationMarker {
+    date: string; // Where the marker goes: the annotation's first day in the range
+    annotation: ReportAnnotation;
+}
+
+/**
+ * Place the annotations overlapping a date range on it, for chart markers
+ */
+export function annotationMarkersIn(annotations: ReportAnnotation[], from: string, to: string): AnnotationMarker[] {
+    return annotations
+        .filter(a => a.date <= to && (a.endDate || a.date) >= from)
+        .map(a => ({ date: a.date < from ? from : a.date, annotation: a }));
+}
+
+/**
+ * Describe an annotation for a marker's tooltip
+ */
+export function annotationLabel(a: ReportAnnotation): string {
+    return `${a.endDate ? `${a.date} – ${a.endDate}` : a.date}: ${a.text}`;
+}
+
+const ANNOTATION_STYLES = `
+    .annotations { margin-top: 24px; padding-top: 16px; border-top: 1px solid #eee; }
+    .annotations-title { font-size: 12px; color: #888; text-transform: uppercase; margin-bottom: 8px; }
+    .annotation { display: flex; gap: 12px; font-size: 13px; color: #444; padding: 4px 0; }
+    .annotation-marker { color: #FF9500; }
+    .annotation-date { color: #888; min-width: 160px; }`;
+
+function escapeHTML(text: string): string {
+    return text
+        .replace(/&/g, '&amp;')
+        .replace(/</g, '&lt;')
+        .replace(/>/g, '&gt;')
+        .replace(/"/g, '&quot;');
+}
+
+/**
+ * Render annotation markers shown below a report chart
+ */
+function generateAnnotationsHTML(annotations?: ReportAnnotation[]): string {
+    if (!annotations || annotations.length === 0) return '';
+
+    const items = annotations.map(a => `
+      <div class="annotation">
+        <span class="annotation-marker">◆</span>
+        <span class="annotation-date">${a.endDate ? `${a.date} – ${a.endDate}` : a.date}</span>
+        <span>${escapeHTML(a.text)}</span>
+      </div>
+    `).join('');
+
+    return `
+    <div class="annotations">
+      <div class="annotations-title">Notes</div>
+      ${items}
+    </div>`;
+}
+
 /**
  * Generate HTML for weekly report
  */
@@ -18,6 +81,7 @@ export function generateWeeklyHTML(data: {
     dateRange: string;
     dailyPercentages: number[];
     weeklyAverage: number;
+    annotations?: ReportAnnotation[];
 }): string {
   const days = ['Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday', 'Sunday'];
 
@@ -52,7 +116,7 @@ export function generateWeeklyHTML(data: {
     .bar { width: 40px; background: linear-gradient(180deg, #34C759 0%, #2FB350 100%); border-radius: 8px 8px 0 0; display: flex; align-items: flex-start; justify-content: center; min-height: 4px; }
     .bar-value { font-size: 11px; font-weight: 600; color: white; padding: 4px; }
     .bar-label { font-size: 12px; color: #666; margin-top: 8px; }
-    .footer { margin-top: 24px; padding-top: 16px; border-top: 1px solid #eee; text-align: center; color: #888; font-size: 12px; }
+    .footer { margin-top: 24px; padding-top: 16px; border-top: 1px solid #eee; text-align: center; color: #888; font-size: 12px; }${ANNOTATION_STYLES}
   </style>
 </head>
 <body>
@@ -70,6 +134,7 @@ export function generateWeeklyHTML(data: {
     <div class="chart">
       ${barsHTML}
     </div>
+    ${generateAnnotationsHTML(data.annotations)}
     <div class="footer">
       Generated by PLAN • ${new Date().toLocaleDateString()}
     </div>
@@ -86,6 +151,7 @@ export function generateMonthlyHTML(data: {
     year: number;
     weeklyAverages: number[];
     trendDirection: string;
+    annotations?: ReportAnnotation[];
 }): string {
     const monthlyAvg = data.weeklyAverages.length > 0
         ? Math.round(data.weeklyAverages.reduce((a, b) => a + b, 0) / data.weeklyAverages.length)
@@ -122,7 +188,7 @@ export function generateMonthlyHTML(data: {
     .summary { text-align: center; padding: 24px; background: #f5f5f5; border-radius: 12px; }
     .summary-value { font-size: 48px; font-weight: 700; color: #34C759; }
     .summary-label { font-size: 14px; color: #888; text-transform: uppercase; margin-top: 4px; }
-    .footer { margin-top: 24px; padding-top: 16px; border-top: 1px solid #eee; text-align: center; color: #888; font-size: 12px; }
+    .footer { margin-top: 24px; padding-top: 16px; border-top: 1px solid #eee; text-align: center; color: #888; font-size: 12px; }${ANNOTATION_STYLES}
   </style>
 </head>
 <body>
@@ -141,6 +207,7 @@ export function generateMonthlyHTML(data: {
       <div class="summary-value">${monthlyAvg}%</div>
       <div class="summary-label">Monthly Average</div>
     </div>
+    ${generateAnnotationsHTML(data.annotations)}
     <div class="footer">
       Generated by PLAN • ${new Date().toLocaleDateString()}
     </div>
@@ -157,6 +224,7 @@ export function generateYearlyHTML(data: {
     monthlyAverages: number[];
     mostConsistentMonth: number;
     yearTotal: number;
+    annotations?: ReportAnnotation[];
 }): string {
     const months = ['Jan', 'Feb', 'Mar', 'Apr', 'May', 'Jun', 'Jul', 'Aug', 'Sep', 'Oct', 'Nov', 'Dec'];
     const fullMonths = ['January', 'February', 'March', 'April', 'May', 'June', 'July', 'August', 'September', 'October', 'November', 'December'];
@@ -196,7 +264,7 @@ export function generateYearlyHTML(data: {
     .insight { margin-top: 32px; padding: 20px; background: #f9f9f9; border-radius: 12px; text-align: center; }
     .insight p { color: #666; font-size: 14px; }
     .insight strong { color: #34C759; }
-    .footer { margin-top: 24px; padding-top: 16px; border-top: 1px solid #eee; text-align: center; color: #888; font-size: 12px; }
+    .footer { margin-top: 24px; padding-top: 16px; border-top: 1px solid #eee; text-align: center; color: #888; font-size: 12px; }${ANNOTATION_STYLES}
   </style>
 </head>
 <body>
@@ -216,6 +284,7 @@ export function generateYearlyHTML(data: {
       <p><strong>${fullMonths[data.mostConsistentMonth]}</strong> was your most consistent month</p>
     </div>
     ` : ''}
+    ${generateAnnotationsHTML(data.annotations)}
     <div class="footer">
       Generated by PLAN • ${new Date().toLocaleDateString()}
     </div>
@@ -12,6 +12,7 @@ export interface TaskTemplate {
   order: number;
   createdAt: string;
   deletedAt?: string;
+  readOnly?: boolean; // Value is computed by the app (signals, composites)
 }
 
 export interface DayData {
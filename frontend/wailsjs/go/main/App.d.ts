@@ -2,42 +2,456 @@
 // This file is automatically generated. DO NOT EDIT
 import {main} from '../models';
 
+export function AddAnnotation(arg1:string,arg2:string):Promise<main.Annotation>;
+
+export function AddAnnotationRange(arg1:string,arg2:string,arg3:string):Promise<main.Annotation>;
+
+export function AddDayProfile(arg1:string,arg2:Array<number>):Promise<main.DayProfile>;
+
+export function AddGroup(arg1:string):Promise<main.TaskGroup>;
+
+export function AddLifetimeGoal(arg1:string,arg2:string,arg3:number,arg4:string):Promise<main.LifetimeGoal>;
+
+export function AddSpecialDay(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<main.SpecialDay>;
+
+export function AddSubitem(arg1:string,arg2:string):Promise<main.Subitem>;
+
 export function AddTask(arg1:string,arg2:string,arg3:string):Promise<main.TaskTemplate>;
 
+export function AddTasksBulk(arg1:string):Promise<Array<main.TaskTemplate>>;
+
+export function ApplyGapChoice(arg1:string):Promise<void>;
+
+export function ApplyPreset(arg1:string):Promise<Array<main.TaskTemplate>>;
+
+export function ArchiveTask(arg1:string):Promise<void>;
+
+export function BackupToFile():Promise<string>;
+
+export function CheckTask(arg1:string,arg2:string,arg3:string):Promise<number>;
+
+export function ClearMeasurement(arg1:string,arg2:string):Promise<void>;
+
+export function CloneTask(arg1:string):Promise<main.TaskTemplate>;
+
+export function CompareWeeks(arg1:string,arg2:string):Promise<main.WeekComparison>;
+
+export function CompleteOnboardingStep(arg1:string):Promise<void>;
+
+export function ConnectCloudSync(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function DeleteAnnotation(arg1:string):Promise<void>;
+
+export function DeleteChartConfig(arg1:string):Promise<void>;
+
+export function DeleteDataRange(arg1:string,arg2:string,arg3:boolean):Promise<main.WipePreview>;
+
+export function DeleteFutureNote(arg1:string):Promise<void>;
+
+export function DeleteGroup(arg1:string):Promise<void>;
+
 export function DeleteTask(arg1:string):Promise<void>;
 
+export function DeleteTaskHistory(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<main.WipePreview>;
+
+export function DisableEncryption(arg1:string):Promise<void>;
+
+export function EmptyTrash():Promise<number>;
+
+export function EnableEncryption(arg1:string):Promise<void>;
+
+export function ExitDemoMode():Promise<void>;
+
+export function Export(arg1:string,arg2:string,arg3:Record<string, string>):Promise<string>;
+
+export function ExportAllData():Promise<main.PlannerData>;
+
+export function ExportICS(arg1:string,arg2:string):Promise<string>;
+
+export function ExportLoopHabitsCSV(arg1:string):Promise<string>;
+
+export function ExportMarkdown(arg1:string,arg2:string,arg3:string):Promise<main.MarkdownExportResult>;
+
+export function ExportQueryCSV(arg1:string):Promise<string>;
+
+export function ExportStreakCertificate(arg1:string,arg2:string):Promise<string>;
+
+export function ExportWeekComparison(arg1:string,arg2:string):Promise<string>;
+
+export function FactoryReset(arg1:string):Promise<main.WipePreview>;
+
+export function FinishReview():Promise<main.WeeklyReview>;
+
+export function GetAPIVersion():Promise<main.APIInfo>;
+
+export function GetAnnotations(arg1:string,arg2:string):Promise<Array<main.Annotation>>;
+
+export function GetArchivedTasks():Promise<Array<main.TaskTemplate>>;
+
+export function GetArchivedYears():Promise<Array<number>>;
+
+export function GetAuditLog(arg1:string,arg2:string):Promise<Array<main.AuditEntry>>;
+
+export function GetAvailableFormats():Promise<Array<main.ExportFormat>>;
+
+export function GetBackupRetention():Promise<main.BackupRetention>;
+
+export function GetChangeHistory(arg1:string):Promise<Array<main.ChangeEntry>>;
+
+export function GetDashboard():Promise<main.Dashboard>;
+
+export function GetDataCompression():Promise<boolean>;
+
+export function GetDataDirectory():Promise<string>;
+
+export function GetDayProfiles():Promise<Array<main.DayProfile>>;
+
+export function GetDeletedTasks():Promise<Array<main.TaskTemplate>>;
+
+export function GetDependencyGraph():Promise<Array<main.DependencyNode>>;
+
+export function GetDependencyWarnings(arg1:string):Promise<Array<main.DependencyWarning>>;
+
+export function GetDiagnostics():Promise<Record<string, any>>;
+
+export function GetEditWindow():Promise<number>;
+
 export function GetExportPath():Promise<string>;
 
+export function GetExportSchedule():Promise<Array<main.ExportRule>>;
+
+export function GetFeatureOptIns():Promise<Record<string, boolean>>;
+
+export function GetFocusReport(arg1:string,arg2:string):Promise<main.FocusReport>;
+
+export function GetFutureNotes():Promise<Array<main.FutureNote>>;
+
+export function GetGapSummary():Promise<main.GapSummary>;
+
+export function GetGroups():Promise<Array<main.TaskGroup>>;
+
+export function GetHeatmap(arg1:string,arg2:string,arg3:string,arg4:number):Promise<main.Heatmap>;
+
+export function GetLifetimeGoals():Promise<Array<main.LifetimeGoalProgress>>;
+
+export function GetMeasurementStats(arg1:string,arg2:string,arg3:string):Promise<main.MeasurementStats>;
+
 export function GetMonthlyReport(arg1:number,arg2:number):Promise<Record<string, any>>;
 
+export function GetNotifications():Promise<Array<main.Notification>>;
+
+export function GetOnboardingState():Promise<main.OnboardingProgress>;
+
+export function GetPersonalRecords():Promise<Record<string, main.PersonalRecord>>;
+
+export function GetPlanReminderTime():Promise<string>;
+
+export function GetPresets():Promise<Array<main.Preset>>;
+
+export function GetProfileForDate(arg1:string):Promise<main.DayProfile>;
+
+export function GetQuarantine():Promise<Array<main.QuarantinedEntry>>;
+
+export function GetRetentionPolicy():Promise<main.RetentionPolicy>;
+
+export function GetRolloverHour():Promise<number>;
+
+export function GetRunningTimers():Promise<Record<string, string>>;
+
+export function GetSaveStatus():Promise<main.SaveStatus>;
+
+export function GetScoringConfig():Promise<main.ScoringConfig>;
+
+export function GetSecondaryBackupDir():Promise<string>;
+
+export function GetSignals(arg1:string):Promise<Record<string, number>>;
+
+export function GetSkippedDays(arg1:string,arg2:string):Promise<Record<string, string>>;
+
+export function GetSortMode():Promise<string>;
+
+export function GetSpecialDays():Promise<Array<main.SpecialDay>>;
+
+export function GetSpecialDaysInRange(arg1:string,arg2:string):Promise<Array<main.SpecialDayOccurrence>>;
+
+export function GetStorageBackend():Promise<string>;
+
+export function GetStreakProgress(arg1:string):Promise<main.StreakProgress>;
+
 export function GetStreaks():Promise<Record<string, any>>;
 
+export function GetSyncStatus():Promise<main.SyncStatus>;
+
+export function GetTaperProgress(arg1:string):Promise<main.TaperProgress>;
+
 export function GetTaskTemplates():Promise<Array<main.TaskTemplate>>;
 
 export function GetTasksForDate(arg1:string):Promise<Array<main.TaskTemplate>>;
 
+export function GetTierDays(arg1:string,arg2:string,arg3:string):Promise<Record<string, number>>;
+
+export function GetTierDistribution(arg1:string,arg2:number,arg3:number):Promise<main.TierDistribution>;
+
+export function GetToday():Promise<main.TodayView>;
+
+export function GetTrash():Promise<Array<main.TrashItem>>;
+
+export function GetUndoState():Promise<main.UndoState>;
+
+export function GetUnseenChanges():Promise<Array<main.ChangeNote>>;
+
+export function GetVacations():Promise<Array<main.DateRange>>;
+
+export function GetValueDistribution(arg1:string,arg2:string,arg3:string):Promise<main.ValueDistribution>;
+
+export function GetWeekDiff(arg1:string):Promise<main.WeekDiff>;
+
+export function GetWeekSummaryTargets():Promise<main.WeekSummaryTargets>;
+
 export function GetWeeklyReport(arg1:string):Promise<Record<string, any>>;
 
+export function GetWeeklyReviews():Promise<Array<main.WeeklyReview>>;
+
 export function GetYearlyReport(arg1:number):Promise<Record<string, any>>;
 
+export function HasPlannedWeek(arg1:string):Promise<boolean>;
+
+export function ImportCSV(arg1:string,arg2:Record<string, string>):Promise<main.CSVImportReport>;
+
+export function ImportData(arg1:string,arg2:string):Promise<main.ImportResult>;
+
+export function ImportDroppedFile(arg1:string,arg2:Record<string, string>):Promise<number>;
+
+export function ImportHabits(arg1:string,arg2:Record<string, string>):Promise<main.HabitImportReport>;
+
+export function ImportSignalCSV(arg1:string,arg2:string):Promise<number>;
+
+export function ImportSignals(arg1:string,arg2:Record<string, number>):Promise<void>;
+
+export function IsDataInUse():Promise<boolean>;
+
+export function IsDayLocked(arg1:string):Promise<boolean>;
+
+export function IsDemoMode():Promise<boolean>;
+
+export function IsEncrypted():Promise<boolean>;
+
+export function IsGitHistoryEnabled():Promise<boolean>;
+
+export function IsLocked():Promise<boolean>;
+
+export function IsReplayMode():Promise<boolean>;
+
 export function IsWeekExported(arg1:string):Promise<boolean>;
 
-export function LoadDay(arg1:string):Promise<Record<string, number>>;
+export function ListBackups():Promise<Array<main.BackupInfo>>;
+
+export function ListChartConfigs():Promise<Array<main.ChartConfig>>;
+
+export function ListRevisions():Promise<Array<main.Revision>>;
+
+export function LoadDay(arg1:string):Promise<main.DayValues>;
+
+export function LoadDayMeasurements(arg1:string):Promise<Record<string, number>>;
+
+export function LoadDaySkipped(arg1:string):Promise<Record<string, string>>;
+
+export function LoadDaySubitems(arg1:string):Promise<Record<string, Array<string>>>;
 
 export function LoadWeek(arg1:string):Promise<Record<string, Record<string, number>>>;
 
+export function LoadWeekDetailed(arg1:string):Promise<main.WeekDetail>;
+
+export function Lock():Promise<void>;
+
+export function LockDayAgain(arg1:string):Promise<void>;
+
+export function MarkAllNotificationsRead():Promise<void>;
+
+export function MarkChangesSeen(arg1:string):Promise<void>;
+
+export function MarkNotificationRead(arg1:string):Promise<void>;
+
 export function MarkWeekExported(arg1:string):Promise<void>;
 
+export function MergeTasks(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function MoveTaskToGroup(arg1:string,arg2:string):Promise<void>;
+
+export function PreviewCSV(arg1:string):Promise<main.CSVPreview>;
+
+export function PreviewDroppedFile(arg1:string):Promise<main.DropPreview>;
+
+export function PurgeTask(arg1:string,arg2:string):Promise<void>;
+
+export function Query(arg1:string):Promise<main.QueryResult>;
+
+export function QuickCheck(arg1:string):Promise<number>;
+
+export function Redo():Promise<main.UndoChange>;
+
+export function RemoveDayProfile(arg1:string):Promise<void>;
+
+export function RemoveLifetimeGoal(arg1:string):Promise<void>;
+
+export function RemoveSpecialDay(arg1:string):Promise<void>;
+
+export function RemoveSubitem(arg1:string,arg2:string):Promise<void>;
+
+export function RemoveVacation(arg1:string,arg2:string):Promise<void>;
+
+export function RenameGroup(arg1:string,arg2:string):Promise<void>;
+
+export function ReorderGroups(arg1:Array<string>):Promise<void>;
+
 export function ReorderTasks(arg1:Array<string>):Promise<void>;
 
-export function SaveDay(arg1:string,arg2:Record<string, number>):Promise<void>;
+export function RepairData():Promise<main.IntegrityReport>;
+
+export function RestoreBackup(arg1:string):Promise<void>;
+
+export function RestoreBackupWithPassphrase(arg1:string,arg2:string):Promise<void>;
+
+export function RestoreFileWithPassphrase(arg1:string,arg2:string):Promise<void>;
+
+export function RestoreFromFile():Promise<string>;
+
+export function RestoreFromTrash(arg1:string):Promise<void>;
+
+export function RestoreRevision(arg1:string):Promise<void>;
+
+export function RunMaintenance():Promise<main.MaintenanceResult>;
+
+export function SaveChartConfig(arg1:string,arg2:main.ChartConfig):Promise<void>;
+
+export function SaveDay(arg1:string,arg2:Record<string, number>):Promise<Array<main.RecordBreak>>;
 
 export function SaveHTMLExport(arg1:string,arg2:string):Promise<string>;
 
 export function SelectDirectory():Promise<string>;
 
+export function SetBackupRetention(arg1:number,arg2:number):Promise<void>;
+
+export function SetDataCompression(arg1:boolean):Promise<void>;
+
+export function SetDataDirectory(arg1:string):Promise<void>;
+
+export function SetDateProfile(arg1:string,arg2:string):Promise<void>;
+
+export function SetEditWindow(arg1:number):Promise<void>;
+
 export function SetExportPath(arg1:string):Promise<void>;
 
+export function SetExportSchedule(arg1:Array<main.ExportRule>):Promise<void>;
+
+export function SetFeatureOptIn(arg1:string,arg2:boolean):Promise<void>;
+
+export function SetGitHistory(arg1:boolean):Promise<void>;
+
+export function SetMeasurement(arg1:string,arg2:string,arg3:number):Promise<void>;
+
+export function SetPlanReminderTime(arg1:string):Promise<void>;
+
+export function SetRetentionPolicy(arg1:main.RetentionPolicy):Promise<void>;
+
+export function SetRolloverHour(arg1:number):Promise<void>;
+
+export function SetScoringConfig(arg1:main.ScoringConfig):Promise<void>;
+
+export function SetSecondaryBackup(arg1:string,arg2:string):Promise<void>;
+
+export function SetSortMode(arg1:string):Promise<void>;
+
+export function SetStorageBackend(arg1:string):Promise<void>;
+
+export function SetSubitemDone(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<number>;
+
+export function SetSyncSettings(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
+
+export function SetTaskAutoSource(arg1:string,arg2:string,arg3:string,arg4:number):Promise<void>;
+
+export function SetTaskComposite(arg1:string,arg2:string,arg3:Array<string>):Promise<void>;
+
+export function SetTaskEndDate(arg1:string,arg2:string):Promise<void>;
+
+export function SetTaskFrequency(arg1:string,arg2:number):Promise<void>;
+
+export function SetTaskMonthly(arg1:string,arg2:number):Promise<void>;
+
+export function SetTaskOnDays(arg1:string,arg2:string):Promise<void>;
+
+export function SetTaskPaused(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function SetTaskPrefill(arg1:string,arg2:string):Promise<void>;
+
+export function SetTaskPrerequisite(arg1:string,arg2:string):Promise<void>;
+
+export function SetTaskPriority(arg1:string,arg2:number):Promise<void>;
+
+export function SetTaskProfiles(arg1:string,arg2:Array<string>):Promise<void>;
+
+export function SetTaskReminder(arg1:string,arg2:string):Promise<void>;
+
+export function SetTaskScaleMax(arg1:string,arg2:number):Promise<void>;
+
+export function SetTaskStreakGoal(arg1:string,arg2:number):Promise<void>;
+
+export function SetTaskTaper(arg1:string,arg2:string,arg3:number,arg4:number,arg5:number):Promise<void>;
+
+export function SetTaskTarget(arg1:string,arg2:number):Promise<void>;
+
+export function SetTaskTiers(arg1:string,arg2:Array<number>):Promise<void>;
+
 export function SetTaskType(arg1:string,arg2:string):Promise<void>;
 
+export function SetVacation(arg1:string,arg2:string):Promise<void>;
+
+export function SetWeekSummaryTargets(arg1:main.WeekSummaryTargets):Promise<void>;
+
+export function SimulateReport(arg1:Array<main.ReportChange>,arg2:string):Promise<main.SimulatedReport>;
+
+export function SkipDay(arg1:string,arg2:string):Promise<void>;
+
+export function SkipTask(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function StartDemoMode():Promise<void>;
+
+export function StartTimer(arg1:string):Promise<void>;
+
+export function StartWeeklyReview(arg1:string):Promise<main.ReviewState>;
+
+export function StopTimer(arg1:string):Promise<number>;
+
+export function SubmitReviewStep(arg1:string,arg2:Record<string, string>):Promise<main.ReviewState>;
+
+export function Sync():Promise<main.SyncResult>;
+
+export function UnarchiveTask(arg1:string):Promise<void>;
+
+export function UnarchiveYears():Promise<void>;
+
+export function UndeleteTask(arg1:string):Promise<void>;
+
+export function Undo():Promise<main.UndoChange>;
+
+export function Unlock(arg1:string):Promise<void>;
+
+export function UnlockDayForEditing(arg1:string):Promise<void>;
+
+export function UnskipDay(arg1:string):Promise<void>;
+
+export function UnskipTask(arg1:string,arg2:string):Promise<void>;
+
+export function UpdateDayProfile(arg1:string,arg2:string,arg3:Array<number>):Promise<main.DayProfile>;
+
+export function UpdateLifetimeGoal(arg1:string,arg2:string,arg3:number,arg4:string):Promise<void>;
+
+export function UpdateSubitem(arg1:string,arg2:string,arg3:string):Promise<void>;
+
 export function UpdateTask(arg1:string,arg2:string):Promise<void>;
+
+export function UpdateTaskDescription(arg1:string,arg2:string):Promise<void>;
+
+export function VerifyData():Promise<main.IntegrityReport>;
+
+export function WriteFutureNote(arg1:string,arg2:string):Promise<main.FutureNote>;
@@ -2,26 +2,370 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddAnnotation(arg1, arg2) {
+  return window['go']['main']['App']['AddAnnotation'](arg1, arg2);
+}
+
+export function AddAnnotationRange(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AddAnnotationRange'](arg1, arg2, arg3);
+}
+
+export function AddDayProfile(arg1, arg2) {
+  return window['go']['main']['App']['AddDayProfile'](arg1, arg2);
+}
+
+export function AddGroup(arg1) {
+  return window['go']['main']['App']['AddGroup'](arg1);
+}
+
+export function AddLifetimeGoal(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['AddLifetimeGoal'](arg1, arg2, arg3, arg4);
+}
+
+export function AddSpecialDay(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['AddSpecialDay'](arg1, arg2, arg3, arg4);
+}
+
+export function AddSubitem(arg1, arg2) {
+  return window['go']['main']['App']['AddSubitem'](arg1, arg2);
+}
+
 export function AddTask(arg1, arg2, arg3) {
   return window['go']['main']['App']['AddTask'](arg1, arg2, arg3);
 }
 
+export function AddTasksBulk(arg1) {
+  return window['go']['main']['App']['AddTasksBulk'](arg1);
+}
+
+export function ApplyGapChoice(arg1) {
+  return window['go']['main']['App']['ApplyGapChoice'](arg1);
+}
+
+export function ApplyPreset(arg1) {
+  return window['go']['main']['App']['ApplyPreset'](arg1);
+}
+
+export function ArchiveTask(arg1) {
+  return window['go']['main']['App']['ArchiveTask'](arg1);
+}
+
+export function BackupToFile() {
+  return window['go']['main']['App']['BackupToFile']();
+}
+
+export function CheckTask(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CheckTask'](arg1, arg2, arg3);
+}
+
+export function ClearMeasurement(arg1, arg2) {
+  return window['go']['main']['App']['ClearMeasurement'](arg1, arg2);
+}
+
+export function CloneTask(arg1) {
+  return window['go']['main']['App']['CloneTask'](arg1);
+}
+
+export function CompareWeeks(arg1, arg2) {
+  return window['go']['main']['App']['CompareWeeks'](arg1, arg2);
+}
+
+export function CompleteOnboardingStep(arg1) {
+  return window['go']['main']['App']['CompleteOnboardingStep'](arg1);
+}
+
+export function ConnectCloudSync(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ConnectCloudSync'](arg1, arg2, arg3);
+}
+
+export function DeleteAnnotation(arg1) {
+  return window['go']['main']['App']['DeleteAnnotation'](arg1);
+}
+
+export function DeleteChartConfig(arg1) {
+  return window['go']['main']['App']['DeleteChartConfig'](arg1);
+}
+
+export function DeleteDataRange(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DeleteDataRange'](arg1, arg2, arg3);
+}
+
+export function DeleteFutureNote(arg1) {
+  return window['go']['main']['App']['DeleteFutureNote'](arg1);
+}
+
+export function DeleteGroup(arg1) {
+  return window['go']['main']['App']['DeleteGroup'](arg1);
+}
+
 export function DeleteTask(arg1) {
   return window['go']['main']['App']['DeleteTask'](arg1);
 }
 
+export function DeleteTaskHistory(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['DeleteTaskHistory'](arg1, arg2, arg3, arg4);
+}
+
+export function DisableEncryption(arg1) {
+  return window['go']['main']['App']['DisableEncryption'](arg1);
+}
+
+export function EmptyTrash() {
+  return window['go']['main']['App']['EmptyTrash']();
+}
+
+export function EnableEncryption(arg1) {
+  return window['go']['main']['App']['EnableEncryption'](arg1);
+}
+
+export function ExitDemoMode() {
+  return window['go']['main']['App']['ExitDemoMode']();
+}
+
+export function Export(arg1, arg2, arg3) {
+  return window['go']['main']['App']['Export'](arg1, arg2, arg3);
+}
+
+export function ExportAllData() {
+  return window['go']['main']['App']['ExportAllData']();
+}
+
+export function ExportICS(arg1, arg2) {
+  return window['go']['main']['App']['ExportICS'](arg1, arg2);
+}
+
+export function ExportLoopHabitsCSV(arg1) {
+  return window['go']['main']['App']['ExportLoopHabitsCSV'](arg1);
+}
+
+export function ExportMarkdown(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportMarkdown'](arg1, arg2, arg3);
+}
+
+export function ExportQueryCSV(arg1) {
+  return window['go']['main']['App']['ExportQueryCSV'](arg1);
+}
+
+export function ExportStreakCertificate(arg1, arg2) {
+  return window['go']['main']['App']['ExportStreakCertificate'](arg1, arg2);
+}
+
+export function ExportWeekComparison(arg1, arg2) {
+  return window['go']['main']['App']['ExportWeekComparison'](arg1, arg2);
+}
+
+export function FactoryReset(arg1) {
+  return window['go']['main']['App']['FactoryReset'](arg1);
+}
+
+export function FinishReview() {
+  return window['go']['main']['App']['FinishReview']();
+}
+
+export function GetAPIVersion() {
+  return window['go']['main']['App']['GetAPIVersion']();
+}
+
+export function GetAnnotations(arg1, arg2) {
+  return window['go']['main']['App']['GetAnnotations'](arg1, arg2);
+}
+
+export function GetArchivedTasks() {
+  return window['go']['main']['App']['GetArchivedTasks']();
+}
+
+export function GetArchivedYears() {
+  return window['go']['main']['App']['GetArchivedYears']();
+}
+
+export function GetAuditLog(arg1, arg2) {
+  return window['go']['main']['App']['GetAuditLog'](arg1, arg2);
+}
+
+export function GetAvailableFormats() {
+  return window['go']['main']['App']['GetAvailableFormats']();
+}
+
+export function GetBackupRetention() {
+  return window['go']['main']['App']['GetBackupRetention']();
+}
+
+export function GetChangeHistory(arg1) {
+  return window['go']['main']['App']['GetChangeHistory'](arg1);
+}
+
+export function GetDashboard() {
+  return window['go']['main']['App']['GetDashboard']();
+}
+
+export function GetDataCompression() {
+  return window['go']['main']['App']['GetDataCompression']();
+}
+
+export function GetDataDirectory() {
+  return window['go']['main']['App']['GetDataDirectory']();
+}
+
+export function GetDayProfiles() {
+  return window['go']['main']['App']['GetDayProfiles']();
+}
+
+export function GetDeletedTasks() {
+  return window['go']['main']['App']['GetDeletedTasks']();
+}
+
+export function GetDependencyGraph() {
+  return window['go']['main']['App']['GetDependencyGraph']();
+}
+
+export function GetDependencyWarnings(arg1) {
+  return window['go']['main']['App']['GetDependencyWarnings'](arg1);
+}
+
+export function GetDiagnostics() {
+  return window['go']['main']['App']['GetDiagnostics']();
+}
+
+export function GetEditWindow() {
+  return window['go']['main']['App']['GetEditWindow']();
+}
+
 export function GetExportPath() {
   return window['go']['main']['App']['GetExportPath']();
 }
 
+export function GetExportSchedule() {
+  return window['go']['main']['App']['GetExportSchedule']();
+}
+
+export function GetFeatureOptIns() {
+  return window['go']['main']['App']['GetFeatureOptIns']();
+}
+
+export function GetFocusReport(arg1, arg2) {
+  return window['go']['main']['App']['GetFocusReport'](arg1, arg2);
+}
+
+export function GetFutureNotes() {
+  return window['go']['main']['App']['GetFutureNotes']();
+}
+
+export function GetGapSummary() {
+  return window['go']['main']['App']['GetGapSummary']();
+}
+
+export function GetGroups() {
+  return window['go']['main']['App']['GetGroups']();
+}
+
+export function GetHeatmap(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetHeatmap'](arg1, arg2, arg3, arg4);
+}
+
+export function GetLifetimeGoals() {
+  return window['go']['main']['App']['GetLifetimeGoals']();
+}
+
+export function GetMeasurementStats(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetMeasurementStats'](arg1, arg2, arg3);
+}
+
 export function GetMonthlyReport(arg1, arg2) {
   return window['go']['main']['App']['GetMonthlyReport'](arg1, arg2);
 }
 
+export function GetNotifications() {
+  return window['go']['main']['App']['GetNotifications']();
+}
+
+export function GetOnboardingState() {
+  return window['go']['main']['App']['GetOnboardingState']();
+}
+
+export function GetPersonalRecords() {
+  return window['go']['main']['App']['GetPersonalRecords']();
+}
+
+export function GetPlanReminderTime() {
+  return window['go']['main']['App']['GetPlanReminderTime']();
+}
+
+export function GetPresets() {
+  return window['go']['main']['App']['GetPresets']();
+}
+
+export function GetProfileForDate(arg1) {
+  return window['go']['main']['App']['GetProfileForDate'](arg1);
+}
+
+export function GetQuarantine() {
+  return window['go']['main']['App']['GetQuarantine']();
+}
+
+export function GetRetentionPolicy() {
+  return window['go']['main']['App']['GetRetentionPolicy']();
+}
+
+export function GetRolloverHour() {
+  return window['go']['main']['App']['GetRolloverHour']();
+}
+
+export function GetRunningTimers() {
+  return window['go']['main']['App']['GetRunningTimers']();
+}
+
+export function GetSaveStatus() {
+  return window['go']['main']['App']['GetSaveStatus']();
+}
+
+export function GetScoringConfig() {
+  return window['go']['main']['App']['GetScoringConfig']();
+}
+
+export function GetSecondaryBackupDir() {
+  return window['go']['main']['App']['GetSecondaryBackupDir']();
+}
+
+export function GetSignals(arg1) {
+  return window['go']['main']['App']['GetSignals'](arg1);
+}
+
+export function GetSkippedDays(arg1, arg2) {
+  return window['go']['main']['App']['GetSkippedDays'](arg1, arg2);
+}
+
+export function GetSortMode() {
+  return window['go']['main']['App']['GetSortMode']();
+}
+
+export function GetSpecialDays() {
+  return window['go']['main']['App']['GetSpecialDays']();
+}
+
+export function GetSpecialDaysInRange(arg1, arg2) {
+  return window['go']['main']['App']['GetSpecialDaysInRange'](arg1, arg2);
+}
+
+export function GetStorageBackend() {
+  return window['go']['main']['App']['GetStorageBackend']();
+}
+
+export function GetStreakProgress(arg1) {
+  return window['go']['main']['App']['GetStreakProgress'](arg1);
+}
+
 export function GetStreaks() {
   return window['go']['main']['App']['GetStreaks']();
 }
 
+export function GetSyncStatus() {
+  return window['go']['main']['App']['GetSyncStatus']();
+}
+
+export function GetTaperProgress(arg1) {
+  return window['go']['main']['App']['GetTaperProgress'](arg1);
+}
+
 export function GetTaskTemplates() {
   return window['go']['main']['App']['GetTaskTemplates']();
 }
@@ -30,34 +374,278 @@ export function GetTasksForDate(arg1) {
   return window['go']['main']['App']['GetTasksForDate'](arg1);
 }
 
+export function GetTierDays(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetTierDays'](arg1, arg2, arg3);
+}
+
+export function GetTierDistribution(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetTierDistribution'](arg1, arg2, arg3);
+}
+
+export function GetToday() {
+  return window['go']['main']['App']['GetToday']();
+}
+
+export function GetTrash() {
+  return window['go']['main']['App']['GetTrash']();
+}
+
+export function GetUndoState() {
+  return window['go']['main']['App']['GetUndoState']();
+}
+
+export function GetUnseenChanges() {
+  return window['go']['main']['App']['GetUnseenChanges']();
+}
+
+export function GetVacations() {
+  return window['go']['main']['App']['GetVacations']();
+}
+
+export function GetValueDistribution(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetValueDistribution'](arg1, arg2, arg3);
+}
+
+export function GetWeekDiff(arg1) {
+  return window['go']['main']['App']['GetWeekDiff'](arg1);
+}
+
+export function GetWeekSummaryTargets() {
+  return window['go']['main']['App']['GetWeekSummaryTargets']();
+}
+
 export function GetWeeklyReport(arg1) {
   return window['go']['main']['App']['GetWeeklyReport'](arg1);
 }
 
+export function GetWeeklyReviews() {
+  return window['go']['main']['App']['GetWeeklyReviews']();
+}
+
 export function GetYearlyReport(arg1) {
   return window['go']['main']['App']['GetYearlyReport'](arg1);
 }
 
+export function HasPlannedWeek(arg1) {
+  return window['go']['main']['App']['HasPlannedWeek'](arg1);
+}
+
+export function ImportCSV(arg1, arg2) {
+  return window['go']['main']['App']['ImportCSV'](arg1, arg2);
+}
+
+export function ImportData(arg1, arg2) {
+  return window['go']['main']['App']['ImportData'](arg1, arg2);
+}
+
+export function ImportDroppedFile(arg1, arg2) {
+  return window['go']['main']['App']['ImportDroppedFile'](arg1, arg2);
+}
+
+export function ImportHabits(arg1, arg2) {
+  return window['go']['main']['App']['ImportHabits'](arg1, arg2);
+}
+
+export function ImportSignalCSV(arg1, arg2) {
+  return window['go']['main']['App']['ImportSignalCSV'](arg1, arg2);
+}
+
+export function ImportSignals(arg1, arg2) {
+  return window['go']['main']['App']['ImportSignals'](arg1, arg2);
+}
+
+export function IsDataInUse() {
+  return window['go']['main']['App']['IsDataInUse']();
+}
+
+export function IsDayLocked(arg1) {
+  return window['go']['main']['App']['IsDayLocked'](arg1);
+}
+
+export function IsDemoMode() {
+  return window['go']['main']['App']['IsDemoMode']();
+}
+
+export function IsEncrypted() {
+  return window['go']['main']['App']['IsEncrypted']();
+}
+
+export function IsGitHistoryEnabled() {
+  return window['go']['main']['App']['IsGitHistoryEnabled']();
+}
+
+export function IsLocked() {
+  return window['go']['main']['App']['IsLocked']();
+}
+
+export function IsReplayMode() {
+  return window['go']['main']['App']['IsReplayMode']();
+}
+
 export function IsWeekExported(arg1) {
   return window['go']['main']['App']['IsWeekExported'](arg1);
 }
 
+export function ListBackups() {
+  return window['go']['main']['App']['ListBackups']();
+}
+
+export function ListChartConfigs() {
+  return window['go']['main']['App']['ListChartConfigs']();
+}
+
+export function ListRevisions() {
+  return window['go']['main']['App']['ListRevisions']();
+}
+
 export function LoadDay(arg1) {
   return window['go']['main']['App']['LoadDay'](arg1);
 }
 
+export function LoadDayMeasurements(arg1) {
+  return window['go']['main']['App']['LoadDayMeasurements'](arg1);
+}
+
+export function LoadDaySkipped(arg1) {
+  return window['go']['main']['App']['LoadDaySkipped'](arg1);
+}
+
+export function LoadDaySubitems(arg1) {
+  return window['go']['main']['App']['LoadDaySubitems'](arg1);
+}
+
 export function LoadWeek(arg1) {
   return window['go']['main']['App']['LoadWeek'](arg1);
 }
 
+export function LoadWeekDetailed(arg1) {
+  return window['go']['main']['App']['LoadWeekDetailed'](arg1);
+}
+
+export function Lock() {
+  return window['go']['main']['App']['Lock']();
+}
+
+export function LockDayAgain(arg1) {
+  return window['go']['main']['App']['LockDayAgain'](arg1);
+}
+
+export function MarkAllNotificationsRead() {
+  return window['go']['main']['App']['MarkAllNotificationsRead']();
+}
+
+export function MarkChangesSeen(arg1) {
+  return window['go']['main']['App']['MarkChangesSeen'](arg1);
+}
+
+export function MarkNotificationRead(arg1) {
+  return window['go']['main']['App']['MarkNotificationRead'](arg1);
+}
+
 export function MarkWeekExported(arg1) {
   return window['go']['main']['App']['MarkWeekExported'](arg1);
 }
 
+export function MergeTasks(arg1, arg2, arg3) {
+  return window['go']['main']['App']['MergeTasks'](arg1, arg2, arg3);
+}
+
+export function MoveTaskToGroup(arg1, arg2) {
+  return window['go']['main']['App']['MoveTaskToGroup'](arg1, arg2);
+}
+
+export function PreviewCSV(arg1) {
+  return window['go']['main']['App']['PreviewCSV'](arg1);
+}
+
+export function PreviewDroppedFile(arg1) {
+  return window['go']['main']['App']['PreviewDroppedFile'](arg1);
+}
+
+export function PurgeTask(arg1, arg2) {
+  return window['go']['main']['App']['PurgeTask'](arg1, arg2);
+}
+
+export function Query(arg1) {
+  return window['go']['main']['App']['Query'](arg1);
+}
+
+export function QuickCheck(arg1) {
+  return window['go']['main']['App']['QuickCheck'](arg1);
+}
+
+export function Redo() {
+  return window['go']['main']['App']['Redo']();
+}
+
+export function RemoveDayProfile(arg1) {
+  return window['go']['main']['App']['RemoveDayProfile'](arg1);
+}
+
+export function RemoveLifetimeGoal(arg1) {
+  return window['go']['main']['App']['RemoveLifetimeGoal'](arg1);
+}
+
+export function RemoveSpecialDay(arg1) {
+  return window['go']['main']['App']['RemoveSpecialDay'](arg1);
+}
+
+export function RemoveSubitem(arg1, arg2) {
+  return window['go']['main']['App']['RemoveSubitem'](arg1, arg2);
+}
+
+export function RemoveVacation(arg1, arg2) {
+  return window['go']['main']['App']['RemoveVacation'](arg1, arg2);
+}
+
+export function RenameGroup(arg1, arg2) {
+  return window['go']['main']['App']['RenameGroup'](arg1, arg2);
+}
+
+export function ReorderGroups(arg1) {
+  return window['go']['main']['App']['ReorderGroups'](arg1);
+}
+
 export function ReorderTasks(arg1) {
   return window['go']['main']['App']['ReorderTasks'](arg1);
 }
 
+export function RepairData() {
+  return window['go']['main']['App']['RepairData']();
+}
+
+export function RestoreBackup(arg1) {
+  return window['go']['main']['App']['RestoreBackup'](arg1);
+}
+
+export function RestoreBackupWithPassphrase(arg1, arg2) {
+  return window['go']['main']['App']['RestoreBackupWithPassphrase'](arg1, arg2);
+}
+
+export function RestoreFileWithPassphrase(arg1, arg2) {
+  return window['go']['main']['App']['RestoreFileWithPassphrase'](arg1, arg2);
+}
+
+export function RestoreFromFile() {
+  return window['go']['main']['App']['RestoreFromFile']();
+}
+
+export function RestoreFromTrash(arg1) {
+  return window['go']['main']['App']['RestoreFromTrash'](arg1);
+}
+
+export function RestoreRevision(arg1) {
+  return window['go']['main']['App']['RestoreRevision'](arg1);
+}
+
+export function RunMaintenance() {
+  return window['go']['main']['App']['RunMaintenance']();
+}
+
+export function SaveChartConfig(arg1, arg2) {
+  return window['go']['main']['App']['SaveChartConfig'](arg1, arg2);
+}
+
 export function SaveDay(arg1, arg2) {
   return window['go']['main']['App']['SaveDay'](arg1, arg2);
 }
@@ -70,14 +658,254 @@ export function SelectDirectory() {
   return window['go']['main']['App']['SelectDirectory']();
 }
 
+export function SetBackupRetention(arg1, arg2) {
+  return window['go']['main']['App']['SetBackupRetention'](arg1, arg2);
+}
+
+export function SetDataCompression(arg1) {
+  return window['go']['main']['App']['SetDataCompression'](arg1);
+}
+
+export function SetDataDirectory(arg1) {
+  return window['go']['main']['App']['SetDataDirectory'](arg1);
+}
+
+export function SetDateProfile(arg1, arg2) {
+  return window['go']['main']['App']['SetDateProfile'](arg1, arg2);
+}
+
+export function SetEditWindow(arg1) {
+  return window['go']['main']['App']['SetEditWindow'](arg1);
+}
+
 export function SetExportPath(arg1) {
   return window['go']['main']['App']['SetExportPath'](arg1);
 }
 
+export function SetExportSchedule(arg1) {
+  return window['go']['main']['App']['SetExportSchedule'](arg1);
+}
+
+export function SetFeatureOptIn(arg1, arg2) {
+  return window['go']['main']['App']['SetFeatureOptIn'](arg1, arg2);
+}
+
+export function SetGitHistory(arg1) {
+  return window['go']['main']['App']['SetGitHistory'](arg1);
+}
+
+export function SetMeasurement(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetMeasurement'](arg1, arg2, arg3);
+}
+
+export function SetPlanReminderTime(arg1) {
+  return window['go']['main']['App']['SetPlanReminderTime'](arg1);
+}
+
+export function SetRetentionPolicy(arg1) {
+  return window['go']['main']['App']['SetRetentionPolicy'](arg1);
+}
+
+export function SetRolloverHour(arg1) {
+  return window['go']['main']['App']['SetRolloverHour'](arg1);
+}
+
+export function SetScoringConfig(arg1) {
+  return window['go']['main']['App']['SetScoringConfig'](arg1);
+}
+
+export function SetSecondaryBackup(arg1, arg2) {
+  return window['go']['main']['App']['SetSecondaryBackup'](arg1, arg2);
+}
+
+export function SetSortMode(arg1) {
+  return window['go']['main']['App']['SetSortMode'](arg1);
+}
+
+export function SetStorageBackend(arg1) {
+  return window['go']['main']['App']['SetStorageBackend'](arg1);
+}
+
+export function SetSubitemDone(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SetSubitemDone'](arg1, arg2, arg3, arg4);
+}
+
+export function SetSyncSettings(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SetSyncSettings'](arg1, arg2, arg3, arg4);
+}
+
+export function SetTaskAutoSource(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SetTaskAutoSource'](arg1, arg2, arg3, arg4);
+}
+
+export function SetTaskComposite(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetTaskComposite'](arg1, arg2, arg3);
+}
+
+export function SetTaskEndDate(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskEndDate'](arg1, arg2);
+}
+
+export function SetTaskFrequency(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskFrequency'](arg1, arg2);
+}
+
+export function SetTaskMonthly(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskMonthly'](arg1, arg2);
+}
+
+export function SetTaskOnDays(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskOnDays'](arg1, arg2);
+}
+
+export function SetTaskPaused(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetTaskPaused'](arg1, arg2, arg3);
+}
+
+export function SetTaskPrefill(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskPrefill'](arg1, arg2);
+}
+
+export function SetTaskPrerequisite(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskPrerequisite'](arg1, arg2);
+}
+
+export function SetTaskPriority(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskPriority'](arg1, arg2);
+}
+
+export function SetTaskProfiles(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskProfiles'](arg1, arg2);
+}
+
+export function SetTaskReminder(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskReminder'](arg1, arg2);
+}
+
+export function SetTaskScaleMax(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskScaleMax'](arg1, arg2);
+}
+
+export function SetTaskStreakGoal(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskStreakGoal'](arg1, arg2);
+}
+
+export function SetTaskTaper(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SetTaskTaper'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SetTaskTarget(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskTarget'](arg1, arg2);
+}
+
+export function SetTaskTiers(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskTiers'](arg1, arg2);
+}
+
 export function SetTaskType(arg1, arg2) {
   return window['go']['main']['App']['SetTaskType'](arg1, arg2);
 }
 
+export function SetVacation(arg1, arg2) {
+  return window['go']['main']['App']['SetVacation'](arg1, arg2);
+}
+
+export function SetWeekSummaryTargets(arg1) {
+  return window['go']['main']['App']['SetWeekSummaryTargets'](arg1);
+}
+
+export function SimulateReport(arg1, arg2) {
+  return window['go']['main']['App']['SimulateReport'](arg1, arg2);
+}
+
+export function SkipDay(arg1, arg2) {
+  return window['go']['main']['App']['SkipDay'](arg1, arg2);
+}
+
+export function SkipTask(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SkipTask'](arg1, arg2, arg3);
+}
+
+export function StartDemoMode() {
+  return window['go']['main']['App']['StartDemoMode']();
+}
+
+export function StartTimer(arg1) {
+  return window['go']['main']['App']['StartTimer'](arg1);
+}
+
+export function StartWeeklyReview(arg1) {
+  return window['go']['main']['App']['StartWeeklyReview'](arg1);
+}
+
+export function StopTimer(arg1) {
+  return window['go']['main']['App']['StopTimer'](arg1);
+}
+
+export function SubmitReviewStep(arg1, arg2) {
+  return window['go']['main']['App']['SubmitReviewStep'](arg1, arg2);
+}
+
+export function Sync() {
+  return window['go']['main']['App']['Sync']();
+}
+
+export function UnarchiveTask(arg1) {
+  return window['go']['main']['App']['UnarchiveTask'](arg1);
+}
+
+export function UnarchiveYears() {
+  return window['go']['main']['App']['UnarchiveYears']();
+}
+
+export function UndeleteTask(arg1) {
+  return window['go']['main']['App']['UndeleteTask'](arg1);
+}
+
+export function Undo() {
+  return window['go']['main']['App']['Undo']();
+}
+
+export function Unlock(arg1) {
+  return window['go']['main']['App']['Unlock'](arg1);
+}
+
+export function UnlockDayForEditing(arg1) {
+  return window['go']['main']['App']['UnlockDayForEditing'](arg1);
+}
+
+export function UnskipDay(arg1) {
+  return window['go']['main']['App']['UnskipDay'](arg1);
+}
+
+export function UnskipTask(arg1, arg2) {
+  return window['go']['main']['App']['UnskipTask'](arg1, arg2);
+}
+
+export function UpdateDayProfile(arg1, arg2, arg3) {
+  return window['go']['main']['App']['UpdateDayProfile'](arg1, arg2, arg3);
+}
+
+export function UpdateLifetimeGoal(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['UpdateLifetimeGoal'](arg1, arg2, arg3, arg4);
+}
+
+export function UpdateSubitem(arg1, arg2, arg3) {
+  return window['go']['main']['App']['UpdateSubitem'](arg1, arg2, arg3);
+}
+
 export function UpdateTask(arg1, arg2) {
   return window['go']['main']['App']['UpdateTask'](arg1, arg2);
 }
+
+export function UpdateTaskDescription(arg1, arg2) {
+  return window['go']['main']['App']['UpdateTaskDescription'](arg1, arg2);
+}
+
+export function VerifyData() {
+  return window['go']['main']['App']['VerifyData']();
+}
+
+export function WriteFutureNote(arg1, arg2) {
+  return window['go']['main']['App']['WriteFutureNote'](arg1, arg2);
+}
@@ -1,5 +1,443 @@
 export namespace main {
 	
+	export class DeprecatedMethod {
+	    method: string;
+	    since: number;
+	    replacement: string;
+	    note?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeprecatedMethod(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.method = source["method"];
+	        this.since = source["since"];
+	        this.replacement = source["replacement"];
+	        this.note = source["note"];
+	    }
+	}
+	export class APIInfo {
+	    version: number;
+	    capabilities: string[];
+	    deprecated: DeprecatedMethod[];
+	
+	    static createFrom(source: any = {}) {
+	        return new APIInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.capabilities = source["capabilities"];
+	        this.deprecated = this.convertValues(source["deprecated"], DeprecatedMethod);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Annotation {
+	    id: string;
+	    date: string;
+	    endDate?: string;
+	    text: string;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Annotation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.date = source["date"];
+	        this.endDate = source["endDate"];
+	        this.text = source["text"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class AuditEntry {
+	    time: string;
+	    actor: string;
+	    method: string;
+	    date?: string;
+	    taskId?: string;
+	    oldValue?: any;
+	    newValue?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuditEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.actor = source["actor"];
+	        this.method = source["method"];
+	        this.date = source["date"];
+	        this.taskId = source["taskId"];
+	        this.oldValue = source["oldValue"];
+	        this.newValue = source["newValue"];
+	    }
+	}
+	export class AutoSource {
+	    signal: string;
+	    op?: string;
+	    threshold?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AutoSource(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.signal = source["signal"];
+	        this.op = source["op"];
+	        this.threshold = source["threshold"];
+	    }
+	}
+	export class BackupInfo {
+	    name: string;
+	    path: string;
+	    kind: string;
+	    created: string;
+	    size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.kind = source["kind"];
+	        this.created = source["created"];
+	        this.size = source["size"];
+	    }
+	}
+	export class BackupRetention {
+	    daily: number;
+	    weekly: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupRetention(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.daily = source["daily"];
+	        this.weekly = source["weekly"];
+	    }
+	}
+	export class CSVRowError {
+	    line: number;
+	    column?: string;
+	    value: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVRowError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.line = source["line"];
+	        this.column = source["column"];
+	        this.value = source["value"];
+	        this.message = source["message"];
+	    }
+	}
+	export class CSVImportReport {
+	    dryRun: boolean;
+	    rows: number;
+	    from: string;
+	    to: string;
+	    columns: Record<string, string>;
+	    newTasks: string[];
+	    backdated: string[];
+	    values: number;
+	    overwritten: number;
+	    warnings: string[];
+	    errors: CSVRowError[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVImportReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dryRun = source["dryRun"];
+	        this.rows = source["rows"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.columns = source["columns"];
+	        this.newTasks = source["newTasks"];
+	        this.backdated = source["backdated"];
+	        this.values = source["values"];
+	        this.overwritten = source["overwritten"];
+	        this.warnings = source["warnings"];
+	        this.errors = this.convertValues(source["errors"], CSVRowError);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CSVPreviewColumn {
+	    header: string;
+	    format: string;
+	    taskId?: string;
+	    taskName?: string;
+	    ignored?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVPreviewColumn(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.header = source["header"];
+	        this.format = source["format"];
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.ignored = source["ignored"];
+	    }
+	}
+	export class CSVPreview {
+	    headers: string[];
+	    rows: string[][];
+	    totalRows: number;
+	    dateColumn: string;
+	    dateFormat?: string;
+	    columns: CSVPreviewColumn[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.headers = source["headers"];
+	        this.rows = source["rows"];
+	        this.totalRows = source["totalRows"];
+	        this.dateColumn = source["dateColumn"];
+	        this.dateFormat = source["dateFormat"];
+	        this.columns = this.convertValues(source["columns"], CSVPreviewColumn);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class ChangeEntry {
+	    time: string;
+	    device: string;
+	    cause?: string;
+	    date: string;
+	    taskId: string;
+	    old?: number;
+	    new?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangeEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.device = source["device"];
+	        this.cause = source["cause"];
+	        this.date = source["date"];
+	        this.taskId = source["taskId"];
+	        this.old = source["old"];
+	        this.new = source["new"];
+	    }
+	}
+	export class ChangeNote {
+	    version: string;
+	    title: string;
+	    body: string;
+	    optIn?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangeNote(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.title = source["title"];
+	        this.body = source["body"];
+	        this.optIn = source["optIn"];
+	    }
+	}
+	export class ChartConfig {
+	    name: string;
+	    taskIds: string[];
+	    metric: string;
+	    days: number;
+	    type: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChartConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.taskIds = source["taskIds"];
+	        this.metric = source["metric"];
+	        this.days = source["days"];
+	        this.type = source["type"];
+	    }
+	}
+	export class Composite {
+	    op: string;
+	    parts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Composite(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.op = source["op"];
+	        this.parts = source["parts"];
+	    }
+	}
+	export class Notification {
+	    id: string;
+	    time: string;
+	    kind: string;
+	    title: string;
+	    body?: string;
+	    read?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Notification(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.time = source["time"];
+	        this.kind = source["kind"];
+	        this.title = source["title"];
+	        this.body = source["body"];
+	        this.read = source["read"];
+	    }
+	}
+	export class TaskStreak {
+	    taskId: string;
+	    taskName: string;
+	    streak: number;
+	    atRisk: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskStreak(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.streak = source["streak"];
+	        this.atRisk = source["atRisk"];
+	    }
+	}
+	export class Taper {
+	    start: string;
+	    from: number;
+	    to: number;
+	    weeks: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Taper(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = source["start"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.weeks = source["weeks"];
+	    }
+	}
+	export class DateRange {
+	    from: string;
+	    to: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DateRange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class Subitem {
+	    id: string;
+	    name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Subitem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	    }
+	}
 	export class TaskTemplate {
 	    id: string;
 	    name: string;
@@ -8,6 +446,29 @@ export namespace main {
 	    order: number;
 	    createdAt: string;
 	    deletedAt?: string;
+	    subitems?: Subitem[];
+	    archived?: boolean;
+	    archivedAt?: string;
+	    paused?: DateRange[];
+	    source?: AutoSource;
+	    endsAt?: string;
+	    timesPerWeek?: number;
+	    group?: string;
+	    reminderTime?: string;
+	    target?: number;
+	    prefill?: string;
+	    dayOfMonth?: number;
+	    scaleMax?: number;
+	    taper?: Taper;
+	    priority?: number;
+	    description?: string;
+	    requires?: string;
+	    onDays?: string;
+	    streakGoal?: number;
+	    tiers?: number[];
+	    profiles?: string[];
+	    composite?: Composite;
+	    readOnly?: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new TaskTemplate(source);
@@ -22,6 +483,2017 @@ export namespace main {
 	        this.order = source["order"];
 	        this.createdAt = source["createdAt"];
 	        this.deletedAt = source["deletedAt"];
+	        this.subitems = this.convertValues(source["subitems"], Subitem);
+	        this.archived = source["archived"];
+	        this.archivedAt = source["archivedAt"];
+	        this.paused = this.convertValues(source["paused"], DateRange);
+	        this.source = this.convertValues(source["source"], AutoSource);
+	        this.endsAt = source["endsAt"];
+	        this.timesPerWeek = source["timesPerWeek"];
+	        this.group = source["group"];
+	        this.reminderTime = source["reminderTime"];
+	        this.target = source["target"];
+	        this.prefill = source["prefill"];
+	        this.dayOfMonth = source["dayOfMonth"];
+	        this.scaleMax = source["scaleMax"];
+	        this.taper = this.convertValues(source["taper"], Taper);
+	        this.priority = source["priority"];
+	        this.description = source["description"];
+	        this.requires = source["requires"];
+	        this.onDays = source["onDays"];
+	        this.streakGoal = source["streakGoal"];
+	        this.tiers = source["tiers"];
+	        this.profiles = source["profiles"];
+	        this.composite = this.convertValues(source["composite"], Composite);
+	        this.readOnly = source["readOnly"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Dashboard {
+	    today: string;
+	    todayTasks: TaskTemplate[];
+	    todayValues: Record<string, number>;
+	    todayProvisional: Record<string, boolean>;
+	    weekStart: string;
+	    weekPercentages: number[];
+	    currentStreak: number;
+	    longestStreak: number;
+	    taskStreaks: TaskStreak[];
+	    atRiskStreaks: TaskStreak[];
+	    pendingExports: string[];
+	    unreadNotifications: Notification[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Dashboard(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.today = source["today"];
+	        this.todayTasks = this.convertValues(source["todayTasks"], TaskTemplate);
+	        this.todayValues = source["todayValues"];
+	        this.todayProvisional = source["todayProvisional"];
+	        this.weekStart = source["weekStart"];
+	        this.weekPercentages = source["weekPercentages"];
+	        this.currentStreak = source["currentStreak"];
+	        this.longestStreak = source["longestStreak"];
+	        this.taskStreaks = this.convertValues(source["taskStreaks"], TaskStreak);
+	        this.atRiskStreaks = this.convertValues(source["atRiskStreaks"], TaskStreak);
+	        this.pendingExports = source["pendingExports"];
+	        this.unreadNotifications = this.convertValues(source["unreadNotifications"], Notification);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DayProfile {
+	    id: string;
+	    name: string;
+	    weekdays?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DayProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.weekdays = source["weekdays"];
+	    }
+	}
+	export class DayValues {
+	    values: Record<string, number>;
+	    scaleMax: Record<string, number>;
+	
+	    static createFrom(source: any = {}) {
+	        return new DayValues(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.values = source["values"];
+	        this.scaleMax = source["scaleMax"];
+	    }
+	}
+	export class DependencyNode {
+	    taskId: string;
+	    taskName: string;
+	    requires?: string;
+	    dependents: string[];
+	    depth: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DependencyNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.requires = source["requires"];
+	        this.dependents = source["dependents"];
+	        this.depth = source["depth"];
+	    }
+	}
+	export class DependencyWarning {
+	    date: string;
+	    taskId: string;
+	    taskName: string;
+	    requiresId: string;
+	    requiresName: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DependencyWarning(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.requiresId = source["requiresId"];
+	        this.requiresName = source["requiresName"];
+	    }
+	}
+	
+	export class DropPreview {
+	    path: string;
+	    fileName: string;
+	    format: string;
+	    summary: string;
+	    tasks?: string[];
+	    days: number;
+	    from?: string;
+	    to?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DropPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.fileName = source["fileName"];
+	        this.format = source["format"];
+	        this.summary = source["summary"];
+	        this.tasks = source["tasks"];
+	        this.days = source["days"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.error = source["error"];
+	    }
+	}
+	export class EmailTarget {
+	    host: string;
+	    port: number;
+	    username?: string;
+	    password?: string;
+	    from: string;
+	    to: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EmailTarget(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.username = source["username"];
+	        this.password = source["password"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class ExportFormat {
+	    id: string;
+	    label: string;
+	    extension: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportFormat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.label = source["label"];
+	        this.extension = source["extension"];
+	    }
+	}
+	export class ExportRun {
+	    time: string;
+	    period: string;
+	    path?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportRun(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.period = source["period"];
+	        this.path = source["path"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ExportRule {
+	    id: string;
+	    format: string;
+	    cadence: string;
+	    day: number;
+	    lastPeriod?: string;
+	    history?: ExportRun[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.format = source["format"];
+	        this.cadence = source["cadence"];
+	        this.day = source["day"];
+	        this.lastPeriod = source["lastPeriod"];
+	        this.history = this.convertValues(source["history"], ExportRun);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TaskFocus {
+	    taskId: string;
+	    taskName: string;
+	    sessions: number;
+	    minutes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskFocus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.sessions = source["sessions"];
+	        this.minutes = source["minutes"];
+	    }
+	}
+	export class FocusReport {
+	    from: string;
+	    to: string;
+	    sessions: number;
+	    totalMinutes: number;
+	    averageMinutes: number;
+	    bestDay?: string;
+	    bestDayMinutes: number;
+	    tasks: TaskFocus[];
+	
+	    static createFrom(source: any = {}) {
+	        return new FocusReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.sessions = source["sessions"];
+	        this.totalMinutes = source["totalMinutes"];
+	        this.averageMinutes = source["averageMinutes"];
+	        this.bestDay = source["bestDay"];
+	        this.bestDayMinutes = source["bestDayMinutes"];
+	        this.tasks = this.convertValues(source["tasks"], TaskFocus);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FocusSession {
+	    taskId: string;
+	    date: string;
+	    start: string;
+	    end: string;
+	    minutes: number;
+	    count?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FocusSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.date = source["date"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.minutes = source["minutes"];
+	        this.count = source["count"];
+	    }
+	}
+	export class FrequencyProgress {
+	    taskId: string;
+	    done: number;
+	    quota: number;
+	    met: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FrequencyProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.done = source["done"];
+	        this.quota = source["quota"];
+	        this.met = source["met"];
+	    }
+	}
+	export class FutureNote {
+	    id: string;
+	    date: string;
+	    text: string;
+	    createdAt: string;
+	    revealed?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FutureNote(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.date = source["date"];
+	        this.text = source["text"];
+	        this.createdAt = source["createdAt"];
+	        this.revealed = source["revealed"];
+	    }
+	}
+	export class GapSummary {
+	    from: string;
+	    to: string;
+	    days: number;
+	    lastTracked: string;
+	    choices: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GapSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.days = source["days"];
+	        this.lastTracked = source["lastTracked"];
+	        this.choices = source["choices"];
+	    }
+	}
+	export class HabitImportReport {
+	    dryRun: boolean;
+	    app: string;
+	    habits: number;
+	    from: string;
+	    to: string;
+	    newTasks: string[];
+	    matched: string[];
+	    values: number;
+	    overwritten: number;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new HabitImportReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dryRun = source["dryRun"];
+	        this.app = source["app"];
+	        this.habits = source["habits"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.newTasks = source["newTasks"];
+	        this.matched = source["matched"];
+	        this.values = source["values"];
+	        this.overwritten = source["overwritten"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class HeatmapDay {
+	    date: string;
+	    score: number;
+	    intensity: number;
+	    level: number;
+	    excluded?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new HeatmapDay(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.score = source["score"];
+	        this.intensity = source["intensity"];
+	        this.level = source["level"];
+	        this.excluded = source["excluded"];
+	    }
+	}
+	export class Heatmap {
+	    from: string;
+	    to: string;
+	    scale: string;
+	    levels: number;
+	    days: HeatmapDay[];
+	    legend?: number[];
+	    monthLegends?: Record<string, Array<number>>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Heatmap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.scale = source["scale"];
+	        this.levels = source["levels"];
+	        this.days = this.convertValues(source["days"], HeatmapDay);
+	        this.legend = source["legend"];
+	        this.monthLegends = source["monthLegends"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ImportResult {
+	    tasks: number;
+	    values: number;
+	    notes: number;
+	    specialDays: number;
+	    backupPath: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tasks = source["tasks"];
+	        this.values = source["values"];
+	        this.notes = source["notes"];
+	        this.specialDays = source["specialDays"];
+	        this.backupPath = source["backupPath"];
+	    }
+	}
+	export class IntegrityIssue {
+	    kind: string;
+	    date?: string;
+	    taskId?: string;
+	    detail: string;
+	    repairable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new IntegrityIssue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.date = source["date"];
+	        this.taskId = source["taskId"];
+	        this.detail = source["detail"];
+	        this.repairable = source["repairable"];
+	    }
+	}
+	export class IntegrityReport {
+	    issues: IntegrityIssue[];
+	    values: number;
+	    repaired: number;
+	    backupPath?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IntegrityReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.issues = this.convertValues(source["issues"], IntegrityIssue);
+	        this.values = source["values"];
+	        this.repaired = source["repaired"];
+	        this.backupPath = source["backupPath"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LifetimeGoal {
+	    id: string;
+	    taskId: string;
+	    name: string;
+	    target: number;
+	    since?: string;
+	    milestone?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifetimeGoal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.taskId = source["taskId"];
+	        this.name = source["name"];
+	        this.target = source["target"];
+	        this.since = source["since"];
+	        this.milestone = source["milestone"];
+	    }
+	}
+	export class LifetimeGoalProgress {
+	    id: string;
+	    taskId: string;
+	    taskName: string;
+	    name: string;
+	    unit: string;
+	    target: number;
+	    total: number;
+	    remaining: number;
+	    percent: number;
+	    perDay: number;
+	    projected?: string;
+	    reachedOn?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifetimeGoalProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.name = source["name"];
+	        this.unit = source["unit"];
+	        this.target = source["target"];
+	        this.total = source["total"];
+	        this.remaining = source["remaining"];
+	        this.percent = source["percent"];
+	        this.perDay = source["perDay"];
+	        this.projected = source["projected"];
+	        this.reachedOn = source["reachedOn"];
+	    }
+	}
+	export class MaintenanceResult {
+	    notes: number;
+	    subitemDays: number;
+	    focusSessions: number;
+	    auditEntries: number;
+	    tasks: number;
+	    archivedDays: number;
+	    archivePath?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MaintenanceResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.notes = source["notes"];
+	        this.subitemDays = source["subitemDays"];
+	        this.focusSessions = source["focusSessions"];
+	        this.auditEntries = source["auditEntries"];
+	        this.tasks = source["tasks"];
+	        this.archivedDays = source["archivedDays"];
+	        this.archivePath = source["archivePath"];
+	    }
+	}
+	export class MarkdownExportResult {
+	    folder: string;
+	    files: string[];
+	    skipped: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MarkdownExportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.folder = source["folder"];
+	        this.files = source["files"];
+	        this.skipped = source["skipped"];
+	    }
+	}
+	export class MeasurementStats {
+	    taskId: string;
+	    unit?: string;
+	    count: number;
+	    sum: number;
+	    average: number;
+	    min: number;
+	    max: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MeasurementStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.unit = source["unit"];
+	        this.count = source["count"];
+	        this.sum = source["sum"];
+	        this.average = source["average"];
+	        this.min = source["min"];
+	        this.max = source["max"];
+	    }
+	}
+	
+	export class OnboardingStep {
+	    id: string;
+	    done: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new OnboardingStep(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.done = source["done"];
+	    }
+	}
+	export class OnboardingProgress {
+	    steps: OnboardingStep[];
+	    next?: string;
+	    finished: boolean;
+	    completed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OnboardingProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steps = this.convertValues(source["steps"], OnboardingStep);
+	        this.next = source["next"];
+	        this.finished = source["finished"];
+	        this.completed = source["completed"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PersonalRecord {
+	    taskId: string;
+	    bestDay: number;
+	    bestDayDate?: string;
+	    bestWeek: number;
+	    bestWeekStart?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PersonalRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.bestDay = source["bestDay"];
+	        this.bestDayDate = source["bestDayDate"];
+	        this.bestWeek = source["bestWeek"];
+	        this.bestWeekStart = source["bestWeekStart"];
+	    }
+	}
+	export class SpecialDay {
+	    id: string;
+	    name: string;
+	    kind: string;
+	    date: string;
+	    year?: number;
+	    excluded: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SpecialDay(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.date = source["date"];
+	        this.year = source["year"];
+	        this.excluded = source["excluded"];
+	    }
+	}
+	export class RetentionPolicy {
+	    notesDays: number;
+	    detailDays: number;
+	    auditDays: number;
+	    trashDays: number;
+	    archive: boolean;
+	    archiveYears: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RetentionPolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.notesDays = source["notesDays"];
+	        this.detailDays = source["detailDays"];
+	        this.auditDays = source["auditDays"];
+	        this.trashDays = source["trashDays"];
+	        this.archive = source["archive"];
+	        this.archiveYears = source["archiveYears"];
+	    }
+	}
+	export class ReviewHabit {
+	    taskId: string;
+	    taskName: string;
+	    done: number;
+	    target: number;
+	    progress: number;
+	    decision?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewHabit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.done = source["done"];
+	        this.target = source["target"];
+	        this.progress = source["progress"];
+	        this.decision = source["decision"];
+	    }
+	}
+	export class WeeklyReview {
+	    weekStart: string;
+	    completedAt?: string;
+	    average: number;
+	    best: string[];
+	    struggling: ReviewHabit[];
+	    reflection?: string;
+	    intentions: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WeeklyReview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.weekStart = source["weekStart"];
+	        this.completedAt = source["completedAt"];
+	        this.average = source["average"];
+	        this.best = source["best"];
+	        this.struggling = this.convertValues(source["struggling"], ReviewHabit);
+	        this.reflection = source["reflection"];
+	        this.intentions = source["intentions"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScoringConfig {
+	    priorityWeights?: Record<number, number>;
+	    taskWeights?: Record<string, number>;
+	    perfectDayBonus?: number;
+	    avoidPenalty?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoringConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.priorityWeights = source["priorityWeights"];
+	        this.taskWeights = source["taskWeights"];
+	        this.perfectDayBonus = source["perfectDayBonus"];
+	        this.avoidPenalty = source["avoidPenalty"];
+	    }
+	}
+	export class QuarantinedEntry {
+	    date: string;
+	    taskId?: string;
+	    raw: number[];
+	    reason: string;
+	    found: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new QuarantinedEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.taskId = source["taskId"];
+	        this.raw = source["raw"];
+	        this.reason = source["reason"];
+	        this.found = source["found"];
+	    }
+	}
+	export class TaskGroup {
+	    id: string;
+	    name: string;
+	    order: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.order = source["order"];
+	    }
+	}
+	export class SecondaryBackupSettings {
+	    dir: string;
+	    salt: string;
+	    key?: string;
+	    lastSuccess?: string;
+	    lastError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SecondaryBackupSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dir = source["dir"];
+	        this.salt = source["salt"];
+	        this.key = source["key"];
+	        this.lastSuccess = source["lastSuccess"];
+	        this.lastError = source["lastError"];
+	    }
+	}
+	export class PlannerData {
+	    version?: number;
+	    templates: TaskTemplate[];
+	    days: Record<string, any>;
+	    exportPath?: string;
+	    exportHistory?: Record<string, string>;
+	    seenChangesVersion?: string;
+	    optIns?: Record<string, boolean>;
+	    timers?: Record<string, string>;
+	    secondaryBackup?: SecondaryBackupSettings;
+	    subitemDays?: Record<string, any>;
+	    annotations?: Annotation[];
+	    notifications?: Notification[];
+	    vacations?: DateRange[];
+	    signals?: Record<string, any>;
+	    records?: Record<string, PersonalRecord>;
+	    groups?: TaskGroup[];
+	    gapHandledThrough?: string;
+	    remindersSent?: Record<string, string>;
+	    measurements?: Record<string, any>;
+	    quarantine?: QuarantinedEntry[];
+	    skipped?: Record<string, any>;
+	    skippedDays?: Record<string, string>;
+	    focusSessions?: FocusSession[];
+	    scoring?: ScoringConfig;
+	    futureNotes?: FutureNote[];
+	    sortMode?: string;
+	    reviews?: WeeklyReview[];
+	    retention?: RetentionPolicy;
+	    specialDays?: SpecialDay[];
+	    rolloverHour?: number;
+	    editWindowDays?: number;
+	    charts?: ChartConfig[];
+	    stamps?: Record<string, any>;
+	    weekSummarySent?: string;
+	    planReminderTime?: string;
+	    planReminderSent?: string;
+	    dayProfiles?: DayProfile[];
+	    profileDates?: Record<string, string>;
+	    lifetimeGoals?: LifetimeGoal[];
+	    archivedYears?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PlannerData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.templates = this.convertValues(source["templates"], TaskTemplate);
+	        this.days = source["days"];
+	        this.exportPath = source["exportPath"];
+	        this.exportHistory = source["exportHistory"];
+	        this.seenChangesVersion = source["seenChangesVersion"];
+	        this.optIns = source["optIns"];
+	        this.timers = source["timers"];
+	        this.secondaryBackup = this.convertValues(source["secondaryBackup"], SecondaryBackupSettings);
+	        this.subitemDays = source["subitemDays"];
+	        this.annotations = this.convertValues(source["annotations"], Annotation);
+	        this.notifications = this.convertValues(source["notifications"], Notification);
+	        this.vacations = this.convertValues(source["vacations"], DateRange);
+	        this.signals = source["signals"];
+	        this.records = this.convertValues(source["records"], PersonalRecord, true);
+	        this.groups = this.convertValues(source["groups"], TaskGroup);
+	        this.gapHandledThrough = source["gapHandledThrough"];
+	        this.remindersSent = source["remindersSent"];
+	        this.measurements = source["measurements"];
+	        this.quarantine = this.convertValues(source["quarantine"], QuarantinedEntry);
+	        this.skipped = source["skipped"];
+	        this.skippedDays = source["skippedDays"];
+	        this.focusSessions = this.convertValues(source["focusSessions"], FocusSession);
+	        this.scoring = this.convertValues(source["scoring"], ScoringConfig);
+	        this.futureNotes = this.convertValues(source["futureNotes"], FutureNote);
+	        this.sortMode = source["sortMode"];
+	        this.reviews = this.convertValues(source["reviews"], WeeklyReview);
+	        this.retention = this.convertValues(source["retention"], RetentionPolicy);
+	        this.specialDays = this.convertValues(source["specialDays"], SpecialDay);
+	        this.rolloverHour = source["rolloverHour"];
+	        this.editWindowDays = source["editWindowDays"];
+	        this.charts = this.convertValues(source["charts"], ChartConfig);
+	        this.stamps = source["stamps"];
+	        this.weekSummarySent = source["weekSummarySent"];
+	        this.planReminderTime = source["planReminderTime"];
+	        this.planReminderSent = source["planReminderSent"];
+	        this.dayProfiles = this.convertValues(source["dayProfiles"], DayProfile);
+	        this.profileDates = source["profileDates"];
+	        this.lifetimeGoals = this.convertValues(source["lifetimeGoals"], LifetimeGoal);
+	        this.archivedYears = source["archivedYears"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PresetTask {
+	    name: string;
+	    type: string;
+	    unit?: string;
+	    target?: number;
+	    timesPerWeek?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PresetTask(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.unit = source["unit"];
+	        this.target = source["target"];
+	        this.timesPerWeek = source["timesPerWeek"];
+	    }
+	}
+	export class Preset {
+	    id: string;
+	    name: string;
+	    description: string;
+	    tasks: PresetTask[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Preset(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.tasks = this.convertValues(source["tasks"], PresetTask);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class QueryResult {
+	    columns: string[];
+	    rows: string[][];
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new QueryResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.columns = source["columns"];
+	        this.rows = source["rows"];
+	        this.truncated = source["truncated"];
+	    }
+	}
+	export class RecordBreak {
+	    taskId: string;
+	    taskName: string;
+	    kind: string;
+	    previous: number;
+	    value: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecordBreak(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.kind = source["kind"];
+	        this.previous = source["previous"];
+	        this.value = source["value"];
+	    }
+	}
+	export class ReportChange {
+	    taskId: string;
+	    action: string;
+	    onDays?: string;
+	    timesPerWeek?: number;
+	    dayOfMonth?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReportChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.action = source["action"];
+	        this.onDays = source["onDays"];
+	        this.timesPerWeek = source["timesPerWeek"];
+	        this.dayOfMonth = source["dayOfMonth"];
+	    }
+	}
+	export class ReportFigures {
+	    average: number;
+	    scoredDays: number;
+	    perfectDays: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReportFigures(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.average = source["average"];
+	        this.scoredDays = source["scoredDays"];
+	        this.perfectDays = source["perfectDays"];
+	    }
+	}
+	
+	
+	export class ReviewState {
+	    weekStart: string;
+	    completedAt?: string;
+	    average: number;
+	    best: string[];
+	    struggling: ReviewHabit[];
+	    reflection?: string;
+	    intentions: string[];
+	    steps: string[];
+	    next: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.weekStart = source["weekStart"];
+	        this.completedAt = source["completedAt"];
+	        this.average = source["average"];
+	        this.best = source["best"];
+	        this.struggling = this.convertValues(source["struggling"], ReviewHabit);
+	        this.reflection = source["reflection"];
+	        this.intentions = source["intentions"];
+	        this.steps = source["steps"];
+	        this.next = source["next"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Revision {
+	    commit: string;
+	    time: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Revision(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.commit = source["commit"];
+	        this.time = source["time"];
+	        this.message = source["message"];
+	    }
+	}
+	export class SaveStatus {
+	    state: string;
+	    lastSaved?: string;
+	    pending: number;
+	    lastError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SaveStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.state = source["state"];
+	        this.lastSaved = source["lastSaved"];
+	        this.pending = source["pending"];
+	        this.lastError = source["lastError"];
+	    }
+	}
+	
+	
+	export class SimulatedWeek {
+	    weekStart: string;
+	    before: number;
+	    after: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SimulatedWeek(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.weekStart = source["weekStart"];
+	        this.before = source["before"];
+	        this.after = source["after"];
+	    }
+	}
+	export class SimulatedReport {
+	    from: string;
+	    to: string;
+	    before: ReportFigures;
+	    after: ReportFigures;
+	    delta: number;
+	    weeks: SimulatedWeek[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SimulatedReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.before = this.convertValues(source["before"], ReportFigures);
+	        this.after = this.convertValues(source["after"], ReportFigures);
+	        this.delta = source["delta"];
+	        this.weeks = this.convertValues(source["weeks"], SimulatedWeek);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class SpecialDayOccurrence {
+	    id: string;
+	    name: string;
+	    kind: string;
+	    date: string;
+	    year?: number;
+	    excluded: boolean;
+	    on: string;
+	    years?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SpecialDayOccurrence(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.date = source["date"];
+	        this.year = source["year"];
+	        this.excluded = source["excluded"];
+	        this.on = source["on"];
+	        this.years = source["years"];
+	    }
+	}
+	export class StreakChange {
+	    taskName: string;
+	    days: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StreakChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskName = source["taskName"];
+	        this.days = source["days"];
+	    }
+	}
+	export class StreakProgress {
+	    taskId: string;
+	    taskName: string;
+	    current: number;
+	    goal: number;
+	    remaining: number;
+	    reached: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new StreakProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.current = source["current"];
+	        this.goal = source["goal"];
+	        this.remaining = source["remaining"];
+	        this.reached = source["reached"];
+	    }
+	}
+	
+	export class SyncResult {
+	    pulled: number;
+	    pushed: boolean;
+	    conflict: boolean;
+	    backupPath: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pulled = source["pulled"];
+	        this.pushed = source["pushed"];
+	        this.conflict = source["conflict"];
+	        this.backupPath = source["backupPath"];
+	    }
+	}
+	export class SyncStatus {
+	    enabled: boolean;
+	    provider?: string;
+	    url?: string;
+	    username?: string;
+	    lastSync?: string;
+	    lastError?: string;
+	    localChange: boolean;
+	    syncing: boolean;
+	    failures: number;
+	    nextSync?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.provider = source["provider"];
+	        this.url = source["url"];
+	        this.username = source["username"];
+	        this.lastSync = source["lastSync"];
+	        this.lastError = source["lastError"];
+	        this.localChange = source["localChange"];
+	        this.syncing = source["syncing"];
+	        this.failures = source["failures"];
+	        this.nextSync = source["nextSync"];
+	    }
+	}
+	
+	export class TaperStep {
+	    weekStart: string;
+	    target: number;
+	    days: number;
+	    daysOnTarget: number;
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaperStep(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.weekStart = source["weekStart"];
+	        this.target = source["target"];
+	        this.days = source["days"];
+	        this.daysOnTarget = source["daysOnTarget"];
+	        this.total = source["total"];
+	    }
+	}
+	export class TaperProgress {
+	    taskId: string;
+	    taper: Taper;
+	    currentWeek: number;
+	    currentTarget: number;
+	    steps: TaperStep[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TaperProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.taper = this.convertValues(source["taper"], Taper);
+	        this.currentWeek = source["currentWeek"];
+	        this.currentTarget = source["currentTarget"];
+	        this.steps = this.convertValues(source["steps"], TaperStep);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TaskChange {
+	    taskName: string;
+	    before: number;
+	    after: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskName = source["taskName"];
+	        this.before = source["before"];
+	        this.after = source["after"];
+	    }
+	}
+	export class TaskComparison {
+	    taskId: string;
+	    taskName: string;
+	    status: string;
+	    progressA: number;
+	    progressB: number;
+	    totalA: number;
+	    totalB: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.taskName = source["taskName"];
+	        this.status = source["status"];
+	        this.progressA = source["progressA"];
+	        this.progressB = source["progressB"];
+	        this.totalA = source["totalA"];
+	        this.totalB = source["totalB"];
+	    }
+	}
+	
+	
+	
+	
+	export class TierDistribution {
+	    taskId: string;
+	    month: string;
+	    tiers: number[];
+	    names: string[];
+	    counts: number[];
+	    days: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TierDistribution(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.month = source["month"];
+	        this.tiers = source["tiers"];
+	        this.names = source["names"];
+	        this.counts = source["counts"];
+	        this.days = source["days"];
+	    }
+	}
+	export class TodayView {
+	    date: string;
+	    tasks: TaskTemplate[];
+	    values: Record<string, number>;
+	    provisional: Record<string, boolean>;
+	    notes: FutureNote[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TodayView(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.tasks = this.convertValues(source["tasks"], TaskTemplate);
+	        this.values = source["values"];
+	        this.provisional = source["provisional"];
+	        this.notes = this.convertValues(source["notes"], FutureNote);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TrashItem {
+	    task: TaskTemplate;
+	    deletedAt: string;
+	    purgeOn?: string;
+	    values: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrashItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.task = this.convertValues(source["task"], TaskTemplate);
+	        this.deletedAt = source["deletedAt"];
+	        this.purgeOn = source["purgeOn"];
+	        this.values = source["values"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UndoState {
+	    canUndo: boolean;
+	    canRedo: boolean;
+	    undoLabel?: string;
+	    redoLabel?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UndoState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.canUndo = source["canUndo"];
+	        this.canRedo = source["canRedo"];
+	        this.undoLabel = source["undoLabel"];
+	        this.redoLabel = source["redoLabel"];
+	    }
+	}
+	export class UndoChange {
+	    action: string;
+	    label: string;
+	    dates: string[];
+	    taskIds: string[];
+	    state: UndoState;
+	
+	    static createFrom(source: any = {}) {
+	        return new UndoChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	        this.label = source["label"];
+	        this.dates = source["dates"];
+	        this.taskIds = source["taskIds"];
+	        this.state = this.convertValues(source["state"], UndoState);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ValueBucket {
+	    from: number;
+	    to: number;
+	    label: string;
+	    days: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ValueBucket(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.label = source["label"];
+	        this.days = source["days"];
+	    }
+	}
+	export class ValueDistribution {
+	    taskId: string;
+	    unit?: string;
+	    from: string;
+	    to: string;
+	    buckets: ValueBucket[];
+	    days: number;
+	    mean: number;
+	    median: number;
+	    max: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ValueDistribution(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.unit = source["unit"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.buckets = this.convertValues(source["buckets"], ValueBucket);
+	        this.days = source["days"];
+	        this.mean = source["mean"];
+	        this.median = source["median"];
+	        this.max = source["max"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ValueStamp {
+	    value: number;
+	    at: number;
+	    device: string;
+	    deleted?: boolean;
+	    up?: Record<string, number>;
+	    down?: Record<string, number>;
+	
+	    static createFrom(source: any = {}) {
+	        return new ValueStamp(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.at = source["at"];
+	        this.device = source["device"];
+	        this.deleted = source["deleted"];
+	        this.up = source["up"];
+	        this.down = source["down"];
+	    }
+	}
+	export class WeekComparison {
+	    weekA: string;
+	    weekB: string;
+	    averageA: number;
+	    averageB: number;
+	    tasks: TaskComparison[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WeekComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.weekA = source["weekA"];
+	        this.weekB = source["weekB"];
+	        this.averageA = source["averageA"];
+	        this.averageB = source["averageB"];
+	        this.tasks = this.convertValues(source["tasks"], TaskComparison);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WeekTaskDetail {
+	    taskId: string;
+	    total: number;
+	    done: number;
+	    target: number;
+	    progress: number;
+	    applicable: Record<string, boolean>;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeekTaskDetail(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.taskId = source["taskId"];
+	        this.total = source["total"];
+	        this.done = source["done"];
+	        this.target = source["target"];
+	        this.progress = source["progress"];
+	        this.applicable = source["applicable"];
+	    }
+	}
+	export class WeekDetail {
+	    days: Record<string, any>;
+	    tasks: WeekTaskDetail[];
+	    frequency: FrequencyProgress[];
+	    excludedDays: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WeekDetail(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.days = source["days"];
+	        this.tasks = this.convertValues(source["tasks"], WeekTaskDetail);
+	        this.frequency = this.convertValues(source["frequency"], FrequencyProgress);
+	        this.excludedDays = source["excludedDays"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WeekDiff {
+	    weekStart: string;
+	    average: number;
+	    previousAverage: number;
+	    streaksGained: StreakChange[];
+	    streaksLost: StreakChange[];
+	    improvement?: TaskChange;
+	    regression?: TaskChange;
+	    title: string;
+	    text: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeekDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.weekStart = source["weekStart"];
+	        this.average = source["average"];
+	        this.previousAverage = source["previousAverage"];
+	        this.streaksGained = this.convertValues(source["streaksGained"], StreakChange);
+	        this.streaksLost = this.convertValues(source["streaksLost"], StreakChange);
+	        this.improvement = this.convertValues(source["improvement"], TaskChange);
+	        this.regression = this.convertValues(source["regression"], TaskChange);
+	        this.title = source["title"];
+	        this.text = source["text"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WeekSummaryTargets {
+	    webhook?: string;
+	    email?: EmailTarget;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeekSummaryTargets(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.webhook = source["webhook"];
+	        this.email = this.convertValues(source["email"], EmailTarget);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class WipePreview {
+	    days: number;
+	    values: number;
+	    measurements: number;
+	    skips: number;
+	    sessions: number;
+	    tasks: number;
+	    backupPath?: string;
+	    confirmToken?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WipePreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.days = source["days"];
+	        this.values = source["values"];
+	        this.measurements = source["measurements"];
+	        this.skips = source["skips"];
+	        this.sessions = source["sessions"];
+	        this.tasks = source["tasks"];
+	        this.backupPath = source["backupPath"];
+	        this.confirmToken = source["confirmToken"];
 	    }
 	}
 
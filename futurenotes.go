@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FutureNote is a note written now and revealed on a later date
+type FutureNote struct {
+	ID        string `json:"id"`
+	Date      string `json:"date"` // Day the note is revealed
+	Text      string `json:"text"`
+	CreatedAt string `json:"createdAt"`
+	Revealed  bool   `json:"revealed,omitempty"` // Notification already sent
+}
+
+// WriteFutureNote stores a note to be revealed on a future date
+func (a *App) WriteFutureNote(date string, text string) (FutureNote, error) {
+	today := time.Now().Format("2006-01-02")
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return FutureNote{}, errors.New("invalid date")
+	}
+	if date <= today {
+		return FutureNote{}, errors.New("date must be in the future")
+	}
+	if text == "" {
+		return FutureNote{}, errors.New("note text is required")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	note := FutureNote{ID: uuid.New().String(), Date: date, Text: text, CreatedAt: today}
+	a.data.FutureNotes = append(a.data.FutureNotes, note)
+	a.audit("WriteFutureNote", date, "", nil, note.ID)
+	return note, a.saveDataLocked()
+}
+
+// GetFutureNotes lists all notes. Notes that aren't due yet have their text
+// hidden so they stay a surprise.
+func (a *App) GetFutureNotes() []FutureNote {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	today := time.Now().Format("2006-01-02")
+	notes := make([]FutureNote, 0, len(a.data.FutureNotes))
+	for _, n := range a.data.FutureNotes {
+		if n.Date > today {
+			n.Text = ""
+		}
+		notes = append(notes, n)
+	}
+	return notes
+}
+
+// DeleteFutureNote removes a note
+func (a *App) DeleteFutureNote(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, n := range a.data.FutureNotes {
+		if n.ID == id {
+			a.data.FutureNotes = append(a.data.FutureNotes[:i], a.data.FutureNotes[i+1:]...)
+			a.audit("DeleteFutureNote", n.Date, "", n.ID, nil)
+			return a.saveDataLocked()
+		}
+	}
+	return nil
+}
+
+// futureNotesForLocked returns the notes revealed on date (must hold lock)
+func (a *App) futureNotesForLocked(date string) []FutureNote {
+	notes := []FutureNote{}
+	for _, n := range a.data.FutureNotes {
+		if n.Date == date {
+			notes = append(notes, n)
+		}
+	}
+	return notes
+}
+
+// revealDueNotes sends a notification for each note that has come due.
+// Notes whose day passed while the app was closed are revealed on the next run.
+func (a *App) revealDueNotes() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	today := time.Now().Format("2006-01-02")
+	revealed := false
+	for i, n := range a.data.FutureNotes {
+		if n.Revealed || n.Date > today {
+			continue
+		}
+		a.data.FutureNotes[i].Revealed = true
+		a.notifyLocked("future-note", "A note from "+n.CreatedAt, n.Text)
+		revealed = true
+	}
+
+	if revealed {
+		if err := a.saveDataLocked(); err != nil {
+			println("Error saving future notes:", err.Error())
+		}
+	}
+}
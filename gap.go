@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// gapMinDays is the number of untracked days before a gap is reported
+const gapMinDays = 3
+
+// Gap choices accepted by ApplyGapChoice
+const (
+	gapChoiceSkip     = "skip"     // Exclude the gap from stats like a vacation
+	gapChoiceBackfill = "backfill" // User fills the days in through the backfill wizard
+	gapChoiceFresh    = "fresh"    // Leave the gap as missed days and carry on
+)
+
+// GapSummary describes a run of untracked days up to yesterday
+type GapSummary struct {
+	From        string   `json:"from"`        // First untracked date
+	To          string   `json:"to"`          // Last untracked date (yesterday)
+	Days        int      `json:"days"`        // Untracked days, not counting vacations
+	LastTracked string   `json:"lastTracked"` // Last date with recorded data
+	Choices     []string `json:"choices"`
+}
+
+// GetGapSummary reports the untracked days since the app was last used, or
+// nil when there's no gap worth asking about. Intended to be called on startup.
+func (a *App) GetGapSummary() *GapSummary {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.gapSummaryLocked()
+}
+
+// gapSummaryLocked finds the gap between the last tracked (or acknowledged)
+// date and yesterday (must hold lock)
+func (a *App) gapSummaryLocked() *GapSummary {
+	lastTracked := ""
+	for date := range a.data.Days {
+		if date > lastTracked && len(a.data.Days[date]) > 0 {
+			lastTracked = date
+		}
+	}
+	if lastTracked == "" {
+		return nil
+	}
+
+	since := lastTracked
+	if a.data.GapHandledThrough > since {
+		since = a.data.GapHandledThrough
+	}
+
+	start, err := time.Parse("2006-01-02", since)
+	if err != nil {
+		return nil
+	}
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	yesterday := today.AddDate(0, 0, -1)
+
+	days := 0
+	for d := start.AddDate(0, 0, 1); !d.After(yesterday); d = d.AddDate(0, 0, 1) {
+		if !a.dayExcludedLocked(d.Format("2006-01-02")) {
+			days++
+		}
+	}
+	if days < gapMinDays {
+		return nil
+	}
+
+	return &GapSummary{
+		From:        start.AddDate(0, 0, 1).Format("2006-01-02"),
+		To:          yesterday.Format("2006-01-02"),
+		Days:        days,
+		LastTracked: lastTracked,
+		Choices:     []string{gapChoiceSkip, gapChoiceBackfill, gapChoiceFresh},
+	}
+}
+
+// ApplyGapChoice resolves the current gap: "skip" excludes it from stats,
+// "backfill" and "fresh" keep the days as they are. In every case the gap
+// is not reported again.
+func (a *App) ApplyGapChoice(choice string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	gap := a.gapSummaryLocked()
+	if gap == nil {
+		return nil
+	}
+
+	switch choice {
+	case gapChoiceSkip:
+		a.data.Vacations = append(a.data.Vacations, DateRange{From: gap.From, To: gap.To})
+	case gapChoiceBackfill, gapChoiceFresh:
+	default:
+		return errors.New("unknown gap choice")
+	}
+
+	a.data.GapHandledThrough = gap.To
+	a.audit("ApplyGapChoice", gap.From, "", nil, choice)
+	return a.saveDataLocked()
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// historyFile is the data file's name inside the history repository
+const historyFile = "data.json"
+
+// historyQueueSize is how many saves can wait to be committed; when
+// commits fall behind, the next one that fits carries the changes
+const historyQueueSize = 64
+
+// commitPattern matches an abbreviated or full commit hash
+var commitPattern = regexp.MustCompile(`^[0-9a-f]{4,40}$`)
+
+// Revision is one committed version of the data file
+type Revision struct {
+	Commit  string `json:"commit"`
+	Time    string `json:"time"` // RFC3339
+	Message string `json:"message"`
+}
+
+// historyCommit is a saved data file waiting to be committed
+type historyCommit struct {
+	content []byte
+	message string
+}
+
+// historyDir returns the history repository. Like backups, it sits with
+// the local settings rather than in a data folder that may be shared.
+func (a *App) historyDir() string {
+	return filepath.Join(filepath.Dir(a.settingsPath), "history")
+}
+
+// git runs a git command in the history repository and returns its output
+func git(dir string, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir, "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=PLAN", "GIT_AUTHOR_EMAIL=plan@localhost",
+		"GIT_COMMITTER_NAME=PLAN", "GIT_COMMITTER_EMAIL=plan@localhost")
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return out, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return out, err
+	}
+	return out, nil
+}
+
+// IsGitHistoryEnabled reports whether saves are committed to the history repository
+func (a *App) IsGitHistoryEnabled() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.settings.GitHistory
+}
+
+// SetGitHistory turns committing every save into a local git repository
+// in ~/.plan/history on or off. Turning it off keeps the repository. Needs
+// git installed.
+func (a *App) SetGitHistory(enabled bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if enabled == a.settings.GitHistory {
+		return nil
+	}
+	if enabled {
+		if _, err := exec.LookPath("git"); err != nil {
+			return errors.New("git is not installed")
+		}
+		dir := a.historyDir()
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+			if _, err := git(dir, nil, "init", "-q"); err != nil {
+				return err
+			}
+		}
+	}
+
+	a.audit("SetGitHistory", "", "", a.settings.GitHistory, enabled)
+	a.settings.GitHistory = enabled
+	if err := a.saveSettingsLocked(); err != nil {
+		return err
+	}
+	if enabled {
+		// Start the history with the data as it is now
+		a.recordHistoryLocked(nil)
+	}
+	return nil
+}
+
+// recordHistoryLocked queues the saved data file to be committed, with a
+// message naming the days that changed (must hold lock)
+func (a *App) recordHistoryLocked(changes []dayChange) {
+	if !a.settings.GitHistory || a.historyQueue == nil {
+		return
+	}
+	content, err := a.store.snapshot()
+	if err != nil {
+		println("Error reading data for history:", err.Error())
+		return
+	}
+
+	message := "Save"
+	dates := []string{}
+	seen := make(map[string]bool)
+	for _, c := range changes {
+		if !seen[c.Date] {
+			seen[c.Date] = true
+			dates = append(dates, c.Date)
+		}
+	}
+	if len(dates) > 0 {
+		sort.Strings(dates)
+		if len(dates) > 3 {
+			dates = append(dates[:3], fmt.Sprintf("%d more days", len(dates)-3))
+		}
+		values := "values"
+		if len(changes) == 1 {
+			values = "value"
+		}
+		message = fmt.Sprintf("Save %s (%d %s)", strings.Join(dates, ", "), len(changes), values)
+	}
+
+	select {
+	case a.historyQueue <- historyCommit{content: content, message: message}:
+	default:
+	}
+}
+
+// runGitHistory commits queued saves in order until the app exits
+func (a *App) runGitHistory() {
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case c := <-a.historyQueue:
+			a.mu.RLock()
+			dir := a.historyDir()
+			a.mu.RUnlock()
+
+			a.historyMu.Lock()
+			// Saves queued before encryption was turned on stay out
+			if !a.historySealed || isEncrypted(c.content) {
+				if err := commitHistory(dir, c); err != nil {
+					println("Error committing history:", err.Error())
+				}
+			}
+			a.historyMu.Unlock()
+		}
+	}
+}
+
+// resetHistoryLocked deletes the history repository when encryption is
+// turned on, since its revisions hold the data in plain text, and starts it
+// again from the sealed data, telling the user. Plain saves still queued
+// are skipped from now on (must hold lock).
+func (a *App) resetHistoryLocked() error {
+	a.historyMu.Lock()
+	a.historySealed = true
+	dir := a.historyDir()
+	_, statErr := os.Stat(dir)
+	err := os.RemoveAll(dir)
+	if err == nil && a.settings.GitHistory {
+		if err = os.MkdirAll(dir, 0755); err == nil {
+			_, err = git(dir, nil, "init", "-q")
+		}
+	}
+	a.historyMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if statErr == nil {
+		a.notifyLocked("history", "Git history was cleared",
+			"Earlier revisions kept your data in plain text, so they were deleted. History starts again from the encrypted data.")
+	}
+	// Saving keeps the notification and commits the sealed data
+	return a.saveDataLocked()
+}
+
+// commitHistory writes a saved data file into the repository and commits
+// it, unless it's unchanged
+func commitHistory(dir string, c historyCommit) error {
+	if err := os.WriteFile(filepath.Join(dir, historyFile), c.content, 0644); err != nil {
+		return err
+	}
+	if _, err := git(dir, nil, "add", historyFile); err != nil {
+		return err
+	}
+	if _, err := git(dir, nil, "diff", "--cached", "--quiet"); err == nil {
+		return nil
+	}
+	_, err := git(dir, nil, "commit", "-q", "-m", c.message)
+	return err
+}
+
+// ListRevisions returns the committed versions of the data file, newest first
+func (a *App) ListRevisions() ([]Revision, error) {
+	a.mu.RLock()
+	dir := a.historyDir()
+	a.mu.RUnlock()
+
+	revisions := []Revision{}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+		return revisions, nil
+	}
+	out, err := git(dir, nil, "log", "--format=%H%x1f%aI%x1f%s", "--", historyFile)
+	if err != nil {
+		if strings.Contains(err.Error(), "does not have any commits") {
+			return revisions, nil
+		}
+		return nil, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) == 3 {
+			revisions = append(revisions, Revision{Commit: fields[0], Time: fields[1], Message: fields[2]})
+		}
+	}
+	return revisions, nil
+}
+
+// RestoreRevision replaces the current data with a committed version.
+// The current data is backed up first, and the restore is committed too,
+// so it can be undone the same way.
+func (a *App) RestoreRevision(commit string) error {
+	if !commitPattern.MatchString(commit) {
+		return errors.New("invalid revision")
+	}
+
+	a.mu.RLock()
+	dir := a.historyDir()
+	a.mu.RUnlock()
+
+	content, err := git(dir, nil, "show", commit+":"+historyFile)
+	if err != nil {
+		return errors.New("revision not found")
+	}
+	return a.restoreData("RestoreRevision", commit, content, "")
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// maxRolloverHour is the latest hour a day can roll over to the next
+const maxRolloverHour = 6
+
+// GetRolloverHour returns the hour the day rolls over; 0 means midnight
+func (a *App) GetRolloverHour() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.data.RolloverHour
+}
+
+// SetRolloverHour sets a late-night grace period: check-offs for today made
+// before hour (e.g. 3 for 3am) count for the previous day. 0 turns it off.
+func (a *App) SetRolloverHour(hour int) error {
+	if hour < 0 || hour > maxRolloverHour {
+		return errors.New("rollover hour must be between 0 and 6")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.audit("SetRolloverHour", "", "", a.data.RolloverHour, hour)
+	a.data.RolloverHour = hour
+	return a.saveDataLocked()
+}
+
+// checkInDateLocked returns the day a check-off made at now belongs to:
+// the previous calendar day during the grace period (must hold lock)
+func (a *App) checkInDateLocked(now time.Time) string {
+	if now.Hour() < a.data.RolloverHour {
+		now = now.AddDate(0, 0, -1)
+	}
+	return now.Format("2006-01-02")
+}
+
+// checkInDate returns the day a check-off made now belongs to
+func (a *App) checkInDate() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.checkInDateLocked(time.Now())
+}
+
+// attributeDateLocked moves a save for the calendar date of now back to the
+// previous day during the grace period; other dates are kept (must hold lock)
+func (a *App) attributeDateLocked(date string, now time.Time) string {
+	if date == now.Format("2006-01-02") {
+		return a.checkInDateLocked(now)
+	}
+	return date
+}
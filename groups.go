@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// TaskGroup is a named section of tasks ("Morning", "Work", "Evening")
+type TaskGroup struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Order int    `json:"order"`
+}
+
+// GetGroups returns all groups in display order
+func (a *App) GetGroups() []TaskGroup {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	groups := append([]TaskGroup{}, a.data.Groups...)
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Order < groups[j].Order
+	})
+	return groups
+}
+
+// AddGroup creates a new group at the end of the list
+func (a *App) AddGroup(name string) (TaskGroup, error) {
+	if name == "" {
+		return TaskGroup{}, errors.New("group name is required")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	maxOrder := -1
+	for _, g := range a.data.Groups {
+		if g.Order > maxOrder {
+			maxOrder = g.Order
+		}
+	}
+
+	group := TaskGroup{ID: uuid.New().String(), Name: name, Order: maxOrder + 1}
+	a.data.Groups = append(a.data.Groups, group)
+	a.audit("AddGroup", "", "", nil, name)
+	return group, a.saveDataLocked()
+}
+
+// RenameGroup changes a group's name
+func (a *App) RenameGroup(id string, name string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, g := range a.data.Groups {
+		if g.ID == id {
+			a.data.Groups[i].Name = name
+			a.audit("RenameGroup", "", "", g.Name, name)
+			return a.saveDataLocked()
+		}
+	}
+	return nil
+}
+
+// DeleteGroup removes a group; its tasks become ungrouped
+func (a *App) DeleteGroup(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, g := range a.data.Groups {
+		if g.ID != id {
+			continue
+		}
+		a.data.Groups = append(a.data.Groups[:i], a.data.Groups[i+1:]...)
+		for j, t := range a.data.Templates {
+			if t.Group == id {
+				a.data.Templates[j].Group = ""
+			}
+		}
+		a.audit("DeleteGroup", "", "", g.Name, nil)
+		return a.saveDataLocked()
+	}
+	return nil
+}
+
+// ReorderGroups updates the order of groups
+func (a *App) ReorderGroups(ids []string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	orderMap := make(map[string]int)
+	for i, id := range ids {
+		orderMap[id] = i
+	}
+
+	for i, g := range a.data.Groups {
+		if order, ok := orderMap[g.ID]; ok {
+			a.data.Groups[i].Order = order
+		}
+	}
+
+	a.audit("ReorderGroups", "", "", nil, ids)
+	return a.saveDataLocked()
+}
+
+// MoveTaskToGroup moves a task into a group ("" for ungrouped), placing it last
+func (a *App) MoveTaskToGroup(taskID string, groupID string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if groupID != "" {
+		found := false
+		for _, g := range a.data.Groups {
+			if g.ID == groupID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New("group not found")
+		}
+	}
+
+	maxOrder := -1
+	for _, t := range a.data.Templates {
+		if t.Group == groupID && t.Order > maxOrder {
+			maxOrder = t.Order
+		}
+	}
+
+	for i, t := range a.data.Templates {
+		if t.ID == taskID {
+			a.data.Templates[i].Group = groupID
+			a.data.Templates[i].Order = maxOrder + 1
+			a.audit("MoveTaskToGroup", "", taskID, t.Group, groupID)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("task not found")
+}
+
+// sortTasksLocked orders tasks by group, then by their order within the
+// group, highest priority first in priority sort mode. Ungrouped tasks come
+// first (must hold lock).
+func (a *App) sortTasksLocked(tasks []TaskTemplate) {
+	groupOrder := make(map[string]int)
+	for _, g := range a.data.Groups {
+		groupOrder[g.ID] = g.Order
+	}
+
+	rank := func(t TaskTemplate) int {
+		if order, ok := groupOrder[t.Group]; ok {
+			return order
+		}
+		return -1
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		ri, rj := rank(tasks[i]), rank(tasks[j])
+		if ri != rj {
+			return ri < rj
+		}
+		if a.data.SortMode == sortModePriority && tasks[i].Priority != tasks[j].Priority {
+			return tasks[i].Priority > tasks[j].Priority
+		}
+		return tasks[i].Order < tasks[j].Order
+	})
+}
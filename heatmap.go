@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// Ways GetHeatmap can scale intensity
+const (
+	heatmapAbsolute        = "absolute"        // By score: 100% is the darkest shade
+	heatmapPercentile      = "percentile"      // By rank among the range's days
+	heatmapMonthPercentile = "monthPercentile" // By rank among the same month's days
+)
+
+// Bounds on how many shades a heatmap has, counting the empty one
+const (
+	defaultHeatmapLevels = 5
+	minHeatmapLevels     = 2
+	maxHeatmapLevels     = 10
+)
+
+// HeatmapDay is one cell of a heatmap
+type HeatmapDay struct {
+	Date      string  `json:"date"`
+	Score     float64 `json:"score"`
+	Intensity float64 `json:"intensity"` // 0 to 1
+	Level     int     `json:"level"`     // 0 (empty) to Levels-1
+	Excluded  bool    `json:"excluded,omitempty"`
+}
+
+// Heatmap is every scored day of a range, shaded by level
+type Heatmap struct {
+	From   string       `json:"from"`
+	To     string       `json:"to"`
+	Scale  string       `json:"scale"`
+	Levels int          `json:"levels"`
+	Days   []HeatmapDay `json:"days"`
+	// Legend is the lowest score reaching each level from 1 up, for the
+	// whole range; with monthPercentile scaling there's one per month
+	// ("2006-01") in MonthLegends instead
+	Legend       []float64            `json:"legend,omitempty"`
+	MonthLegends map[string][]float64 `json:"monthLegends,omitempty"`
+}
+
+// GetHeatmap shades each day between from and to (inclusive) by its score.
+// scale is "absolute" (the default), "percentile" or "monthPercentile":
+// percentiles shade days by how they rank among your own days, so someone
+// averaging 60% still sees a full gradient. levels is the number of shades
+// including the empty one, 5 when 0. Excluded days are marked and don't
+// count towards percentiles; future days are left out.
+func (a *App) GetHeatmap(from string, to string, scale string, levels int) (Heatmap, error) {
+	r, err := newDateRange(from, to)
+	if err != nil {
+		return Heatmap{}, err
+	}
+	if scale == "" {
+		scale = heatmapAbsolute
+	}
+	if scale != heatmapAbsolute && scale != heatmapPercentile && scale != heatmapMonthPercentile {
+		return Heatmap{}, errors.New(`scale must be "absolute", "percentile" or "monthPercentile"`)
+	}
+	if levels == 0 {
+		levels = defaultHeatmapLevels
+	}
+	if levels < minHeatmapLevels || levels > maxHeatmapLevels {
+		return Heatmap{}, errors.New("levels must be between 2 and 10")
+	}
+
+	defer a.lockDays(r.From, r.To)()
+
+	heatmap := Heatmap{From: r.From, To: r.To, Scale: scale, Levels: levels, Days: []HeatmapDay{}}
+	groups := make(map[string][]float64) // Group -> scores above 0, for percentiles
+	today := time.Now().Format("2006-01-02")
+	start, _ := time.Parse("2006-01-02", r.From)
+	end, _ := time.Parse("2006-01-02", r.To)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		if date > today {
+			break
+		}
+		if a.dayExcludedLocked(date) {
+			heatmap.Days = append(heatmap.Days, HeatmapDay{Date: date, Excluded: true})
+			continue
+		}
+		score, ok := a.dayScoreLocked(date)
+		if !ok {
+			continue
+		}
+		heatmap.Days = append(heatmap.Days, HeatmapDay{Date: date, Score: score})
+		if score > 0 {
+			groups[heatmapGroup(scale, date)] = append(groups[heatmapGroup(scale, date)], score)
+		}
+	}
+
+	legends := make(map[string][]float64)
+	for group, scores := range groups {
+		sort.Float64s(scores)
+		legends[group] = heatmapLegend(scale, scores, levels)
+	}
+	for i, day := range heatmap.Days {
+		if day.Excluded || day.Score <= 0 {
+			continue
+		}
+		group := heatmapGroup(scale, day.Date)
+		legend := legends[group]
+		if legend == nil {
+			legend = heatmapLegend(scale, nil, levels)
+		}
+		for _, low := range legend {
+			if day.Score >= low {
+				heatmap.Days[i].Level++
+			}
+		}
+		if scale == heatmapAbsolute {
+			heatmap.Days[i].Intensity = math.Min(day.Score/100, 1)
+		} else {
+			heatmap.Days[i].Intensity = percentileRank(groups[group], day.Score)
+		}
+	}
+
+	if scale == heatmapMonthPercentile {
+		heatmap.MonthLegends = legends
+	} else if legend, ok := legends[""]; ok {
+		heatmap.Legend = legend
+	} else {
+		heatmap.Legend = heatmapLegend(scale, nil, levels)
+	}
+	return heatmap, nil
+}
+
+// heatmapGroup returns which days a day is ranked among: its month with
+// monthPercentile scaling, otherwise the whole range ("")
+func heatmapGroup(scale string, date string) string {
+	if scale == heatmapMonthPercentile {
+		return date[:len("2006-01")]
+	}
+	return ""
+}
+
+// heatmapLegend returns the lowest score of each level above 0; any score
+// above 0 reaches level 1. Absolute levels split 0–100% evenly; percentile
+// levels split the sorted scores above 0 into equal shares.
+func heatmapLegend(scale string, sorted []float64, levels int) []float64 {
+	legend := make([]float64, levels-1)
+	for k := range legend {
+		share := float64(k) / float64(levels-1)
+		if scale == heatmapAbsolute || len(sorted) == 0 {
+			legend[k] = share * 100
+		} else {
+			legend[k] = sorted[int(share*float64(len(sorted)))]
+		}
+	}
+	return legend
+}
+
+// percentileRank returns the share of sorted at or below score
+func percentileRank(sorted []float64, score float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return float64(sort.Search(len(sorted), func(i int) bool { return sorted[i] > score })) / float64(len(sorted))
+}
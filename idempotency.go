@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// idempotencyTTL is how long a key's result is remembered
+	idempotencyTTL = 24 * time.Hour
+	// maxIdempotencyKeys bounds how many keys are remembered at once
+	maxIdempotencyKeys = 1000
+)
+
+// idempotentResult is the outcome of a call made with an idempotency key
+type idempotentResult struct {
+	request string // What was asked, so a reused key can be told apart
+	value   int
+	at      time.Time
+}
+
+// idempotent runs fn once per key: a retry with the same key and request
+// gets the first result back without running fn again. Failures aren't
+// remembered, so a failed call can be retried. An empty key always runs fn.
+func (a *App) idempotent(key string, request string, fn func() (int, error)) (int, error) {
+	if key == "" {
+		return fn()
+	}
+
+	a.idempotencyMu.Lock()
+	defer a.idempotencyMu.Unlock()
+
+	now := time.Now()
+	for k, result := range a.idempotencyKeys {
+		if now.Sub(result.at) > idempotencyTTL {
+			delete(a.idempotencyKeys, k)
+		}
+	}
+
+	if result, ok := a.idempotencyKeys[key]; ok {
+		if result.request != request {
+			return 0, errors.New("idempotency key was already used for a different request")
+		}
+		return result.value, nil
+	}
+
+	value, err := fn()
+	if err != nil {
+		return value, err
+	}
+
+	if a.idempotencyKeys == nil {
+		a.idempotencyKeys = make(map[string]idempotentResult)
+	}
+	if len(a.idempotencyKeys) >= maxIdempotencyKeys {
+		oldest := ""
+		for k, result := range a.idempotencyKeys {
+			if oldest == "" || result.at.Before(a.idempotencyKeys[oldest].at) {
+				oldest = k
+			}
+		}
+		delete(a.idempotencyKeys, oldest)
+	}
+	a.idempotencyKeys[key] = idempotentResult{request: request, value: value, at: now}
+	return value, nil
+}
+
+// CheckTask records a check-off of a task on date for automations such as
+// phone shortcuts: binary and negative tasks are marked, count and duration
+// tasks are incremented by one. Retries with the same key are applied only
+// once. Returns the new value.
+func (a *App) CheckTask(date string, taskID string, key string) (int, error) {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return 0, errors.New("invalid date")
+	}
+	return a.idempotent(key, "CheckTask "+date+" "+taskID, func() (int, error) {
+		return a.checkOff("CheckTask", date, taskID, false)
+	})
+}
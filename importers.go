@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// importedHabit is one habit read from another app's export
+type importedHabit struct {
+	name        string
+	description string
+	taskType    string // "binary", "count" or "negative"
+	unit        string
+	target      int
+	archived    bool
+	values      map[string]int // date -> day value
+}
+
+// habitImporter reads the export format of one habit app
+type habitImporter struct {
+	app    string
+	detect func(path string, head []byte) bool
+	read   func(path string) ([]importedHabit, []string, error) // Habits and warnings
+}
+
+// habitImporters are tried in order when no app option is given
+var habitImporters = []habitImporter{
+	{app: "loop", detect: isLoopBackup, read: readLoopBackup},
+	{app: "loop", detect: isLoopCSVExport, read: readLoopCSVExport},
+	{app: "habitica", detect: isHabiticaExport, read: readHabiticaExport},
+	{app: "streaks", detect: isStreaksExport, read: readStreaksExport},
+}
+
+// HabitImportReport describes what ImportHabits did, or would do on a dry run
+type HabitImportReport struct {
+	DryRun      bool     `json:"dryRun"`
+	App         string   `json:"app"` // "loop", "habitica" or "streaks"
+	Habits      int      `json:"habits"`
+	From        string   `json:"from"`
+	To          string   `json:"to"`
+	NewTasks    []string `json:"newTasks"`    // Tasks created for habits with no task of the same name
+	Matched     []string `json:"matched"`     // Existing tasks the history went into
+	Values      int      `json:"values"`      // Values written
+	Overwritten int      `json:"overwritten"` // Of which replaced a different recorded value
+	Warnings    []string `json:"warnings"`
+}
+
+// ImportHabits imports the habits and history exported by another habit
+// app, so switching to PLAN doesn't mean starting over:
+//   - Loop Habit Tracker: the database backup (.db) or the CSV export (.zip)
+//   - Habitica: the user data JSON export
+//   - Streaks: the CSV or JSON export
+//
+// Habits are matched to tasks by name; the rest become new tasks that
+// start on their first recorded day. Options:
+//   - "dryRun": "true" reports what would happen without changing anything
+//   - "app": "loop", "habitica" or "streaks" when the format isn't recognized
+func (a *App) ImportHabits(path string, options map[string]string) (HabitImportReport, error) {
+	report := HabitImportReport{
+		DryRun:   options["dryRun"] == "true",
+		NewTasks: []string{},
+		Matched:  []string{},
+		Warnings: []string{},
+	}
+
+	importer, err := findHabitImporter(path, options["app"])
+	if err != nil {
+		return report, err
+	}
+	report.App = importer.app
+	habits, warnings, err := importer.read(path)
+	if err != nil {
+		return report, err
+	}
+	report.Warnings = append(report.Warnings, warnings...)
+	if len(habits) == 0 {
+		return report, errors.New("no habits found in the export")
+	}
+	report.Habits = len(habits)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !report.DryRun {
+		if _, err := a.preChangeBackupLocked("import"); err != nil {
+			return report, err
+		}
+		a.rememberLocked("ImportHabits")
+	}
+
+	byName := make(map[string]TaskTemplate)
+	for _, t := range a.data.Templates {
+		if t.DeletedAt == nil {
+			byName[strings.ToLower(t.Name)] = t
+		}
+	}
+	for _, habit := range habits {
+		a.importHabitLocked(habit, byName, &report)
+	}
+	sort.Strings(report.NewTasks)
+	sort.Strings(report.Matched)
+
+	if report.DryRun {
+		return report, nil
+	}
+	a.rebuildRecordsLocked()
+	a.audit("ImportHabits", report.From+".."+report.To, "", nil, report.App)
+	if err := a.saveDataLocked(); err != nil {
+		return report, err
+	}
+	a.emitDataChanged("")
+	return report, nil
+}
+
+// importHabitLocked writes one habit's history into the task of the same
+// name, creating the task if there is none (must hold lock)
+func (a *App) importHabitLocked(habit importedHabit, byName map[string]TaskTemplate, report *HabitImportReport) {
+	first, last := "", ""
+	for date := range habit.values {
+		if first == "" || date < first {
+			first = date
+		}
+		if date > last {
+			last = date
+		}
+	}
+	if first != "" && (report.From == "" || first < report.From) {
+		report.From = first
+	}
+	if last > report.To {
+		report.To = last
+	}
+
+	taskID := ""
+	task, exists := byName[strings.ToLower(habit.name)]
+	if exists {
+		if isAutoTask(task) || isValueTask(task) {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s: skipped, its values aren't set by hand", task.Name))
+			return
+		}
+		taskID = task.ID
+		report.Matched = append(report.Matched, task.Name)
+		if i := a.templateIndexLocked(task.ID); i >= 0 && first != "" && a.data.Templates[i].CreatedAt > first && !report.DryRun {
+			a.audit("ImportHabits", "", task.ID, a.data.Templates[i].CreatedAt, first)
+			a.data.Templates[i].CreatedAt = first
+		}
+	} else {
+		report.NewTasks = append(report.NewTasks, habit.name)
+		if !report.DryRun {
+			task = a.addTaskLocked(habit.name, habit.taskType, habit.unit)
+			created := &a.data.Templates[len(a.data.Templates)-1]
+			if first != "" {
+				created.CreatedAt = first
+			}
+			created.Description = habit.description
+			if habit.target > 0 && habit.taskType == "count" {
+				created.Target = habit.target
+			}
+			if habit.archived {
+				created.Archived = true
+				created.ArchivedAt = time.Now().Format("2006-01-02")
+				if last != "" {
+					next, _ := time.Parse("2006-01-02", last)
+					created.ArchivedAt = next.AddDate(0, 0, 1).Format("2006-01-02")
+				}
+			}
+			taskID = task.ID
+			task = *created
+			byName[strings.ToLower(habit.name)] = task
+			a.audit("ImportHabits", "", task.ID, nil, task.Name)
+		}
+	}
+
+	for date, value := range habit.values {
+		if exists && (task.Type == "" || task.Type == "binary") && value > 1 {
+			value = 1
+		}
+		report.Values++
+		if taskID == "" {
+			continue
+		}
+		if err := a.pullArchivedDayLocked(date); err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s on %s: %v", task.Name, date, err))
+			continue
+		}
+		if old, ok := a.data.Days[date][taskID]; ok && old != value {
+			report.Overwritten++
+		}
+		if report.DryRun {
+			continue
+		}
+		if a.data.Days[date] == nil {
+			a.data.Days[date] = make(DayTasks)
+		}
+		a.data.Days[date][taskID] = value
+	}
+}
+
+// findHabitImporter picks the importer for path: the named app's, or the
+// first whose format the file matches
+func findHabitImporter(path string, app string) (habitImporter, error) {
+	head := make([]byte, 4096)
+	f, err := os.Open(path)
+	if err != nil {
+		return habitImporter{}, err
+	}
+	n, _ := f.Read(head)
+	f.Close()
+	head = bytes.TrimPrefix(head[:n], []byte("\ufeff"))
+
+	known := false
+	for _, importer := range habitImporters {
+		if app != "" && importer.app != app {
+			continue
+		}
+		known = true
+		if importer.detect(path, head) {
+			return importer, nil
+		}
+	}
+	if app != "" && !known {
+		return habitImporter{}, errors.New(`app must be "loop", "habitica" or "streaks"`)
+	}
+	if app != "" {
+		return habitImporter{}, fmt.Errorf("%s is not a %s export", filepath.Base(path), app)
+	}
+	return habitImporter{}, errors.New("unrecognized export; for a spreadsheet of dates and tasks use the CSV import")
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// habiticaExport is the part of Habitica's user data JSON export that
+// holds tasks
+type habiticaExport struct {
+	Tasks struct {
+		Habits []habiticaTask `json:"habits"`
+		Dailys []habiticaTask `json:"dailys"`
+		Todos  []habiticaTask `json:"todos"`
+	} `json:"tasks"`
+}
+
+// habiticaTask is a Habitica habit, daily or to-do
+type habiticaTask struct {
+	Text    string `json:"text"`
+	Notes   string `json:"notes"`
+	Up      *bool  `json:"up"`
+	Down    *bool  `json:"down"`
+	History []struct {
+		Date       json.RawMessage `json:"date"` // Unix milliseconds, or an ISO time in older exports
+		Value      float64         `json:"value"`
+		ScoredUp   *int            `json:"scoredUp"`
+		ScoredDown *int            `json:"scoredDown"`
+		Completed  *bool           `json:"completed"`
+		IsDue      *bool           `json:"isDue"`
+	} `json:"history"`
+}
+
+// isHabiticaExport reports whether path is a Habitica user data export.
+// The tasks come after the rest of the profile, so the whole file is read.
+func isHabiticaExport(path string, head []byte) bool {
+	if !bytes.HasPrefix(bytes.TrimSpace(head), []byte("{")) {
+		return false
+	}
+	export, err := decodeHabiticaExport(path)
+	return err == nil && len(export.Tasks.Habits)+len(export.Tasks.Dailys)+len(export.Tasks.Todos) > 0
+}
+
+// decodeHabiticaExport reads the tasks of a Habitica export
+func decodeHabiticaExport(path string) (habiticaExport, error) {
+	var export habiticaExport
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return export, err
+	}
+	if err := json.Unmarshal(bytes.TrimPrefix(content, []byte("\ufeff")), &export); err != nil {
+		return export, fmt.Errorf("not a Habitica export: %v", err)
+	}
+	return export, nil
+}
+
+// readHabiticaExport maps dailies to binary tasks and habits to count
+// tasks (or negative ones for habits that can only be scored down).
+// To-dos aren't habits and are left out.
+func readHabiticaExport(path string) ([]importedHabit, []string, error) {
+	export, err := decodeHabiticaExport(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	habits := []importedHabit{}
+	warnings := []string{}
+	for _, task := range export.Tasks.Dailys {
+		habit := importedHabit{name: task.Text, description: task.Notes, taskType: "binary", values: make(map[string]int)}
+		previous := 0.0
+		for i, entry := range task.History {
+			at, ok := habiticaTime(entry.Date)
+			if !ok {
+				continue
+			}
+			// Entries are written when the day rolls over, for the day before
+			date := at.AddDate(0, 0, -1).Format("2006-01-02")
+			if entry.IsDue != nil && !*entry.IsDue {
+				previous = entry.Value
+				continue
+			}
+			switch {
+			case entry.Completed != nil && *entry.Completed:
+				habit.values[date] = 1
+			case entry.Completed != nil:
+				habit.values[date] = 0
+			case i > 0 && entry.Value > previous:
+				habit.values[date] = 1
+			case i > 0:
+				habit.values[date] = 0
+			}
+			previous = entry.Value
+		}
+		habits = append(habits, habit)
+	}
+
+	for _, task := range export.Tasks.Habits {
+		up := task.Up == nil || *task.Up
+		down := task.Down != nil && *task.Down
+		habit := importedHabit{name: task.Text, description: task.Notes, taskType: "count", values: make(map[string]int)}
+		if down && !up {
+			habit.taskType = "negative"
+		} else if down {
+			warnings = append(warnings, fmt.Sprintf("%s: only the times scored up are imported", task.Text))
+		}
+		previous := 0.0
+		for i, entry := range task.History {
+			at, ok := habiticaTime(entry.Date)
+			if !ok {
+				continue
+			}
+			date := at.Format("2006-01-02")
+			scored := 0
+			switch {
+			case habit.taskType == "negative" && entry.ScoredDown != nil:
+				scored = *entry.ScoredDown
+			case habit.taskType == "count" && entry.ScoredUp != nil:
+				scored = *entry.ScoredUp
+			case i > 0 && habit.taskType == "negative" && entry.Value < previous:
+				scored = 1
+			case i > 0 && habit.taskType == "count" && entry.Value > previous:
+				scored = 1
+			}
+			previous = entry.Value
+			habit.values[date] += scored
+		}
+		habits = append(habits, habit)
+	}
+
+	if n := len(export.Tasks.Todos); n > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d to-dos left out", n))
+	}
+	return habits, warnings, nil
+}
+
+// habiticaTime reads a history date, in milliseconds or as an ISO time
+func habiticaTime(raw json.RawMessage) (time.Time, bool) {
+	if ms, err := strconv.ParseFloat(string(raw), 64); err == nil {
+		return time.UnixMilli(int64(ms)), true
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return time.Time{}, false
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms), true
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t.Local(), err == nil
+}
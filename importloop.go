@@ -0,0 +1,298 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Loop Habit Tracker entry values. Numerical habits store the amount
+// times 1000 instead.
+const (
+	loopYesManual = 2
+	loopYesAuto   = 1 // Implied by the habit's frequency, not done that day
+	loopSkip      = 3
+)
+
+// isLoopBackup reports whether path is a Loop database backup
+func isLoopBackup(path string, head []byte) bool {
+	return bytes.HasPrefix(head, []byte("SQLite format 3\x00"))
+}
+
+// readLoopBackup reads habits and repetitions from a Loop .db backup.
+// Columns are looked up by name, since they vary between Loop versions.
+func readLoopBackup(path string) ([]importedHabit, []string, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM Habits")
+	if err != nil {
+		return nil, nil, errors.New("not a Loop Habit Tracker backup")
+	}
+	habits := []importedHabit{}
+	numerical := make(map[int64]bool)
+	index := make(map[int64]int)
+	columns, _ := rows.Columns()
+	for rows.Next() {
+		cells := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range cells {
+			pointers[i] = &cells[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		row := make(map[string]any)
+		for i, name := range columns {
+			row[strings.ToLower(name)] = cells[i]
+		}
+
+		id := sqlInt(row["id"])
+		habit := importedHabit{
+			name:        sqlString(row["name"]),
+			description: sqlString(row["description"]),
+			taskType:    "binary",
+			archived:    sqlInt(row["archived"]) != 0,
+			values:      make(map[string]int),
+		}
+		if habit.description == "" {
+			habit.description = sqlString(row["question"])
+		}
+		if sqlInt(row["type"]) == 1 {
+			numerical[id] = true
+			habit.taskType = "count"
+			habit.unit = sqlString(row["unit"])
+			habit.target = int(math.Round(sqlFloat(row["target_value"])))
+		}
+		index[id] = len(habits)
+		habits = append(habits, habit)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	reps, err := db.Query("SELECT habit, timestamp, value FROM Repetitions")
+	if err != nil {
+		return nil, nil, errors.New("not a Loop Habit Tracker backup")
+	}
+	defer reps.Close()
+	for reps.Next() {
+		var habitID, timestamp, value int64
+		if err := reps.Scan(&habitID, &timestamp, &value); err != nil {
+			return nil, nil, err
+		}
+		i, ok := index[habitID]
+		if !ok {
+			continue
+		}
+		// Loop stamps entries at UTC midnight of the day they're for
+		date := time.UnixMilli(timestamp).UTC().Format("2006-01-02")
+		if numerical[habitID] {
+			if value >= 0 {
+				habits[i].values[date] = int(math.Round(float64(value) / 1000))
+			}
+		} else if value == loopYesManual {
+			habits[i].values[date] = 1
+		} else if value != loopSkip && value != loopYesAuto {
+			habits[i].values[date] = 0
+		}
+	}
+	return habits, nil, reps.Err()
+}
+
+// isLoopCSVExport reports whether path is the zip written by Loop's
+// "Export as CSV"
+func isLoopCSVExport(path string, head []byte) bool {
+	if !bytes.HasPrefix(head, []byte("PK\x03\x04")) {
+		return false
+	}
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return false
+	}
+	defer r.Close()
+	return zipEntry(&r.Reader, "Checkmarks.csv") != nil
+}
+
+// readLoopCSVExport reads Habits.csv and the combined Checkmarks.csv from
+// a Loop CSV export. Checkmarks.csv has a Date column and one column per
+// habit, in the order of Habits.csv.
+func readLoopCSVExport(path string) ([]importedHabit, []string, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+
+	details := make(map[string]importedHabit)
+	if f := zipEntry(&r.Reader, "Habits.csv"); f != nil {
+		records, err := readZipCSV(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(records) > 0 {
+			col := make(map[string]int)
+			for i, h := range records[0] {
+				col[strings.ToLower(strings.TrimSpace(h))] = i
+			}
+			field := func(record []string, name string) string {
+				if i, ok := col[name]; ok && i < len(record) {
+					return strings.TrimSpace(record[i])
+				}
+				return ""
+			}
+			for _, record := range records[1:] {
+				habit := importedHabit{
+					name:        field(record, "name"),
+					description: field(record, "description"),
+					taskType:    "binary",
+				}
+				if habit.description == "" {
+					habit.description = field(record, "question")
+				}
+				if kind := strings.ToUpper(field(record, "type")); kind == "NUMERICAL" || kind == "1" {
+					habit.taskType = "count"
+					habit.unit = field(record, "unit")
+					target, _ := strconv.ParseFloat(field(record, "target value"), 64)
+					habit.target = int(math.Round(target))
+				}
+				archived := strings.ToLower(field(record, "archived?"))
+				habit.archived = archived == "true" || archived == "1"
+				details[habit.name] = habit
+			}
+		}
+	}
+
+	checkmarks := zipEntry(&r.Reader, "Checkmarks.csv")
+	if checkmarks == nil {
+		return nil, nil, errors.New("export is missing Checkmarks.csv")
+	}
+	records, err := readZipCSV(checkmarks)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, errors.New("Checkmarks.csv is empty")
+	}
+
+	habits := []importedHabit{}
+	for _, name := range records[0][1:] {
+		name = strings.TrimSpace(name)
+		habit, ok := details[name]
+		if !ok {
+			habit = importedHabit{name: name, taskType: "binary"}
+		}
+		habit.values = make(map[string]int)
+		habits = append(habits, habit)
+	}
+
+	warnings := []string{}
+	for line, record := range records[1:] {
+		date, ok := parseCSVDate(strings.TrimSpace(record[0]), []string{"2006-01-02"})
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("Checkmarks.csv line %d: unreadable date %q", line+2, record[0]))
+			continue
+		}
+		for i, cell := range record[1:] {
+			cell = strings.TrimSpace(cell)
+			if i >= len(habits) || habits[i].name == "" || cell == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(cell, 64)
+			if err != nil || value < 0 {
+				continue // Unknown
+			}
+			if habits[i].taskType == "count" {
+				if !strings.Contains(cell, ".") {
+					value /= 1000
+				}
+				habits[i].values[date] = int(math.Round(value))
+			} else if value == loopYesManual {
+				habits[i].values[date] = 1
+			} else if value != loopSkip && value != loopYesAuto {
+				habits[i].values[date] = 0
+			}
+		}
+	}
+
+	named := habits[:0]
+	for _, habit := range habits {
+		if habit.name != "" {
+			named = append(named, habit)
+		}
+	}
+	return named, warnings, nil
+}
+
+// zipEntry finds a file at the top level of a zip. Loop also writes a
+// Checkmarks.csv per habit, in a folder each.
+func zipEntry(r *zip.Reader, name string) *zip.File {
+	for _, f := range r.File {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// readZipCSV reads every record of a CSV file in a zip
+func readZipCSV(f *zip.File) ([][]string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
+
+// sqlInt reads an integer cell scanned into an any
+func sqlInt(v any) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	case float64:
+		return int64(n)
+	}
+	return 0
+}
+
+// sqlFloat reads a number cell scanned into an any
+func sqlFloat(v any) float64 {
+	switch n := v.(type) {
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
+
+// sqlString reads a text cell scanned into an any
+func sqlString(v any) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case []byte:
+		return string(s)
+	}
+	return ""
+}
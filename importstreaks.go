@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// streaksDateLayouts are the date formats seen in Streaks exports
+var streaksDateLayouts = []string{"20060102", "2006-01-02", time.RFC3339}
+
+// isStreaksExport reports whether path is a Streaks CSV or JSON export
+func isStreaksExport(path string, head []byte) bool {
+	trimmed := bytes.TrimSpace(head)
+	if bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")) {
+		return bytes.Contains(head, []byte(`"title"`)) || bytes.Contains(head, []byte(`"entries"`))
+	}
+	line, _, _ := bytes.Cut(head, []byte("\n"))
+	return bytes.Contains(line, []byte("entry_type")) || bytes.Contains(line, []byte("entry_date"))
+}
+
+// streaksHabits collects entries by task title
+type streaksHabits struct {
+	order  []*importedHabit
+	byName map[string]*importedHabit
+}
+
+// add records one entry: completions tick the day or count its quantity,
+// misses record a zero, and skipped or paused days are left alone
+func (s *streaksHabits) add(title string, date string, kind string, quantity float64) {
+	habit := s.byName[title]
+	if habit == nil {
+		habit = &importedHabit{name: title, taskType: "binary", values: make(map[string]int)}
+		s.byName[title] = habit
+		s.order = append(s.order, habit)
+	}
+	kind = strings.ToLower(kind)
+	switch {
+	case strings.Contains(kind, "skip") || strings.Contains(kind, "pause"):
+	case strings.Contains(kind, "miss") || strings.Contains(kind, "fail"):
+		habit.values[date] = 0
+	default:
+		value := 1
+		if quantity > 1 {
+			value = int(math.Round(quantity))
+			habit.taskType = "count"
+		}
+		habit.values[date] = value
+	}
+}
+
+// readStreaksExport reads the CSV export (one row per entry) or the JSON
+// backup (tasks with their entries)
+func readStreaksExport(path string) ([]importedHabit, []string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+
+	s := &streaksHabits{byName: make(map[string]*importedHabit)}
+	var warnings []string
+	trimmed := bytes.TrimSpace(content)
+	if bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")) {
+		warnings, err = readStreaksJSON(trimmed, s)
+	} else {
+		warnings, err = readStreaksCSV(content, s)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	habits := make([]importedHabit, 0, len(s.order))
+	for _, habit := range s.order {
+		habits = append(habits, *habit)
+	}
+	return habits, warnings, nil
+}
+
+// readStreaksCSV reads rows of title, entry_type, entry_date and quantity
+func readStreaksCSV(content []byte, s *streaksHabits) ([]string, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("file has no header row")
+	}
+	col := make(map[string]int)
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	field := func(record []string, names ...string) string {
+		for _, name := range names {
+			if i, ok := col[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+		}
+		return ""
+	}
+	if _, ok := col["title"]; !ok {
+		return nil, errors.New("not a Streaks export: no title column")
+	}
+
+	warnings := []string{}
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, err
+		}
+		title := field(record, "title")
+		raw := field(record, "entry_date", "date")
+		date, ok := parseCSVDate(raw, streaksDateLayouts)
+		if title == "" || !ok {
+			warnings = append(warnings, fmt.Sprintf("line %d: unreadable entry %q", line, raw))
+			continue
+		}
+		quantity, _ := strconv.ParseFloat(field(record, "quantity", "value"), 64)
+		s.add(title, date, field(record, "entry_type", "type"), quantity)
+	}
+	return warnings, nil
+}
+
+// readStreaksJSON reads a list of tasks, each with a title and entries.
+// An entry is a date, or an object with a date, a type and a quantity.
+func readStreaksJSON(content []byte, s *streaksHabits) ([]string, error) {
+	var root any
+	if err := json.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("not a Streaks export: %v", err)
+	}
+	tasks, _ := root.([]any)
+	if object, ok := root.(map[string]any); ok {
+		tasks, _ = firstField(object, "tasks", "habits").([]any)
+	}
+	if tasks == nil {
+		return nil, errors.New("not a Streaks export: no tasks")
+	}
+
+	warnings := []string{}
+	for _, item := range tasks {
+		task, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		title, _ := firstField(task, "title", "name").(string)
+		if title == "" {
+			continue
+		}
+		entries, _ := firstField(task, "entries", "completions", "history").([]any)
+		for _, item := range entries {
+			raw, kind, quantity := "", "", 0.0
+			switch entry := item.(type) {
+			case string:
+				raw = entry
+			case map[string]any:
+				switch date := firstField(entry, "entry_date", "date", "day").(type) {
+				case string:
+					raw = date
+				case float64: // e.g. 20240131
+					raw = strconv.FormatFloat(date, 'f', -1, 64)
+				}
+				kind, _ = firstField(entry, "entry_type", "type", "status").(string)
+				quantity, _ = firstField(entry, "quantity", "value").(float64)
+			}
+			date, ok := parseCSVDate(raw, streaksDateLayouts)
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("%s: unreadable entry date %q", title, raw))
+				continue
+			}
+			s.add(title, date, kind, quantity)
+		}
+	}
+	return warnings, nil
+}
+
+// firstField returns the first of the named fields an object has
+func firstField(object map[string]any, names ...string) any {
+	for _, name := range names {
+		if v, ok := object[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of IntegrityIssue
+const (
+	issueOrphanedValue     = "orphaned-value"     // Day value for a task that doesn't exist
+	issueDuplicateTemplate = "duplicate-template" // Two tasks share an ID
+	issueInvalidTemplate   = "invalid-template"   // Task without an ID or name
+	issueInvalidDate       = "invalid-date"       // Key that isn't a YYYY-MM-DD date
+	issueNegativeValue     = "negative-value"     // Day value below zero
+	issueBrokenReference   = "broken-reference"   // Prerequisite, group or day profile that doesn't exist
+	issueQuarantined       = "quarantined"        // Unreadable entry set aside on load
+)
+
+// IntegrityIssue is one problem found by VerifyData
+type IntegrityIssue struct {
+	Kind       string `json:"kind"`
+	Date       string `json:"date,omitempty"`
+	TaskID     string `json:"taskId,omitempty"`
+	Detail     string `json:"detail"`
+	Repairable bool   `json:"repairable"` // RepairData fixes it
+}
+
+// IntegrityReport lists what VerifyData found, or what RepairData fixed
+type IntegrityReport struct {
+	Issues     []IntegrityIssue `json:"issues"`
+	Values     int              `json:"values"`               // Day values checked
+	Repaired   int              `json:"repaired"`             // Issues fixed by RepairData
+	BackupPath string           `json:"backupPath,omitempty"` // Copy from before the repair
+}
+
+// VerifyData checks the data for orphaned values, duplicate or invalid
+// tasks, malformed dates, negative values and broken references
+func (a *App) VerifyData() IntegrityReport {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.verifyDataLocked()
+}
+
+// verifyDataLocked scans the data without changing it (must hold lock)
+func (a *App) verifyDataLocked() IntegrityReport {
+	report := IntegrityReport{Issues: []IntegrityIssue{}}
+	add := func(issue IntegrityIssue) {
+		report.Issues = append(report.Issues, issue)
+	}
+
+	tasks := make(map[string]bool)
+	for _, t := range a.data.Templates {
+		if t.ID == "" || t.Name == "" {
+			add(IntegrityIssue{Kind: issueInvalidTemplate, TaskID: t.ID, Detail: fmt.Sprintf("task %q has no ID or name", t.Name), Repairable: true})
+		}
+		if tasks[t.ID] && t.ID != "" {
+			add(IntegrityIssue{Kind: issueDuplicateTemplate, TaskID: t.ID, Detail: fmt.Sprintf("task %q shares its ID with an earlier task", t.Name), Repairable: true})
+		}
+		tasks[t.ID] = true
+	}
+	groups := make(map[string]bool)
+	for _, g := range a.data.Groups {
+		groups[g.ID] = true
+	}
+	profiles := make(map[string]bool)
+	for _, p := range a.data.DayProfiles {
+		profiles[p.ID] = true
+	}
+	for _, t := range a.data.Templates {
+		if t.Requires != "" && !tasks[t.Requires] {
+			add(IntegrityIssue{Kind: issueBrokenReference, TaskID: t.ID, Detail: fmt.Sprintf("task %q requires a task that doesn't exist", t.Name), Repairable: true})
+		}
+		if t.Group != "" && !groups[t.Group] {
+			add(IntegrityIssue{Kind: issueBrokenReference, TaskID: t.ID, Detail: fmt.Sprintf("task %q is in a group that doesn't exist", t.Name), Repairable: true})
+		}
+		for _, p := range t.Profiles {
+			if !profiles[p] {
+				add(IntegrityIssue{Kind: issueBrokenReference, TaskID: t.ID, Detail: fmt.Sprintf("task %q applies on a day profile that doesn't exist", t.Name), Repairable: true})
+			}
+		}
+	}
+
+	dates := make([]string, 0, len(a.data.Days))
+	for date := range a.data.Days {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	for _, date := range dates {
+		if !isDateKey(date) {
+			add(IntegrityIssue{Kind: issueInvalidDate, Date: date, Detail: "day key is not a date", Repairable: true})
+			continue
+		}
+		ids := make([]string, 0, len(a.data.Days[date]))
+		for id := range a.data.Days[date] {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			value := a.data.Days[date][id]
+			report.Values++
+			if !tasks[id] {
+				add(IntegrityIssue{Kind: issueOrphanedValue, Date: date, TaskID: id, Detail: "value for a task that doesn't exist", Repairable: true})
+			}
+			if value < 0 {
+				add(IntegrityIssue{Kind: issueNegativeValue, Date: date, TaskID: id, Detail: fmt.Sprintf("value %d is below zero", value), Repairable: true})
+			}
+		}
+	}
+
+	for date := range a.data.Measurements {
+		if !isDateKey(date) {
+			add(IntegrityIssue{Kind: issueInvalidDate, Date: date, Detail: "measurement key is not a date", Repairable: true})
+		}
+	}
+	for date := range a.data.Skipped {
+		if !isDateKey(date) {
+			add(IntegrityIssue{Kind: issueInvalidDate, Date: date, Detail: "skipped-day key is not a date", Repairable: true})
+		}
+	}
+	for _, q := range a.data.Quarantine {
+		add(IntegrityIssue{Kind: issueQuarantined, Date: q.Date, TaskID: q.TaskID, Detail: q.Reason})
+	}
+	return report
+}
+
+// isDateKey reports whether key is a YYYY-MM-DD date
+func isDateKey(key string) bool {
+	_, err := time.Parse("2006-01-02", key)
+	return err == nil
+}
+
+// RepairData fixes what VerifyData found where that can be done safely,
+// after backing up the data. Orphaned values and malformed day keys are
+// moved to the quarantine rather than deleted; duplicate tasks after the
+// first are dropped; negative values become 0; broken references are
+// cleared. Quarantined entries are left for the user to review.
+func (a *App) RepairData() (IntegrityReport, error) {
+	a.mu.Lock()
+
+	found := a.verifyDataLocked()
+	report := IntegrityReport{Issues: []IntegrityIssue{}, Values: found.Values}
+	for _, issue := range found.Issues {
+		if issue.Repairable {
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+	if len(report.Issues) == 0 {
+		a.mu.Unlock()
+		return report, nil
+	}
+
+	backupPath, err := a.preChangeBackupLocked("repair")
+	if err != nil {
+		a.mu.Unlock()
+		return report, err
+	}
+	report.BackupPath = backupPath
+	a.rememberLocked("RepairData")
+
+	// Tasks first, so values are checked against the repaired list
+	seen := make(map[string]bool)
+	templates := []TaskTemplate{}
+	for _, t := range a.data.Templates {
+		if t.ID != "" && seen[t.ID] {
+			report.Repaired++
+			continue
+		}
+		if t.ID == "" {
+			t.ID = uuid.New().String()
+			report.Repaired++
+		}
+		if t.Name == "" {
+			t.Name = "Untitled task"
+			report.Repaired++
+		}
+		seen[t.ID] = true
+		templates = append(templates, t)
+	}
+	a.data.Templates = templates
+
+	groups := make(map[string]bool)
+	for _, g := range a.data.Groups {
+		groups[g.ID] = true
+	}
+	profiles := make(map[string]bool)
+	for _, p := range a.data.DayProfiles {
+		profiles[p.ID] = true
+	}
+	for i, t := range a.data.Templates {
+		if t.Requires != "" && !seen[t.Requires] {
+			a.data.Templates[i].Requires = ""
+			report.Repaired++
+		}
+		if t.Group != "" && !groups[t.Group] {
+			a.data.Templates[i].Group = ""
+			report.Repaired++
+		}
+		if kept := slices.DeleteFunc(t.Profiles, func(id string) bool { return !profiles[id] }); len(kept) < len(t.Profiles) {
+			report.Repaired += len(t.Profiles) - len(kept)
+			a.data.Templates[i].Profiles = kept
+		}
+	}
+
+	found = a.verifyDataLocked()
+	now := time.Now().Format(time.RFC3339)
+	set := []QuarantinedEntry{}
+	for _, issue := range found.Issues {
+		switch issue.Kind {
+		case issueOrphanedValue:
+			raw, _ := json.Marshal(a.data.Days[issue.Date][issue.TaskID])
+			set = append(set, QuarantinedEntry{Date: issue.Date, TaskID: issue.TaskID, Raw: raw, Reason: "unknown task", Found: now})
+			delete(a.data.Days[issue.Date], issue.TaskID)
+		case issueNegativeValue:
+			if _, ok := a.data.Days[issue.Date][issue.TaskID]; ok {
+				a.data.Days[issue.Date][issue.TaskID] = 0
+			}
+		case issueInvalidDate:
+			if day, ok := a.data.Days[issue.Date]; ok {
+				raw, _ := json.Marshal(day)
+				set = append(set, QuarantinedEntry{Date: issue.Date, Raw: raw, Reason: "invalid date", Found: now})
+				delete(a.data.Days, issue.Date)
+			}
+			delete(a.data.Measurements, issue.Date)
+			delete(a.data.Skipped, issue.Date)
+		default:
+			continue
+		}
+		report.Repaired++
+	}
+	// Set aside here, so no load-time notice
+	a.data.Quarantine = append(a.data.Quarantine, set...)
+
+	a.rebuildRecordsLocked()
+	a.audit("RepairData", "", "", nil, report.Repaired)
+	err = a.saveDataLocked()
+	a.mu.Unlock()
+
+	a.emitDataChanged("")
+	a.refreshMenu()
+	return report, err
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// journalRecord is one change written to the journal before a save
+type journalRecord struct {
+	At      int64           `json:"at"` // Unix milliseconds
+	Date    string          `json:"date,omitempty"`
+	TaskID  string          `json:"taskId,omitempty"`
+	Value   int             `json:"value,omitempty"`
+	Deleted bool            `json:"deleted,omitempty"`
+	Meta    json.RawMessage `json:"meta,omitempty"` // Everything but day values, when that changed
+}
+
+// journalPath returns the path of the write-ahead journal next to data.json
+func (a *App) journalPath() string {
+	return filepath.Join(filepath.Dir(a.dataPath), "journal.jsonl")
+}
+
+// metaJSON encodes the data without day values and stamps, which are
+// journaled entry by entry
+func metaJSON(data PlannerData) ([]byte, error) {
+	data.Days = nil
+	data.Stamps = nil
+	return json.Marshal(data)
+}
+
+// journalLocked appends the changes a save is about to write and syncs
+// the journal to disk. SQLite saves are transactions already, so only
+// data.json is journaled (must hold lock).
+func (a *App) journalLocked(changes []dayChange) error {
+	if _, ok := a.store.(*jsonStore); !ok || a.dataPath == "" {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+	records := []journalRecord{}
+	meta, err := metaJSON(a.data)
+	if err != nil {
+		return err
+	}
+	sum := checksum(meta)
+	if sum != a.journalMetaSum {
+		records = append(records, journalRecord{At: now, Meta: meta})
+	}
+	for _, c := range changes {
+		records = append(records, journalRecord{At: now, Date: c.Date, TaskID: c.TaskID, Value: c.Value, Deleted: c.Deleted})
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, r := range records {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if a.dataKey != nil {
+			// Journal lines are sealed like data.json, one by one
+			sealed, err := a.sealLocked(line)
+			if err != nil {
+				return err
+			}
+			line = []byte(base64.StdEncoding.EncodeToString(sealed))
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	f, err := os.OpenFile(a.journalPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// Only a journaled meta record counts, or a failed write would skip it next time
+	a.journalMetaSum = sum
+	return nil
+}
+
+// clearJournalLocked empties the journal once a save has completed (must hold lock)
+func (a *App) clearJournalLocked() {
+	if err := os.Remove(a.journalPath()); err != nil && !os.IsNotExist(err) {
+		println("Error clearing journal:", err.Error())
+	}
+}
+
+// readJournalLocked reads the journal's records. A line cut short by a
+// crash ends the journal (must hold lock).
+func (a *App) readJournalLocked() ([]journalRecord, error) {
+	content, err := os.ReadFile(a.journalPath())
+	if err != nil {
+		return nil, err
+	}
+
+	records := []journalRecord{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) > 0 && line[0] != '{' {
+			sealed, err := base64.StdEncoding.DecodeString(string(line))
+			if err != nil {
+				break
+			}
+			if line, err = a.openLocked(sealed); err != nil {
+				return nil, err
+			}
+		}
+		var r journalRecord
+		if err := json.Unmarshal(line, &r); err != nil {
+			break
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// recoverJournalLocked replays changes journaled by a save that didn't
+// complete, e.g. because the app crashed, and saves them (must hold lock)
+func (a *App) recoverJournalLocked() {
+	if _, ok := a.store.(*jsonStore); !ok {
+		return
+	}
+	records, err := a.readJournalLocked()
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(records) == 0) {
+		return
+	}
+	if err != nil {
+		println("Error reading journal:", err.Error())
+		return
+	}
+
+	if _, err := a.preChangeBackupLocked("recover"); err != nil {
+		println("Error backing up before recovering journal:", err.Error())
+		return
+	}
+	for _, r := range records {
+		switch {
+		case r.Meta != nil:
+			var meta PlannerData
+			if err := json.Unmarshal(r.Meta, &meta); err != nil {
+				continue
+			}
+			meta.Days, meta.Stamps = a.data.Days, a.data.Stamps
+			a.data = meta
+		case r.Deleted:
+			if err := a.pullArchivedDayLocked(r.Date); err != nil {
+				println("Error reading archived day:", err.Error())
+			}
+			delete(a.data.Days[r.Date], r.TaskID)
+		default:
+			if err := a.pullArchivedDayLocked(r.Date); err != nil {
+				println("Error reading archived day:", err.Error())
+			}
+			if a.data.Days[r.Date] == nil {
+				a.data.Days[r.Date] = make(DayTasks)
+			}
+			a.data.Days[r.Date][r.TaskID] = r.Value
+		}
+	}
+	if a.data.ExportHistory == nil {
+		a.data.ExportHistory = make(map[string]string)
+	}
+
+	a.rebuildRecordsLocked()
+	a.audit("RecoverJournal", "", "", nil, len(records))
+	if err := a.saveDataLocked(); err != nil {
+		println("Error saving recovered changes:", err.Error())
+	}
+}
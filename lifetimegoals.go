@@ -0,0 +1,278 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// lifetimeMilestones are the percentages of a lifetime goal that raise a
+// notification when reached
+var lifetimeMilestones = []int{25, 50, 75, 100}
+
+// lifetimeRateDays is how many recent days the projected completion date
+// is based on
+const lifetimeRateDays = 90
+
+// LifetimeGoal is a cumulative target for a count or duration task, such as
+// "10,000 km run", in the task's own units (minutes for duration tasks)
+type LifetimeGoal struct {
+	ID        string `json:"id"`
+	TaskID    string `json:"taskId"`
+	Name      string `json:"name"`
+	Target    int    `json:"target"`
+	Since     string `json:"since,omitempty"`     // First date that counts; empty for all history
+	Milestone int    `json:"milestone,omitempty"` // Highest milestone percentage already notified
+}
+
+// LifetimeGoalProgress is a lifetime goal's running total and projection
+type LifetimeGoalProgress struct {
+	ID        string  `json:"id"`
+	TaskID    string  `json:"taskId"`
+	TaskName  string  `json:"taskName"`
+	Name      string  `json:"name"`
+	Unit      string  `json:"unit"`
+	Target    int     `json:"target"`
+	Total     int     `json:"total"`
+	Remaining int     `json:"remaining"`
+	Percent   float64 `json:"percent"`
+	PerDay    float64 `json:"perDay"`              // Average over the last 90 days
+	Projected string  `json:"projected,omitempty"` // Date the goal is reached at PerDay; empty when reached or not moving
+	ReachedOn string  `json:"reachedOn,omitempty"`
+}
+
+// AddLifetimeGoal adds a cumulative goal for a count or duration task.
+// since limits the total to values from that date on; empty counts all
+// history.
+func (a *App) AddLifetimeGoal(taskID string, name string, target int, since string) (LifetimeGoal, error) {
+	name = strings.TrimSpace(name)
+	if err := checkLifetimeGoal(name, target, since); err != nil {
+		return LifetimeGoal{}, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		return LifetimeGoal{}, errors.New("task not found")
+	}
+	if !isCounterTask(task) {
+		return LifetimeGoal{}, errors.New("lifetime goals need a count or duration task")
+	}
+
+	goal := LifetimeGoal{ID: uuid.New().String(), TaskID: taskID, Name: name, Target: target, Since: since}
+	// Milestones already passed aren't announced
+	goal.Milestone = passedMilestone(a.lifetimeProgressLocked(goal, time.Now().Format("2006-01-02")))
+	a.data.LifetimeGoals = append(a.data.LifetimeGoals, goal)
+	a.audit("AddLifetimeGoal", "", taskID, nil, goal)
+	return goal, a.saveDataLocked()
+}
+
+// UpdateLifetimeGoal changes a lifetime goal's name, target and start
+func (a *App) UpdateLifetimeGoal(id string, name string, target int, since string) error {
+	name = strings.TrimSpace(name)
+	if err := checkLifetimeGoal(name, target, since); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, goal := range a.data.LifetimeGoals {
+		if goal.ID == id {
+			updated := goal
+			updated.Name, updated.Target, updated.Since = name, target, since
+			updated.Milestone = passedMilestone(a.lifetimeProgressLocked(updated, time.Now().Format("2006-01-02")))
+			a.data.LifetimeGoals[i] = updated
+			a.audit("UpdateLifetimeGoal", "", goal.TaskID, goal, updated)
+			return a.saveDataLocked()
+		}
+	}
+	return errors.New("lifetime goal not found")
+}
+
+// RemoveLifetimeGoal deletes a lifetime goal; the task's values are kept
+func (a *App) RemoveLifetimeGoal(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, goal := range a.data.LifetimeGoals {
+		if goal.ID == id {
+			a.data.LifetimeGoals = append(a.data.LifetimeGoals[:i], a.data.LifetimeGoals[i+1:]...)
+			a.audit("RemoveLifetimeGoal", "", goal.TaskID, goal, nil)
+			return a.saveDataLocked()
+		}
+	}
+	return errors.New("lifetime goal not found")
+}
+
+// GetLifetimeGoals returns every lifetime goal with its progress as of today
+func (a *App) GetLifetimeGoals() []LifetimeGoalProgress {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	today := time.Now().Format("2006-01-02")
+	result := make([]LifetimeGoalProgress, 0, len(a.data.LifetimeGoals))
+	for _, goal := range a.data.LifetimeGoals {
+		result = append(result, a.lifetimeProgressLocked(goal, today))
+	}
+	return result
+}
+
+// checkLifetimeGoal validates the fields set by the frontend
+func checkLifetimeGoal(name string, target int, since string) error {
+	if name == "" {
+		return errors.New("goal name is required")
+	}
+	if target <= 0 {
+		return errors.New("target must be positive")
+	}
+	if since != "" {
+		if _, err := time.Parse("2006-01-02", since); err != nil {
+			return errors.New("invalid date")
+		}
+	}
+	return nil
+}
+
+// lifetimeProgressLocked totals a goal's task up to and including asOf
+// (must hold lock)
+func (a *App) lifetimeProgressLocked(goal LifetimeGoal, asOf string) LifetimeGoalProgress {
+	progress := LifetimeGoalProgress{ID: goal.ID, TaskID: goal.TaskID, Name: goal.Name, Target: goal.Target}
+	task, ok := a.findTemplateLocked(goal.TaskID)
+	if !ok {
+		return progress
+	}
+	progress.TaskName = task.Name
+	progress.Unit = task.Unit
+	if progress.Unit == "" && taskTypeOf(task) == "duration" {
+		progress.Unit = "min"
+	}
+
+	// Archived years count too; they're read here rather than loaded, as
+	// goals are checked under the read lock
+	values := make(map[string]int)
+	for _, days := range []map[string]DayTasks{a.archivedDaysLocked(), a.data.Days} {
+		for date, tasks := range days {
+			if value, ok := tasks[goal.TaskID]; ok && date >= goal.Since && date <= asOf {
+				values[date] = value
+			}
+		}
+	}
+	dates := make([]string, 0, len(values))
+	for date := range values {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	end, _ := time.Parse("2006-01-02", asOf)
+	rateFrom := end.AddDate(0, 0, 1-lifetimeRateDays).Format("2006-01-02")
+	if goal.Since > rateFrom {
+		rateFrom = goal.Since
+	}
+	if task.CreatedAt > rateFrom {
+		rateFrom = task.CreatedAt
+	}
+	recent := 0
+	for _, date := range dates {
+		value := values[date]
+		progress.Total += value
+		if progress.ReachedOn == "" && progress.Total >= goal.Target {
+			progress.ReachedOn = date
+		}
+		if date >= rateFrom {
+			recent += value
+		}
+	}
+
+	progress.Percent = math.Min(100, float64(progress.Total)*100/float64(goal.Target))
+	if progress.ReachedOn != "" {
+		return progress
+	}
+	progress.Remaining = goal.Target - progress.Total
+	if start, err := time.Parse("2006-01-02", rateFrom); err == nil && !start.After(end) {
+		days := int(end.Sub(start).Hours()/24) + 1
+		progress.PerDay = float64(recent) / float64(days)
+	}
+	if progress.PerDay > 0 {
+		days := int(math.Ceil(float64(progress.Remaining) / progress.PerDay))
+		progress.Projected = end.AddDate(0, 0, days).Format("2006-01-02")
+	}
+	return progress
+}
+
+// passedMilestone returns the highest milestone percentage progress has
+// reached, or 0
+func passedMilestone(progress LifetimeGoalProgress) int {
+	passed := 0
+	for _, milestone := range lifetimeMilestones {
+		if progress.Percent >= float64(milestone) {
+			passed = milestone
+		}
+	}
+	return passed
+}
+
+// checkLifetimeGoalsLocked notifies once per milestone reached by goals on
+// the given tasks. Only the highest new milestone is announced, so a large
+// import doesn't raise one notification per step (must hold lock).
+func (a *App) checkLifetimeGoalsLocked(taskIDs []string) {
+	changed := make(map[string]bool)
+	for _, id := range taskIDs {
+		changed[id] = true
+	}
+	today := time.Now().Format("2006-01-02")
+	for i, goal := range a.data.LifetimeGoals {
+		if !changed[goal.TaskID] {
+			continue
+		}
+		progress := a.lifetimeProgressLocked(goal, today)
+		passed := passedMilestone(progress)
+		if passed <= goal.Milestone {
+			continue
+		}
+		a.data.LifetimeGoals[i].Milestone = passed
+		if passed == 100 {
+			a.notifyLocked("lifetime-goal", "Goal reached: "+goal.Name,
+				fmt.Sprintf("%d%s of %s", progress.Total, unitSuffix(progress.Unit), progress.TaskName))
+		} else {
+			a.notifyLocked("lifetime-goal", fmt.Sprintf("%d%% of %s", passed, goal.Name),
+				fmt.Sprintf("%d of %d%s", progress.Total, goal.Target, unitSuffix(progress.Unit)))
+		}
+	}
+}
+
+// lifetimeGoalYear is a lifetime goal's standing at the end of an exported
+// year, with how much the year added
+type lifetimeGoalYear struct {
+	LifetimeGoalProgress
+	Gained int
+}
+
+// lifetimeGoalYearsLocked returns every lifetime goal as of the last day of
+// a year starting on start (must hold lock)
+func (a *App) lifetimeGoalYearsLocked(start time.Time, last string) []lifetimeGoalYear {
+	before := start.AddDate(0, 0, -1).Format("2006-01-02")
+	years := make([]lifetimeGoalYear, 0, len(a.data.LifetimeGoals))
+	for _, goal := range a.data.LifetimeGoals {
+		progress := a.lifetimeProgressLocked(goal, last)
+		gained := progress.Total - a.lifetimeProgressLocked(goal, before).Total
+		years = append(years, lifetimeGoalYear{LifetimeGoalProgress: progress, Gained: gained})
+	}
+	return years
+}
+
+// summary describes the goal's standing in one line
+func (g lifetimeGoalYear) summary() string {
+	text := fmt.Sprintf("%d of %d%s (%.0f%%), +%d this year", g.Total, g.Target, unitSuffix(g.Unit), g.Percent, g.Gained)
+	if g.ReachedOn != "" {
+		text += ", reached on " + g.ReachedOn
+	}
+	return text
+}
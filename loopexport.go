@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// loopHeader is the header Loop Habit Tracker recognises its HabitBull
+// CSV importer by
+var loopHeader = []string{"HabitName", "HabitDescription", "HabitCategory", "CalendarDate", "Value", "CommentText"}
+
+// ExportLoopHabitsCSV saves every task's history in a CSV file that Loop
+// Habit Tracker for Android can import (Settings → Import data). Loop reads
+// it as HabitBull data, which only has yes/no habits: each day a task
+// succeeded is written as a checkmark. Value and scale tasks are left out,
+// having no success. An empty path saves into the export folder. Returns
+// the file path.
+func (a *App) ExportLoopHabitsCSV(path string) (string, error) {
+	if path != "" && strings.ToLower(filepath.Ext(path)) != ".csv" {
+		return "", errors.New("file must be saved as .csv")
+	}
+
+	unlock := a.lockDays("", "")
+	groups := make(map[string]string)
+	for _, g := range a.data.Groups {
+		groups[g.ID] = g.Name
+	}
+	dates := make([]string, 0, len(a.data.Days))
+	for date := range a.data.Days {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	rows := [][]string{}
+	for _, task := range a.data.Templates {
+		if isValueTask(task) || isScaleTask(task) {
+			continue
+		}
+		for _, date := range dates {
+			value, recorded := a.data.Days[date][task.ID]
+			if !recorded || !a.taskAppliesLocked(task, date) || !taskSucceeded(task, date, value) {
+				continue
+			}
+			rows = append(rows, []string{task.Name, task.Description, groups[task.Group], date, "1", ""})
+		}
+	}
+	unlock()
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(loopHeader)
+	w.WriteAll(rows)
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+
+	if path == "" {
+		return a.writeExportFile("PLAN_loop_"+time.Now().Format("2006-01-02")+".csv", buf.Bytes())
+	}
+	if err := a.atomicWriteFile(path, buf.Bytes()); err != nil {
+		return "", err
+	}
+	return path, nil
+}
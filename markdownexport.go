@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// markdownSource marks files written by ExportMarkdown in their front
+// matter, so files PLAN didn't write are never overwritten
+const markdownSource = "source: PLAN"
+
+// MarkdownExportResult lists the files ExportMarkdown wrote
+type MarkdownExportResult struct {
+	Folder  string   `json:"folder"`
+	Files   []string `json:"files"`
+	Skipped []string `json:"skipped"` // Existing files PLAN didn't write, left alone
+}
+
+// markdownFile is one file to write
+type markdownFile struct {
+	name    string
+	content []byte
+}
+
+// ExportMarkdown writes one Markdown file per day ("day") or per week
+// ("week") of a scope into folder, with a checkbox per task, counts,
+// readings and notes. Scopes are as for Export; an empty folder means the
+// export folder. Files are named plan-2006-01-02.md and plan-2006-W01.md,
+// and are overwritten by later exports of the same days.
+func (a *App) ExportMarkdown(scope string, per string, folder string) (MarkdownExportResult, error) {
+	if per != "day" && per != "week" {
+		return MarkdownExportResult{}, errors.New(`per must be "day" or "week"`)
+	}
+
+	a.mu.RLock()
+	from, to, err := a.exportScopeLocked(scope)
+	if err == nil && folder == "" {
+		folder, err = a.exportFolderLocked()
+	}
+	a.mu.RUnlock()
+	if err != nil {
+		return MarkdownExportResult{}, err
+	}
+	unlock := a.lockDays(from, to)
+	files := a.markdownFilesLocked(from, to, per)
+	unlock()
+
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return MarkdownExportResult{}, err
+	}
+	result := MarkdownExportResult{Folder: folder, Files: []string{}, Skipped: []string{}}
+	for _, f := range files {
+		path := filepath.Join(folder, f.name)
+		if !writtenByPlan(path) {
+			result.Skipped = append(result.Skipped, path)
+			continue
+		}
+		if err := a.atomicWriteFile(path, f.content); err != nil {
+			return result, err
+		}
+		result.Files = append(result.Files, path)
+	}
+	return result, nil
+}
+
+// writtenByPlan reports whether path is missing or was written by
+// ExportMarkdown
+func writtenByPlan(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Is(err, os.ErrNotExist)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for i := 0; i < 5 && scanner.Scan(); i++ {
+		if scanner.Text() == markdownSource {
+			return true
+		}
+	}
+	return false
+}
+
+// markdownFilesLocked renders the range as day or week files (must hold lock)
+func (a *App) markdownFilesLocked(from, to string, per string) []markdownFile {
+	table := a.exportTableLocked(from, to)
+	files := []markdownFile{}
+
+	if per == "day" {
+		for _, date := range table.Dates {
+			var buf bytes.Buffer
+			fmt.Fprintf(&buf, "---\n%s\ndate: %s\n---\n\n", markdownSource, date)
+			a.writeMarkdownDayLocked(&buf, table, date, "#")
+			files = append(files, markdownFile{name: "plan-" + date + ".md", content: buf.Bytes()})
+		}
+		return files
+	}
+
+	for i := 0; i < len(table.Dates); {
+		day, _ := time.Parse("2006-01-02", table.Dates[i])
+		weekStart := weekStartOf(day).Format("2006-01-02")
+		year, week := day.ISOWeek()
+
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "---\n%s\nweek: %s\n---\n\n# Week of %s\n", markdownSource, weekStart, weekStartOf(day).Format("January 2, 2006"))
+		for ; i < len(table.Dates); i++ {
+			d, _ := time.Parse("2006-01-02", table.Dates[i])
+			if weekStartOf(d).Format("2006-01-02") != weekStart {
+				break
+			}
+			buf.WriteString("\n")
+			a.writeMarkdownDayLocked(&buf, table, table.Dates[i], "##")
+		}
+		for _, review := range a.data.Reviews {
+			if review.WeekStart != weekStart || review.CompletedAt == "" {
+				continue
+			}
+			buf.WriteString("\n## Review\n\n")
+			if review.Reflection != "" {
+				buf.WriteString(review.Reflection + "\n")
+			}
+			if len(review.Intentions) > 0 {
+				buf.WriteString("\nNext week:\n\n")
+				for _, intention := range review.Intentions {
+					buf.WriteString("- " + intention + "\n")
+				}
+			}
+		}
+		files = append(files, markdownFile{name: fmt.Sprintf("plan-%d-W%02d.md", year, week), content: buf.Bytes()})
+	}
+	return files
+}
+
+// writeMarkdownDayLocked writes a day's heading, tasks, score and notes
+// (must hold lock)
+func (a *App) writeMarkdownDayLocked(buf *bytes.Buffer, table exportTable, date string, heading string) {
+	day, _ := time.Parse("2006-01-02", date)
+	title := day.Format("Monday, January 2, 2006")
+	names := append([]string{}, table.Special[date]...)
+	if note := table.skippedNote(date); note != "" {
+		names = append(names, note)
+	}
+	if len(names) > 0 {
+		title += " (" + strings.Join(names, ", ") + ")"
+	}
+	fmt.Fprintf(buf, "%s %s\n\n", heading, title)
+
+	tasks := 0
+	for _, task := range table.Tasks {
+		value, ok := table.Values[date][task.ID]
+		if !ok {
+			continue
+		}
+		tasks++
+		if isValueTask(task) {
+			if cell := table.cell(date, task); cell != "" {
+				fmt.Fprintf(buf, "- %s: %s\n", task.Name, cell)
+			} else {
+				fmt.Fprintf(buf, "- %s: not measured\n", task.Name)
+			}
+			continue
+		}
+
+		box := "[ ]"
+		if taskSucceeded(task, date, value) {
+			box = "[x]"
+		}
+		line := "- " + box + " " + task.Name
+		switch taskTypeOf(task) {
+		case "binary", "negative":
+		default:
+			if cell := table.cell(date, task); cell != "" {
+				line += ": " + cell
+				if task.Target > 0 && isCounterTask(task) {
+					line += fmt.Sprintf(" (goal %d)", task.Target)
+				}
+			}
+		}
+		if reason, skipped := a.data.Skipped[date][task.ID]; skipped {
+			line += " (skipped"
+			if reason != "" {
+				line += ": " + reason
+			}
+			line += ")"
+		}
+		buf.WriteString(line + "\n")
+	}
+	if tasks == 0 {
+		buf.WriteString("No tasks.\n")
+	}
+	if score := table.score(date); score != "" {
+		fmt.Fprintf(buf, "\nScore: %s\n", score)
+	}
+
+	notes := a.annotationsInRangeLocked(date, date)
+	if len(notes) > 0 {
+		buf.WriteString("\nNotes:\n\n")
+		for _, note := range notes {
+			buf.WriteString("- " + note.Text + "\n")
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// isValueTask reports whether a task records a decimal measurement (weight,
+// hours slept, km run) rather than a completion
+func isValueTask(t TaskTemplate) bool {
+	return taskTypeOf(t) == "value"
+}
+
+// MeasurementStats aggregates a value task's measurements over a range
+type MeasurementStats struct {
+	TaskID  string  `json:"taskId"`
+	Unit    string  `json:"unit,omitempty"`
+	Count   int     `json:"count"`
+	Sum     float64 `json:"sum"`
+	Average float64 `json:"average"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+}
+
+// SetMeasurement records a value task's measurement for a date
+func (a *App) SetMeasurement(date string, taskID string, value float64) error {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return errors.New("invalid date")
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return errors.New("invalid value")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkEditableLocked(date); err != nil {
+		return err
+	}
+
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		return errors.New("task not found")
+	}
+	if !isValueTask(task) {
+		return errors.New("task is not a value task")
+	}
+
+	if a.data.Measurements == nil {
+		a.data.Measurements = make(map[string]map[string]float64)
+	}
+	if a.data.Measurements[date] == nil {
+		a.data.Measurements[date] = make(map[string]float64)
+	}
+	old, had := a.data.Measurements[date][taskID]
+	a.data.Measurements[date][taskID] = value
+	a.markMeasuredLocked(date, taskID, true)
+
+	if had {
+		a.audit("SetMeasurement", date, taskID, old, value)
+	} else {
+		a.audit("SetMeasurement", date, taskID, nil, value)
+	}
+	return a.saveDataLocked()
+}
+
+// ClearMeasurement removes a value task's measurement for a date
+func (a *App) ClearMeasurement(date string, taskID string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkEditableLocked(date); err != nil {
+		return err
+	}
+
+	old, ok := a.data.Measurements[date][taskID]
+	if !ok {
+		return nil
+	}
+
+	delete(a.data.Measurements[date], taskID)
+	if len(a.data.Measurements[date]) == 0 {
+		delete(a.data.Measurements, date)
+	}
+	a.markMeasuredLocked(date, taskID, false)
+
+	a.audit("ClearMeasurement", date, taskID, old, nil)
+	return a.saveDataLocked()
+}
+
+// markMeasuredLocked keeps the day value of a value task at 1 when a
+// measurement exists, so completion-based views see it as logged
+// (must hold lock; caller saves)
+func (a *App) markMeasuredLocked(date string, taskID string, measured bool) {
+	if err := a.pullArchivedDayLocked(date); err != nil {
+		println("Error reading archived day:", err.Error())
+		return
+	}
+	if !measured {
+		delete(a.data.Days[date], taskID)
+		return
+	}
+	if a.data.Days == nil {
+		a.data.Days = make(map[string]DayTasks)
+	}
+	if a.data.Days[date] == nil {
+		a.data.Days[date] = make(DayTasks)
+	}
+	a.data.Days[date][taskID] = 1
+}
+
+// LoadDayMeasurements returns value task measurements for a date
+func (a *App) LoadDayMeasurements(date string) map[string]float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := make(map[string]float64)
+	for k, v := range a.data.Measurements[date] {
+		result[k] = v
+	}
+	return result
+}
+
+// GetMeasurementStats aggregates a value task's measurements between from
+// and to (inclusive)
+func (a *App) GetMeasurementStats(taskID string, from string, to string) (MeasurementStats, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		return MeasurementStats{}, errors.New("task not found")
+	}
+	return a.measurementStatsLocked(task, from, to), nil
+}
+
+// measurementStatsLocked aggregates a task's measurements over an inclusive
+// date range, skipping excluded days (must hold lock)
+func (a *App) measurementStatsLocked(task TaskTemplate, from, to string) MeasurementStats {
+	stats := MeasurementStats{TaskID: task.ID, Unit: task.Unit}
+	for date, values := range a.data.Measurements {
+		if date < from || date > to || a.dayExcludedLocked(date) {
+			continue
+		}
+		value, ok := values[task.ID]
+		if !ok {
+			continue
+		}
+		if stats.Count == 0 || value < stats.Min {
+			stats.Min = value
+		}
+		if stats.Count == 0 || value > stats.Max {
+			stats.Max = value
+		}
+		stats.Count++
+		stats.Sum += value
+	}
+	if stats.Count > 0 {
+		stats.Average = stats.Sum / float64(stats.Count)
+	}
+	return stats
+}
+
+// measurementsInRangeLocked aggregates every value task that applies
+// somewhere in the range, for report output (must hold lock)
+func (a *App) measurementsInRangeLocked(from, to string) []MeasurementStats {
+	result := []MeasurementStats{}
+	for _, task := range a.data.Templates {
+		if !isValueTask(task) {
+			continue
+		}
+		if !a.taskActiveInRangeLocked(task, from, to) {
+			continue
+		}
+		result = append(result, a.measurementStatsLocked(task, from, to))
+	}
+	return result
+}
+
+// taskActiveInRangeLocked reports whether a task applies on any day of an
+// inclusive range (must hold lock)
+func (a *App) taskActiveInRangeLocked(task TaskTemplate, from, to string) bool {
+	start, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return false
+	}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if a.taskAppliesLocked(task, d.Format("2006-01-02")) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertTaskValuesLocked moves a task's history between integer day values
+// and decimal measurements when its type changes to or from "value"
+// (must hold lock; caller saves)
+func (a *App) convertTaskValuesLocked(taskID string, fromType, toType string) {
+	switch {
+	case toType == "value" && fromType != "value":
+		if err := a.pullArchivedTaskDaysLocked(taskID); err != nil {
+			println("Error reading archived days:", err.Error())
+		}
+		for date, dayTasks := range a.data.Days {
+			value, ok := dayTasks[taskID]
+			if !ok {
+				continue
+			}
+			if a.data.Measurements == nil {
+				a.data.Measurements = make(map[string]map[string]float64)
+			}
+			if a.data.Measurements[date] == nil {
+				a.data.Measurements[date] = make(map[string]float64)
+			}
+			a.data.Measurements[date][taskID] = float64(value)
+			dayTasks[taskID] = 1
+		}
+	case fromType == "value" && toType != "value":
+		for date, values := range a.data.Measurements {
+			value, ok := values[taskID]
+			if !ok {
+				continue
+			}
+			if err := a.pullArchivedDayLocked(date); err != nil {
+				println("Error reading archived day:", err.Error())
+				continue
+			}
+			if a.data.Days[date] == nil {
+				a.data.Days[date] = make(DayTasks)
+			}
+			a.data.Days[date][taskID] = int(math.Round(value))
+			delete(values, taskID)
+			if len(values) == 0 {
+				delete(a.data.Measurements, date)
+			}
+		}
+	}
+}
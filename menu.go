@@ -0,0 +1,182 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/wailsapp/wails/v2/pkg/menu"
+	"github.com/wailsapp/wails/v2/pkg/menu/keys"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// dataChangedEvent is emitted when data changes outside the frontend's control
+// (menu actions, background jobs) so the UI can reload. Send it with
+// emitDataChanged, which coalesces bursts of changes.
+const dataChangedEvent = "plan:data-changed"
+
+// buildMenu creates the native application menu from current data
+func (a *App) buildMenu() *menu.Menu {
+	appMenu := menu.NewMenu()
+
+	fileMenu := appMenu.AddSubmenu("File")
+	fileMenu.AddText("Choose Export Folder…", nil, func(_ *menu.CallbackData) {
+		if dir, err := a.SelectDirectory(); err == nil && dir != "" {
+			a.SetExportPath(dir)
+		}
+	})
+	fileMenu.AddSeparator()
+	fileMenu.AddText("Quit", keys.CmdOrCtrl("q"), func(_ *menu.CallbackData) {
+		runtime.Quit(a.ctx)
+	})
+
+	viewMenu := appMenu.AddSubmenu("View")
+	viewMenu.AddText("Reload", keys.CmdOrCtrl("r"), func(_ *menu.CallbackData) {
+		runtime.WindowReload(a.ctx)
+	})
+	viewMenu.AddText("Toggle Fullscreen", keys.Key("f11"), func(_ *menu.CallbackData) {
+		if runtime.WindowIsFullscreen(a.ctx) {
+			runtime.WindowUnfullscreen(a.ctx)
+		} else {
+			runtime.WindowFullscreen(a.ctx)
+		}
+	})
+
+	a.buildStatsMenu(appMenu.AddSubmenu("Stats"))
+
+	return appMenu
+}
+
+// buildStatsMenu fills the Stats submenu with today's progress and
+// quick-check items for each of today's tasks
+func (a *App) buildStatsMenu(statsMenu *menu.Menu) {
+	today := a.checkInDate()
+	tasks := a.GetTasksForDate(today)
+	values := a.LoadDay(today).Values
+	streaks := a.recentStreaks()
+
+	done := 0
+	for _, task := range tasks {
+		if taskSucceeded(task, today, values[task.ID]) {
+			done++
+		}
+	}
+
+	statsMenu.AddText(fmt.Sprintf("Today: %d/%d done", done, len(tasks)), nil, nil).Disable()
+	statsMenu.AddText(fmt.Sprintf("Current streak: %d days", streaks.current), nil, nil).Disable()
+	statsMenu.AddText(fmt.Sprintf("Longest streak in the past year: %d days", streaks.longest), nil, nil).Disable()
+
+	if len(tasks) == 0 {
+		return
+	}
+
+	statsMenu.AddSeparator()
+	for _, task := range tasks {
+		id := task.ID
+		label := task.Name
+		if isAutoTask(task) {
+			statsMenu.AddCheckbox(label+" (auto)", taskSucceeded(task, today, values[id]), nil, nil).Disable()
+			continue
+		}
+		if taskTypeOf(task) == "count" || taskTypeOf(task) == "duration" {
+			label = fmt.Sprintf("%s (%d%s)", task.Name, values[id], unitSuffix(task.Unit))
+			statsMenu.AddText(label+" +1", nil, func(_ *menu.CallbackData) {
+				a.QuickCheck(id)
+			})
+			continue
+		}
+		statsMenu.AddCheckbox(label, values[id] > 0, nil, func(_ *menu.CallbackData) {
+			a.QuickCheck(id)
+		})
+	}
+}
+
+// unitSuffix formats an optional unit for display after a number
+func unitSuffix(unit string) string {
+	if unit == "" {
+		return ""
+	}
+	return " " + unit
+}
+
+// refreshMenu rebuilds the application menu so dynamic items stay current
+func (a *App) refreshMenu() {
+	if a.ctx == nil {
+		return
+	}
+	runtime.MenuSetApplicationMenu(a.ctx, a.buildMenu())
+	runtime.MenuUpdateApplicationMenu(a.ctx)
+}
+
+// QuickCheck records a single check-off for today: binary and negative tasks
+// are toggled, count and duration tasks are incremented by one.
+// Returns the new value.
+func (a *App) QuickCheck(taskID string) (int, error) {
+	return a.checkOff("QuickCheck", a.checkInDate(), taskID, true)
+}
+
+// checkOff records a check-off of a task on date. Count and duration tasks
+// are incremented by one; binary and negative tasks are toggled, or set
+// when toggle is false. Returns the new value.
+func (a *App) checkOff(method, date, taskID string, toggle bool) (int, error) {
+	a.mu.Lock()
+
+	if err := a.checkEditableLocked(date); err != nil {
+		a.mu.Unlock()
+		return 0, err
+	}
+
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		a.mu.Unlock()
+		return 0, errors.New("task not found")
+	}
+	if isAutoTask(task) {
+		a.mu.Unlock()
+		return 0, errors.New("task value is computed automatically")
+	}
+	if isValueTask(task) {
+		a.mu.Unlock()
+		return 0, errors.New("value tasks are recorded with a measurement")
+	}
+	if isScaleTask(task) {
+		a.mu.Unlock()
+		return 0, errors.New("scale tasks are rated, not checked off")
+	}
+
+	if err := a.pullArchivedDayLocked(date); err != nil {
+		a.mu.Unlock()
+		return 0, err
+	}
+	if a.data.Days == nil {
+		a.data.Days = make(map[string]DayTasks)
+	}
+	if a.data.Days[date] == nil {
+		a.data.Days[date] = make(DayTasks)
+	}
+
+	had := auditValue(a.data.Days[date], taskID)
+	old := a.data.Days[date][taskID]
+	value := old + 1
+	switch taskTypeOf(task) {
+	case "binary", "negative":
+		if old > 0 && toggle {
+			value = 0
+		} else {
+			value = 1
+		}
+	}
+
+	a.data.Days[date][taskID] = value
+	a.audit(method, date, taskID, had, value)
+	a.updateRecordsLocked(date, []string{taskID})
+	warnings := a.dependencyWarningsLocked(date, []string{taskID})
+	reached := a.tiersReachedLocked(date, DayTasks{taskID: old}, []string{taskID})
+	a.checkLifetimeGoalsLocked([]string{taskID})
+	err := a.saveDataLocked()
+	a.mu.Unlock()
+
+	a.emitDependencyWarnings(warnings)
+	a.emitTiersReached(reached)
+	a.emitDataChanged(date)
+	return value, err
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// MergeTasks moves all of source's history onto target and deletes source.
+// Days where both tasks have a value are resolved by conflict: "sum" adds
+// the values, "max" keeps the larger one.
+func (a *App) MergeTasks(sourceID string, targetID string, conflict string) error {
+	if sourceID == targetID {
+		return errors.New("cannot merge a task into itself")
+	}
+	if conflict != "sum" && conflict != "max" {
+		return errors.New(`conflict must be "sum" or "max"`)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	source, ok := a.findTemplateLocked(sourceID)
+	if !ok {
+		return errors.New("task not found")
+	}
+	target, ok := a.findTemplateLocked(targetID)
+	if !ok {
+		return errors.New("task not found")
+	}
+	if isValueTask(source) != isValueTask(target) {
+		return errors.New("value tasks can only be merged with value tasks")
+	}
+
+	// Only counted tasks add up; a check stays a check and a rating stays
+	// in range
+	limit := 0
+	switch taskTypeOf(target) {
+	case "binary", "negative":
+		limit = 1
+	case "scale":
+		limit = scaleMaxOf(target)
+	}
+	resolve := func(existing, incoming int) int {
+		value := max(existing, incoming)
+		if conflict == "sum" {
+			value = existing + incoming
+		}
+		return value
+	}
+
+	if err := a.pullArchivedTaskDaysLocked(sourceID); err != nil {
+		return err
+	}
+	a.rememberLocked("MergeTasks")
+	for _, dayTasks := range a.data.Days {
+		value, ok := dayTasks[sourceID]
+		if !ok {
+			continue
+		}
+		if existing, ok := dayTasks[targetID]; ok {
+			value = resolve(existing, value)
+		}
+		if limit > 0 && value > limit {
+			value = limit
+		}
+		dayTasks[targetID] = value
+		delete(dayTasks, sourceID)
+	}
+
+	for _, values := range a.data.Measurements {
+		reading, ok := values[sourceID]
+		if !ok {
+			continue
+		}
+		if existing, ok := values[targetID]; ok {
+			if conflict == "sum" {
+				reading += existing
+			} else if existing > reading {
+				reading = existing
+			}
+		}
+		values[targetID] = reading
+		delete(values, sourceID)
+	}
+
+	for _, skipped := range a.data.Skipped {
+		if reason, ok := skipped[sourceID]; ok {
+			if _, targetSkipped := skipped[targetID]; !targetSkipped {
+				skipped[targetID] = reason
+			}
+			delete(skipped, sourceID)
+		}
+	}
+
+	// Checklist progress refers to the source's own subitems, so it can't carry over
+	for date, subitems := range a.data.SubitemDays {
+		delete(subitems, sourceID)
+		if len(subitems) == 0 {
+			delete(a.data.SubitemDays, date)
+		}
+	}
+
+	for i := range a.data.FocusSessions {
+		if a.data.FocusSessions[i].TaskID == sourceID {
+			a.data.FocusSessions[i].TaskID = targetID
+		}
+	}
+	delete(a.data.Timers, sourceID)
+
+	today := time.Now().Format("2006-01-02")
+	for i, t := range a.data.Templates {
+		// Tasks that needed the source now need the target
+		if t.Requires == sourceID && t.ID != targetID {
+			a.data.Templates[i].Requires = targetID
+		}
+		switch t.ID {
+		case sourceID:
+			if t.DeletedAt == nil {
+				a.data.Templates[i].DeletedAt = &today
+			}
+		case targetID:
+			// Start the target early enough to cover the merged history
+			if source.CreatedAt < target.CreatedAt {
+				a.data.Templates[i].CreatedAt = source.CreatedAt
+			}
+			if t.Requires == sourceID {
+				a.data.Templates[i].Requires = ""
+			}
+		}
+	}
+
+	a.rebuildRecordsLocked()
+	a.audit("MergeTasks", "", targetID, sourceID, conflict)
+	return a.saveDataLocked()
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// schemaVersion is the PlannerData format this build reads and writes.
+// Bump it together with a new entry in migrations whenever the format changes.
+const schemaVersion = 5
+
+// errNewerData is returned when saving data written by a newer version,
+// which might drop fields this build doesn't know about
+var errNewerData = errors.New("data was written by a newer version of PLAN")
+
+// migration upgrades data from the previous version to version
+type migration struct {
+	version int
+	name    string
+	apply   func(a *App) error // Runs with the lock held
+}
+
+// migrations upgrade data one version at a time, in order. Data without a
+// version field is version 0. Never change or reorder a released entry;
+// add a new one instead.
+var migrations = []migration{
+	{1, "move machine settings to local settings", (*App).migrateSettingsLocked},
+	{2, "key export history by week start", (*App).migrateExportHistoryLocked},
+	{3, "stamp day values for merging", (*App).migrateStampsLocked},
+	{4, "move old years to their own files", (*App).migrateYearArchivesLocked},
+	{5, "move the day score formula to local settings", (*App).migrateScoringLocked},
+}
+
+// migrateDataLocked applies the migrations the loaded data hasn't had yet,
+// backing up the data first and saving it afterwards (must hold lock)
+func (a *App) migrateDataLocked() {
+	if a.data.Version > schemaVersion {
+		println("Data was written by a newer version of PLAN; changes won't be saved")
+		return
+	}
+	if a.data.Version == schemaVersion {
+		return
+	}
+
+	if _, err := a.preChangeBackupLocked(fmt.Sprintf("migrate-v%d", a.data.Version)); err != nil {
+		println("Error backing up before migrating data:", err.Error())
+		return
+	}
+
+	from := a.data.Version
+	for _, m := range migrations {
+		if m.version <= a.data.Version {
+			continue
+		}
+		if err := m.apply(a); err != nil {
+			println("Error migrating data ("+m.name+"):", err.Error())
+			break
+		}
+		a.data.Version = m.version
+	}
+
+	if a.data.Version != from {
+		a.audit("MigrateData", "", "", from, a.data.Version)
+		if err := a.saveDataLocked(); err != nil {
+			println("Error saving migrated data:", err.Error())
+		}
+	}
+}
+
+// decodeLegacyData converts the original format, one list of four booleans
+// per date, to tasks and day values. ok is false when data isn't in that
+// format or holds nothing.
+func decodeLegacyData(data []byte) (decoded PlannerData, ok bool) {
+	var oldFormat map[string][]bool
+	if err := json.Unmarshal(data, &oldFormat); err != nil {
+		return PlannerData{}, false
+	}
+
+	hasOldData := false
+	for _, v := range oldFormat {
+		if len(v) > 0 {
+			hasOldData = true
+			break
+		}
+	}
+	if !hasOldData {
+		return PlannerData{}, false
+	}
+
+	// The old format had four fixed tasks
+	today := time.Now().Format("2006-01-02")
+	defaultTasks := []TaskTemplate{
+		{ID: "task-1", Name: "Task 1", Type: "binary", Order: 0, CreatedAt: today},
+		{ID: "task-2", Name: "Task 2", Type: "binary", Order: 1, CreatedAt: today},
+		{ID: "task-3", Name: "Task 3", Type: "binary", Order: 2, CreatedAt: today},
+		{ID: "task-4", Name: "Task 4", Type: "binary", Order: 3, CreatedAt: today},
+	}
+
+	newDays := make(map[string]DayTasks)
+	for date, tasks := range oldFormat {
+		dayTasks := make(DayTasks)
+		for i, completed := range tasks {
+			if i < len(defaultTasks) {
+				if completed {
+					dayTasks[defaultTasks[i].ID] = 1
+				} else {
+					dayTasks[defaultTasks[i].ID] = 0
+				}
+			}
+		}
+		newDays[date] = dayTasks
+	}
+
+	return PlannerData{
+		Templates:     defaultTasks,
+		Days:          newDays,
+		ExportHistory: make(map[string]string),
+	}, true
+}
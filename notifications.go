@@ -0,0 +1,98 @@
+package main
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// maxNotifications bounds the inbox; the oldest notifications are dropped first
+const maxNotifications = 100
+
+// notificationEvent is emitted whenever a notification is added to the inbox;
+// the frontend shows it on screen and as a system notification when allowed
+const notificationEvent = "plan:notification"
+
+// Notification is an in-app message kept in the notification inbox
+type Notification struct {
+	ID    string `json:"id"`
+	Time  string `json:"time"` // RFC3339
+	Kind  string `json:"kind"` // Source of the notification, e.g. "backup"
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+	Read  bool   `json:"read,omitempty"`
+}
+
+// notifyLocked adds a notification to the inbox and tells the frontend
+// (must hold lock; caller saves)
+func (a *App) notifyLocked(kind, title, body string) Notification {
+	n := Notification{
+		ID:    uuid.New().String(),
+		Time:  time.Now().Format(time.RFC3339),
+		Kind:  kind,
+		Title: title,
+		Body:  body,
+	}
+
+	a.data.Notifications = append(a.data.Notifications, n)
+	if len(a.data.Notifications) > maxNotifications {
+		a.data.Notifications = a.data.Notifications[len(a.data.Notifications)-maxNotifications:]
+	}
+
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, notificationEvent, n)
+	}
+	return n
+}
+
+// GetNotifications returns the inbox, newest first
+func (a *App) GetNotifications() []Notification {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := make([]Notification, 0, len(a.data.Notifications))
+	for i := len(a.data.Notifications) - 1; i >= 0; i-- {
+		result = append(result, a.data.Notifications[i])
+	}
+	return result
+}
+
+// unreadNotificationsLocked returns unread notifications, newest first (must hold lock)
+func (a *App) unreadNotificationsLocked() []Notification {
+	result := []Notification{}
+	for i := len(a.data.Notifications) - 1; i >= 0; i-- {
+		if !a.data.Notifications[i].Read {
+			result = append(result, a.data.Notifications[i])
+		}
+	}
+	return result
+}
+
+// MarkNotificationRead marks a single notification as read
+func (a *App) MarkNotificationRead(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, n := range a.data.Notifications {
+		if n.ID == id {
+			if n.Read {
+				return nil
+			}
+			a.data.Notifications[i].Read = true
+			return a.saveDataLocked()
+		}
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks the whole inbox as read
+func (a *App) MarkAllNotificationsRead() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := range a.data.Notifications {
+		a.data.Notifications[i].Read = true
+	}
+	return a.saveDataLocked()
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// onboardingSteps are the steps of the first-run flow, in order
+var onboardingSteps = []string{
+	"starterPack",  // Pick a preset of tasks
+	"weekStart",    // Pick the day weeks start on
+	"reminderTime", // Pick when incomplete tasks are reminded of
+	"exportFolder", // Pick where exports are saved
+}
+
+// OnboardingState is how far this machine got through the first-run flow.
+// It's kept in local settings, so each machine runs the flow once.
+type OnboardingState struct {
+	Completed  []string `json:"completed"`            // Steps done, in the order they were done
+	FinishedAt string   `json:"finishedAt,omitempty"` // RFC3339; set once every step is done
+}
+
+// OnboardingProgress is OnboardingState for the frontend
+type OnboardingProgress struct {
+	Steps     []OnboardingStep `json:"steps"`
+	Next      string           `json:"next,omitempty"` // First step not done yet; empty when finished
+	Finished  bool             `json:"finished"`
+	Completed int              `json:"completed"`
+}
+
+// OnboardingStep is one step and whether it's done
+type OnboardingStep struct {
+	ID   string `json:"id"`
+	Done bool   `json:"done"`
+}
+
+// GetOnboardingState returns the first-run flow's steps and where to
+// resume it. Machines set up before the flow existed have nothing to do.
+func (a *App) GetOnboardingState() OnboardingProgress {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	state := a.settings.Onboarding
+	finished := state == nil || state.FinishedAt != ""
+	done := make(map[string]bool)
+	if state != nil {
+		for _, step := range state.Completed {
+			done[step] = true
+		}
+	}
+
+	progress := OnboardingProgress{Steps: []OnboardingStep{}, Finished: finished}
+	for _, id := range onboardingSteps {
+		stepDone := finished || done[id]
+		progress.Steps = append(progress.Steps, OnboardingStep{ID: id, Done: stepDone})
+		if stepDone {
+			progress.Completed++
+		} else if progress.Next == "" {
+			progress.Next = id
+		}
+	}
+	return progress
+}
+
+// CompleteOnboardingStep marks a step of the first-run flow as done. Steps
+// can be done in any order, and completing one twice is harmless.
+func (a *App) CompleteOnboardingStep(step string) error {
+	known := false
+	for _, id := range onboardingSteps {
+		known = known || id == step
+	}
+	if !known {
+		return errors.New("unknown onboarding step")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state := a.settings.Onboarding
+	if state == nil || state.FinishedAt != "" {
+		return nil
+	}
+	for _, id := range state.Completed {
+		if id == step {
+			return nil
+		}
+	}
+
+	state.Completed = append(state.Completed, step)
+	if len(state.Completed) == len(onboardingSteps) {
+		state.FinishedAt = time.Now().Format(time.RFC3339)
+	}
+	return a.saveSettingsLocked()
+}
+
+// startOnboardingLocked begins the first-run flow on a fresh install
+// (must hold lock; caller saves settings)
+func (a *App) startOnboardingLocked() {
+	if a.settings.Onboarding == nil {
+		a.settings.Onboarding = &OnboardingState{Completed: []string{}}
+	}
+}
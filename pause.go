@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// DateRange is an inclusive range of "2006-01-02" dates
+type DateRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// contains reports whether date falls within the range
+func (r DateRange) contains(date string) bool {
+	return r.From <= date && date <= r.To
+}
+
+// rangesContain reports whether any range includes date
+func rangesContain(ranges []DateRange, date string) bool {
+	for _, r := range ranges {
+		if r.contains(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// newDateRange validates and orders a date range
+func newDateRange(from, to string) (DateRange, error) {
+	if _, err := time.Parse("2006-01-02", from); err != nil {
+		return DateRange{}, errors.New("invalid start date")
+	}
+	if _, err := time.Parse("2006-01-02", to); err != nil {
+		return DateRange{}, errors.New("invalid end date")
+	}
+	if to < from {
+		from, to = to, from
+	}
+	return DateRange{From: from, To: to}, nil
+}
+
+// SetTaskPaused pauses a task for an inclusive date range. Paused days are
+// hidden from the day view and excluded from reports and streaks.
+// An empty from clears all pauses for the task.
+func (a *App) SetTaskPaused(taskID string, from string, to string) error {
+	var r DateRange
+	if from != "" {
+		var err error
+		if r, err = newDateRange(from, to); err != nil {
+			return err
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID != taskID {
+			continue
+		}
+		if from == "" {
+			a.data.Templates[i].Paused = nil
+			a.audit("SetTaskPaused", "", taskID, t.Paused, nil)
+		} else {
+			a.data.Templates[i].Paused = append(a.data.Templates[i].Paused, r)
+			a.audit("SetTaskPaused", r.From, taskID, nil, r)
+		}
+		return a.saveDataLocked()
+	}
+
+	return errors.New("task not found")
+}
+
+// SetVacation marks an inclusive date range as vacation. Vacation days are
+// excluded from every report and don't break streaks.
+func (a *App) SetVacation(from string, to string) error {
+	r, err := newDateRange(from, to)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.data.Vacations = append(a.data.Vacations, r)
+	a.audit("SetVacation", r.From, "", nil, r)
+	return a.saveDataLocked()
+}
+
+// RemoveVacation deletes a vacation range previously added with SetVacation
+func (a *App) RemoveVacation(from string, to string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, r := range a.data.Vacations {
+		if r.From == from && r.To == to {
+			a.data.Vacations = append(a.data.Vacations[:i], a.data.Vacations[i+1:]...)
+			a.audit("RemoveVacation", from, "", r, nil)
+			return a.saveDataLocked()
+		}
+	}
+
+	return nil
+}
+
+// GetVacations returns all vacation ranges
+func (a *App) GetVacations() []DateRange {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return append([]DateRange{}, a.data.Vacations...)
+}
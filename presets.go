@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"strings"
+)
+
+// PresetTask is a task definition inside a preset
+type PresetTask struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Unit         string `json:"unit,omitempty"`
+	Target       int    `json:"target,omitempty"`
+	TimesPerWeek int    `json:"timesPerWeek,omitempty"`
+}
+
+// Preset is a curated bundle of tasks that can be added in one step
+type Preset struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Tasks       []PresetTask `json:"tasks"`
+}
+
+// defaultPresetID is the preset used to seed a fresh install
+const defaultPresetID = "daily-basics"
+
+// presets is the built-in preset library
+var presets = []Preset{
+	{
+		ID:          defaultPresetID,
+		Name:        "Daily basics",
+		Description: "A simple structure for every day.",
+		Tasks: []PresetTask{
+			{Name: "Morning Routine", Type: "binary"},
+			{Name: "Deep Work", Type: "binary"},
+			{Name: "Exercise", Type: "binary"},
+			{Name: "Evening Review", Type: "binary"},
+		},
+	},
+	{
+		ID:          "fitness-starter",
+		Name:        "Fitness starter",
+		Description: "Move every day and build up strength and sleep habits.",
+		Tasks: []PresetTask{
+			{Name: "Workout", Type: "duration", Unit: "min", Target: 30, TimesPerWeek: 3},
+			{Name: "Steps", Type: "count", Unit: "steps", Target: 8000},
+			{Name: "Stretch", Type: "binary"},
+			{Name: "Water", Type: "count", Unit: "glasses", Target: 8},
+			{Name: "Sleep", Type: "value", Unit: "hrs"},
+		},
+	},
+	{
+		ID:          "deep-work",
+		Name:        "Deep work",
+		Description: "Protect focused time and keep distractions in check.",
+		Tasks: []PresetTask{
+			{Name: "Plan the day", Type: "binary"},
+			{Name: "Deep Work", Type: "duration", Unit: "min", Target: 120},
+			{Name: "No social media", Type: "negative"},
+			{Name: "Inbox zero", Type: "binary"},
+			{Name: "Shutdown ritual", Type: "binary"},
+		},
+	},
+	{
+		ID:          "student",
+		Name:        "Student",
+		Description: "Steady study habits through the term.",
+		Tasks: []PresetTask{
+			{Name: "Attend classes", Type: "binary"},
+			{Name: "Study", Type: "duration", Unit: "min", Target: 90},
+			{Name: "Review notes", Type: "binary"},
+			{Name: "Read", Type: "count", Unit: "pages", Target: 20},
+			{Name: "Energy", Type: "scale"},
+		},
+	},
+}
+
+// GetPresets returns the built-in preset library
+func (a *App) GetPresets() []Preset {
+	return append([]Preset{}, presets...)
+}
+
+// ApplyPreset adds a preset's tasks, skipping any whose name matches an
+// existing task. Returns the tasks that were created.
+func (a *App) ApplyPreset(id string) ([]TaskTemplate, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	created, err := a.applyPresetLocked(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(created) == 0 {
+		return created, nil
+	}
+	for _, task := range created {
+		a.audit("ApplyPreset", "", task.ID, nil, task.Name)
+	}
+	if err := a.saveDataLocked(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// applyPresetLocked merges a preset into the templates (must hold lock; caller saves)
+func (a *App) applyPresetLocked(id string) ([]TaskTemplate, error) {
+	var preset *Preset
+	for i := range presets {
+		if presets[i].ID == id {
+			preset = &presets[i]
+			break
+		}
+	}
+	if preset == nil {
+		return nil, errors.New("preset not found")
+	}
+
+	return a.addPresetTasksLocked(*preset), nil
+}
+
+// addPresetTasksLocked adds a preset's tasks whose names aren't taken yet
+// (must hold lock; caller saves and audits)
+func (a *App) addPresetTasksLocked(preset Preset) []TaskTemplate {
+	existing := make(map[string]bool)
+	for _, t := range a.data.Templates {
+		if t.DeletedAt == nil {
+			existing[strings.ToLower(strings.TrimSpace(t.Name))] = true
+		}
+	}
+
+	created := []TaskTemplate{}
+	for _, pt := range preset.Tasks {
+		key := strings.ToLower(pt.Name)
+		if existing[key] {
+			continue
+		}
+		existing[key] = true
+
+		a.addTaskLocked(pt.Name, pt.Type, pt.Unit)
+		task := &a.data.Templates[len(a.data.Templates)-1]
+		task.Target = pt.Target
+		task.TimesPerWeek = pt.TimesPerWeek
+		created = append(created, *task)
+	}
+
+	return created
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// Task priorities. PriorityNone keeps older tasks unranked.
+const (
+	PriorityNone   = 0
+	PriorityLow    = 1
+	PriorityMedium = 2
+	PriorityHigh   = 3
+)
+
+// Sort modes for task lists
+const (
+	sortModeManual   = "manual"
+	sortModePriority = "priority"
+)
+
+// PriorityFlag marks a high-priority task left unfinished during a week
+type PriorityFlag struct {
+	TaskID   string   `json:"taskId"`
+	TaskName string   `json:"taskName"`
+	Missed   []string `json:"missed"` // Dates the task wasn't done
+}
+
+// SetTaskPriority sets a task's priority (0 none, 1 low, 2 medium, 3 high)
+func (a *App) SetTaskPriority(id string, priority int) error {
+	if priority < PriorityNone || priority > PriorityHigh {
+		return errors.New("priority must be between 0 and 3")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			a.data.Templates[i].Priority = priority
+			a.audit("SetTaskPriority", "", id, t.Priority, priority)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("task not found")
+}
+
+// GetSortMode returns how task lists are ordered: "manual" or "priority"
+func (a *App) GetSortMode() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.data.SortMode == "" {
+		return sortModeManual
+	}
+	return a.data.SortMode
+}
+
+// SetSortMode orders task lists by manual order or by priority. Groups keep
+// their order either way; priority only reorders tasks within a group.
+func (a *App) SetSortMode(mode string) error {
+	if mode != sortModeManual && mode != sortModePriority {
+		return errors.New("sort mode must be manual or priority")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.audit("SetSortMode", "", "", a.data.SortMode, mode)
+	a.data.SortMode = mode
+	return a.saveDataLocked()
+}
+
+// unfinishedPriorityLocked lists high-priority tasks not done on past or
+// current days of the week starting at start (must hold lock)
+func (a *App) unfinishedPriorityLocked(start time.Time) []PriorityFlag {
+	today := time.Now().Format("2006-01-02")
+	flags := []PriorityFlag{}
+	index := make(map[string]int)
+
+	for d := 0; d < 7; d++ {
+		dateKey := start.AddDate(0, 0, d).Format("2006-01-02")
+		if dateKey > today {
+			break
+		}
+		if a.dayExcludedLocked(dateKey) {
+			continue
+		}
+
+		for _, task := range a.getDailyTasksForDateLocked(dateKey) {
+			if task.Priority != PriorityHigh || taskSucceeded(task, dateKey, a.data.Days[dateKey][task.ID]) {
+				continue
+			}
+			i, ok := index[task.ID]
+			if !ok {
+				i = len(flags)
+				index[task.ID] = i
+				flags = append(flags, PriorityFlag{TaskID: task.ID, TaskName: task.Name})
+			}
+			flags[i].Missed = append(flags[i].Missed, dateKey)
+		}
+	}
+
+	return flags
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DayProfile is a kind of day, like "Workday", "Weekend" or "Travel". A day
+// has at most one profile: the one assigned to its date, else the one whose
+// weekdays include it. Tasks that opt into profiles only apply on days with
+// one of them; other tasks apply whatever the profile.
+type DayProfile struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Weekdays []int  `json:"weekdays,omitempty"` // 0 = Sunday .. 6 = Saturday
+}
+
+// AddDayProfile creates a profile applying on weekdays (0 = Sunday); none
+// means it only applies to dates it's assigned to
+func (a *App) AddDayProfile(name string, weekdays []int) (DayProfile, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	profile := DayProfile{ID: uuid.New().String()}
+	if err := a.setDayProfileLocked(&profile, name, weekdays); err != nil {
+		return DayProfile{}, err
+	}
+	a.data.DayProfiles = append(a.data.DayProfiles, profile)
+	a.audit("AddDayProfile", "", "", nil, profile)
+	return profile, a.saveDataLocked()
+}
+
+// UpdateDayProfile renames a profile and changes its weekdays
+func (a *App) UpdateDayProfile(id string, name string, weekdays []int) (DayProfile, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := range a.data.DayProfiles {
+		if a.data.DayProfiles[i].ID != id {
+			continue
+		}
+		old := a.data.DayProfiles[i]
+		profile := old
+		if err := a.setDayProfileLocked(&profile, name, weekdays); err != nil {
+			return DayProfile{}, err
+		}
+		a.data.DayProfiles[i] = profile
+		a.audit("UpdateDayProfile", "", "", old, profile)
+		return profile, a.saveDataLocked()
+	}
+	return DayProfile{}, errors.New("profile not found")
+}
+
+// setDayProfileLocked validates and sets a profile's name and weekdays. A
+// weekday can only belong to one profile (must hold lock).
+func (a *App) setDayProfileLocked(profile *DayProfile, name string, weekdays []int) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	days := []int{}
+	for _, d := range weekdays {
+		if d < 0 || d > 6 {
+			return errors.New("weekdays must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		if !slices.Contains(days, d) {
+			days = append(days, d)
+		}
+	}
+	slices.Sort(days)
+
+	for _, other := range a.data.DayProfiles {
+		if other.ID == profile.ID {
+			continue
+		}
+		if strings.EqualFold(other.Name, name) {
+			return errors.New("a profile with that name already exists")
+		}
+		for _, d := range days {
+			if slices.Contains(other.Weekdays, d) {
+				return errors.New(time.Weekday(d).String() + " already belongs to " + other.Name)
+			}
+		}
+	}
+
+	profile.Name = name
+	profile.Weekdays = days
+	return nil
+}
+
+// RemoveDayProfile deletes a profile, unassigning it from dates and tasks.
+// Tasks left without profiles apply every day again.
+func (a *App) RemoveDayProfile(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	i := slices.IndexFunc(a.data.DayProfiles, func(p DayProfile) bool { return p.ID == id })
+	if i < 0 {
+		return errors.New("profile not found")
+	}
+	profile := a.data.DayProfiles[i]
+	a.data.DayProfiles = slices.Delete(a.data.DayProfiles, i, i+1)
+
+	for date, assigned := range a.data.ProfileDates {
+		if assigned == id {
+			delete(a.data.ProfileDates, date)
+		}
+	}
+	for j := range a.data.Templates {
+		a.data.Templates[j].Profiles = slices.DeleteFunc(a.data.Templates[j].Profiles, func(p string) bool { return p == id })
+	}
+	a.audit("RemoveDayProfile", "", "", profile, nil)
+	return a.saveDataLocked()
+}
+
+// GetDayProfiles returns every day profile
+func (a *App) GetDayProfiles() []DayProfile {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return append([]DayProfile{}, a.data.DayProfiles...)
+}
+
+// SetDateProfile assigns a profile to a date, overriding its weekday's
+// profile; "" goes back to the weekday's profile
+func (a *App) SetDateProfile(date string, profileID string) error {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return errors.New("invalid date")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkEditableLocked(date); err != nil {
+		return err
+	}
+
+	if profileID != "" && !slices.ContainsFunc(a.data.DayProfiles, func(p DayProfile) bool { return p.ID == profileID }) {
+		return errors.New("profile not found")
+	}
+	old := a.data.ProfileDates[date]
+	if profileID == "" {
+		delete(a.data.ProfileDates, date)
+	} else {
+		if a.data.ProfileDates == nil {
+			a.data.ProfileDates = make(map[string]string)
+		}
+		a.data.ProfileDates[date] = profileID
+	}
+	a.audit("SetDateProfile", date, "", old, profileID)
+	return a.saveDataLocked()
+}
+
+// GetProfileForDate returns the profile a date has, if any
+func (a *App) GetProfileForDate(date string) (*DayProfile, error) {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return nil, errors.New("invalid date")
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.profileForDateLocked(date), nil
+}
+
+// SetTaskProfiles limits a task to days with one of the given profiles;
+// none makes it apply whatever the profile
+func (a *App) SetTaskProfiles(taskID string, profileIDs []string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	profiles := []string{}
+	for _, id := range profileIDs {
+		if !slices.ContainsFunc(a.data.DayProfiles, func(p DayProfile) bool { return p.ID == id }) {
+			return errors.New("profile not found")
+		}
+		if !slices.Contains(profiles, id) {
+			profiles = append(profiles, id)
+		}
+	}
+
+	i := a.templateIndexLocked(taskID)
+	if i < 0 {
+		return errors.New("task not found")
+	}
+	old := a.data.Templates[i].Profiles
+	if len(profiles) == 0 {
+		profiles = nil
+	}
+	a.data.Templates[i].Profiles = profiles
+	a.audit("SetTaskProfiles", "", taskID, old, profiles)
+	return a.saveDataLocked()
+}
+
+// profileForDateLocked resolves a date's profile: its own assignment
+// first, then its weekday's (must hold lock)
+func (a *App) profileForDateLocked(date string) *DayProfile {
+	if id, ok := a.data.ProfileDates[date]; ok {
+		for i := range a.data.DayProfiles {
+			if a.data.DayProfiles[i].ID == id {
+				profile := a.data.DayProfiles[i]
+				return &profile
+			}
+		}
+	}
+
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil
+	}
+	for _, p := range a.data.DayProfiles {
+		if slices.Contains(p.Weekdays, int(t.Weekday())) {
+			return &p
+		}
+	}
+	return nil
+}
+
+// taskAppliesLocked reports whether a task applies to a date, taking the
+// date's profile into account as well as the task's own dates and
+// schedule (see taskActiveOn) (must hold lock)
+func (a *App) taskAppliesLocked(t TaskTemplate, date string) bool {
+	if !taskActiveOn(t, date) {
+		return false
+	}
+	if len(t.Profiles) == 0 {
+		return true
+	}
+	profile := a.profileForDateLocked(date)
+	return profile != nil && slices.Contains(t.Profiles, profile.ID)
+}
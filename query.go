@@ -0,0 +1,403 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxQueryRows caps how many rows a query returns
+const maxQueryRows = 5000
+
+// QueryResult is a table produced by Query
+type QueryResult struct {
+	Columns   []string   `json:"columns"`
+	Rows      [][]string `json:"rows"`
+	Truncated bool       `json:"truncated"` // More rows matched than maxQueryRows
+}
+
+// queryCondition is a "field op number" comparison from a where clause
+type queryCondition struct {
+	field string
+	op    string
+	value float64
+}
+
+// parsedQuery is a query expression broken into its filters
+type parsedQuery struct {
+	days       bool     // One row per day instead of per task and day
+	tasks      []string // Lowercased task names; empty for all tasks
+	taskType   string
+	status     string // "done", "missed" or "skipped"
+	from, to   string
+	conditions []queryCondition
+}
+
+// Query evaluates a small filter expression over the recorded data.
+//
+//	task:"Exercise" done range:2025-01..2025-03
+//	days where score < 50 range:2025
+//	type:count where value >= 10
+//
+// Terms: task:NAME (repeatable), type:TYPE, done|missed|skipped,
+// range:FROM..TO (years, months or dates), days, and where clauses on
+// score or value joined with "and".
+func (a *App) Query(expr string) (QueryResult, error) {
+	q, err := parseQuery(expr)
+	if err != nil {
+		return QueryResult{}, err
+	}
+
+	defer a.lockDays(q.from, q.to)()
+
+	return a.runQueryLocked(q)
+}
+
+// ExportQueryCSV runs a query and saves its result as CSV in the export
+// folder. Returns the file path.
+func (a *App) ExportQueryCSV(expr string) (string, error) {
+	result, err := a.Query(expr)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(result.Columns)
+	w.WriteAll(result.Rows)
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+
+	filename := "PLAN_query_" + time.Now().Format("2006-01-02_150405") + ".csv"
+	return a.writeExportFile(filename, buf.Bytes())
+}
+
+// tokenizeQuery splits an expression on spaces, keeping double-quoted
+// text together
+func tokenizeQuery(expr string) ([]string, error) {
+	tokens := []string{}
+	var current strings.Builder
+	quoted, started := false, false
+
+	for _, r := range expr {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			started = true
+		case (r == ' ' || r == '\t') && !quoted:
+			if started {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+	if quoted {
+		return nil, errors.New("unterminated quote")
+	}
+	if started {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// parseQuery turns an expression into its filters
+func parseQuery(expr string) (parsedQuery, error) {
+	q := parsedQuery{}
+	tokens, err := tokenizeQuery(expr)
+	if err != nil {
+		return q, err
+	}
+	if len(tokens) == 0 {
+		return q, errors.New("query is empty")
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		lower := strings.ToLower(token)
+
+		switch {
+		case lower == "days":
+			q.days = true
+		case lower == "done" || lower == "missed" || lower == "skipped":
+			q.status = lower
+		case lower == "where":
+			for {
+				if i+3 >= len(tokens) {
+					return q, errors.New("where needs a field, an operator and a number")
+				}
+				cond, err := parseQueryCondition(tokens[i+1], tokens[i+2], tokens[i+3])
+				if err != nil {
+					return q, err
+				}
+				q.conditions = append(q.conditions, cond)
+				i += 3
+				if i+1 < len(tokens) && strings.ToLower(tokens[i+1]) == "and" {
+					i++
+					continue
+				}
+				break
+			}
+		case strings.HasPrefix(lower, "task:"):
+			name := strings.TrimSpace(token[len("task:"):])
+			if name == "" {
+				return q, errors.New("task: needs a name")
+			}
+			q.tasks = append(q.tasks, strings.ToLower(name))
+		case strings.HasPrefix(lower, "type:"):
+			q.taskType = lower[len("type:"):]
+			if !isValidTaskType(q.taskType) {
+				return q, fmt.Errorf("unknown task type %q", q.taskType)
+			}
+		case strings.HasPrefix(lower, "range:"):
+			if q.from, q.to, err = parseQueryRange(token[len("range:"):]); err != nil {
+				return q, err
+			}
+		default:
+			return q, fmt.Errorf("unknown term %q", token)
+		}
+	}
+
+	if q.days && (len(q.tasks) > 0 || q.taskType != "" || q.status != "") {
+		return q, errors.New("days can't be combined with task filters")
+	}
+	for _, cond := range q.conditions {
+		if q.days && cond.field != "score" {
+			return q, errors.New("days can only be filtered by score")
+		}
+	}
+	return q, nil
+}
+
+// parseQueryCondition reads one "field op number" comparison
+func parseQueryCondition(field, op, number string) (queryCondition, error) {
+	field = strings.ToLower(field)
+	if field != "score" && field != "value" {
+		return queryCondition{}, fmt.Errorf("unknown field %q", field)
+	}
+	switch op {
+	case "<", "<=", ">", ">=", "=", "!=":
+	default:
+		return queryCondition{}, fmt.Errorf("unknown operator %q", op)
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return queryCondition{}, fmt.Errorf("%q is not a number", number)
+	}
+	return queryCondition{field: field, op: op, value: value}, nil
+}
+
+// matches reports whether a number satisfies the condition
+func (c queryCondition) matches(v float64) bool {
+	switch c.op {
+	case "<":
+		return v < c.value
+	case "<=":
+		return v <= c.value
+	case ">":
+		return v > c.value
+	case ">=":
+		return v >= c.value
+	case "=":
+		return v == c.value
+	default:
+		return v != c.value
+	}
+}
+
+// parseQueryRange reads FROM..TO where each end is a year, month or date.
+// A single period stands for its whole span.
+func parseQueryRange(s string) (from string, to string, err error) {
+	start, end, found := strings.Cut(s, "..")
+	if !found {
+		end = start
+	}
+	if from, err = expandPeriod(start, false); err != nil {
+		return "", "", err
+	}
+	if to, err = expandPeriod(end, true); err != nil {
+		return "", "", err
+	}
+	if to < from {
+		return "", "", errors.New("range ends before it starts")
+	}
+	return from, to, nil
+}
+
+// expandPeriod turns "2025", "2025-03" or "2025-03-14" into its first date,
+// or its last date when last is set
+func expandPeriod(p string, last bool) (string, error) {
+	switch len(p) {
+	case 4:
+		if _, err := time.Parse("2006", p); err == nil {
+			if last {
+				return p + "-12-31", nil
+			}
+			return p + "-01-01", nil
+		}
+	case 7:
+		if t, err := time.Parse("2006-01", p); err == nil {
+			if last {
+				t = t.AddDate(0, 1, -1)
+			}
+			return t.Format("2006-01-02"), nil
+		}
+	case 10:
+		if _, err := time.Parse("2006-01-02", p); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("invalid range %q", p)
+}
+
+// runQueryLocked evaluates a parsed query (must hold lock)
+func (a *App) runQueryLocked(q parsedQuery) (QueryResult, error) {
+	from, to := q.from, q.to
+	today := time.Now().Format("2006-01-02")
+	if from == "" {
+		from = today
+		for date := range a.data.Days {
+			if date < from {
+				from = date
+			}
+		}
+	}
+	if to == "" || to > today {
+		to = today
+	}
+
+	known := make(map[string]bool)
+	for _, t := range a.data.Templates {
+		known[strings.ToLower(t.Name)] = true
+	}
+	for _, name := range q.tasks {
+		if !known[name] {
+			return QueryResult{}, fmt.Errorf("no task named %q", name)
+		}
+	}
+
+	result := QueryResult{Columns: []string{"Date", "Task", "Value", "Status"}, Rows: [][]string{}}
+	if q.days {
+		result.Columns = []string{"Date", "Score", "Done", "Tasks"}
+	}
+
+	start, _ := time.Parse("2006-01-02", from)
+	end, _ := time.Parse("2006-01-02", to)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		if a.dayExcludedLocked(date) {
+			continue
+		}
+		score, scored := a.dayScoreLocked(date)
+
+		if q.days {
+			if !scored || !q.matchesAll("score", score) {
+				continue
+			}
+			tasks := a.getDailyTasksForDateLocked(date)
+			done := 0
+			for _, task := range tasks {
+				if taskSucceeded(task, date, a.data.Days[date][task.ID]) {
+					done++
+				}
+			}
+			if !result.add([]string{date, fmt.Sprintf("%.0f", score), strconv.Itoa(done), strconv.Itoa(len(tasks))}) {
+				break
+			}
+			continue
+		}
+
+		for _, task := range a.getTasksForDateLocked(date) {
+			if !q.includesTask(task) {
+				continue
+			}
+
+			value, recorded := a.data.Days[date][task.ID]
+			number := float64(value)
+			shown := strconv.Itoa(value)
+			if isValueTask(task) {
+				reading, ok := a.data.Measurements[date][task.ID]
+				recorded = ok
+				number = reading
+				shown = strconv.FormatFloat(reading, 'f', -1, 64)
+			}
+			if !recorded {
+				shown = ""
+			}
+
+			status := "missed"
+			if a.taskSkippedLocked(date, task.ID) {
+				status = "skipped"
+			} else if recorded && taskSucceeded(task, date, value) {
+				status = "done"
+			}
+			if q.status != "" && status != q.status {
+				continue
+			}
+			if !q.matchesAll("value", number) || (!scored && q.uses("score")) || !q.matchesAll("score", score) {
+				continue
+			}
+
+			if !result.add([]string{date, task.Name, shown, status}) {
+				return result, nil
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// includesTask reports whether a task passes the name and type filters
+func (q parsedQuery) includesTask(task TaskTemplate) bool {
+	if q.taskType != "" && taskTypeOf(task) != q.taskType {
+		return false
+	}
+	if len(q.tasks) == 0 {
+		return true
+	}
+	name := strings.ToLower(task.Name)
+	for _, wanted := range q.tasks {
+		if name == wanted {
+			return true
+		}
+	}
+	return false
+}
+
+// uses reports whether any condition filters on field
+func (q parsedQuery) uses(field string) bool {
+	for _, cond := range q.conditions {
+		if cond.field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAll checks v against every condition on field
+func (q parsedQuery) matchesAll(field string, v float64) bool {
+	for _, cond := range q.conditions {
+		if cond.field == field && !cond.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// add appends a row unless the row limit is reached
+func (r *QueryResult) add(row []string) bool {
+	if len(r.Rows) >= maxQueryRows {
+		r.Truncated = true
+		return false
+	}
+	r.Rows = append(r.Rows, row)
+	return true
+}
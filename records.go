@@ -0,0 +1,161 @@
+package main
+
+import "time"
+
+// PersonalRecord holds a count or duration task's best results
+type PersonalRecord struct {
+	TaskID        string `json:"taskId"`
+	BestDay       int    `json:"bestDay"`
+	BestDayDate   string `json:"bestDayDate,omitempty"`
+	BestWeek      int    `json:"bestWeek"`
+	BestWeekStart string `json:"bestWeekStart,omitempty"`
+}
+
+// RecordBreak describes a record broken by a save
+type RecordBreak struct {
+	TaskID   string `json:"taskId"`
+	TaskName string `json:"taskName"`
+	Kind     string `json:"kind"` // "day" or "week"
+	Previous int    `json:"previous"`
+	Value    int    `json:"value"`
+}
+
+// tracksRecords reports whether a task type has meaningful maxima
+func tracksRecords(t TaskTemplate) bool {
+	switch taskTypeOf(t) {
+	case "count", "duration":
+		return true
+	}
+	return false
+}
+
+// GetPersonalRecords returns personal bests keyed by task ID
+func (a *App) GetPersonalRecords() map[string]PersonalRecord {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.data.Records == nil {
+		a.rebuildRecordsLocked()
+	}
+
+	result := make(map[string]PersonalRecord)
+	for k, v := range a.data.Records {
+		result[k] = v
+	}
+	return result
+}
+
+// rebuildRecordsLocked recomputes every record from history, archived
+// years included (must hold write lock)
+func (a *App) rebuildRecordsLocked() {
+	a.data.Records = make(map[string]PersonalRecord)
+	loaded := a.loadArchivedDaysLocked("", "")
+	defer a.unloadArchivedDaysLocked(loaded)
+
+	weekTotals := make(map[string]map[string]int) // taskID -> weekStart -> total
+	for date, dayTasks := range a.data.Days {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			continue
+		}
+		weekKey := weekStartOf(day).Format("2006-01-02")
+
+		for _, t := range a.data.Templates {
+			value, ok := dayTasks[t.ID]
+			if !ok || !tracksRecords(t) {
+				continue
+			}
+
+			rec := a.data.Records[t.ID]
+			rec.TaskID = t.ID
+			if value > rec.BestDay || (value == rec.BestDay && date < rec.BestDayDate) {
+				rec.BestDay = value
+				rec.BestDayDate = date
+			}
+			a.data.Records[t.ID] = rec
+
+			if weekTotals[t.ID] == nil {
+				weekTotals[t.ID] = make(map[string]int)
+			}
+			weekTotals[t.ID][weekKey] += value
+		}
+	}
+
+	for taskID, weeks := range weekTotals {
+		rec := a.data.Records[taskID]
+		for weekKey, total := range weeks {
+			if total > rec.BestWeek || (total == rec.BestWeek && weekKey < rec.BestWeekStart) {
+				rec.BestWeek = total
+				rec.BestWeekStart = weekKey
+			}
+		}
+		a.data.Records[taskID] = rec
+	}
+}
+
+// updateRecordsLocked checks the given tasks on a date against their
+// records and returns any records broken (must hold lock; caller saves)
+func (a *App) updateRecordsLocked(date string, taskIDs []string) []RecordBreak {
+	breaks := []RecordBreak{}
+
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return breaks
+	}
+	if a.data.Records == nil {
+		// First run: seed from history so old bests aren't reported as new
+		a.rebuildRecordsLocked()
+		return breaks
+	}
+
+	weekStart := weekStartOf(day)
+	weekKey := weekStart.Format("2006-01-02")
+
+	for _, id := range taskIDs {
+		t, ok := a.findTemplateLocked(id)
+		if !ok || !tracksRecords(t) {
+			continue
+		}
+
+		rec := a.data.Records[id]
+		rec.TaskID = id
+		value := a.data.Days[date][id]
+
+		// A lowered record-holding value means an older day may now be best
+		if date == rec.BestDayDate && value < rec.BestDay {
+			a.rebuildRecordsLocked()
+			rec = a.data.Records[id]
+			rec.TaskID = id
+		}
+
+		if value > rec.BestDay {
+			if rec.BestDay > 0 {
+				breaks = append(breaks, RecordBreak{TaskID: id, TaskName: t.Name, Kind: "day", Previous: rec.BestDay, Value: value})
+			}
+			rec.BestDay = value
+			rec.BestDayDate = date
+		}
+
+		weekTotal := 0
+		for i := 0; i < 7; i++ {
+			weekTotal += a.data.Days[weekStart.AddDate(0, 0, i).Format("2006-01-02")][id]
+		}
+		// Likewise a lowered record-holding week
+		if weekKey == rec.BestWeekStart && weekTotal < rec.BestWeek {
+			a.rebuildRecordsLocked()
+			rec = a.data.Records[id]
+			rec.TaskID = id
+		}
+		if weekTotal > rec.BestWeek {
+			if rec.BestWeek > 0 && rec.BestWeekStart != weekKey {
+				breaks = append(breaks, RecordBreak{TaskID: id, TaskName: t.Name, Kind: "week", Previous: rec.BestWeek, Value: weekTotal})
+			}
+			rec.BestWeek = weekTotal
+			rec.BestWeekStart = weekKey
+		}
+
+		a.data.Records[id] = rec
+	}
+
+	return breaks
+}
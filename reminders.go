@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// reminderCheckInterval is how often due reminders are checked
+const reminderCheckInterval = time.Minute
+
+// SetTaskReminder sets the time of day ("15:04") at which to remind the user
+// about a task that is still incomplete. An empty time removes the reminder.
+func (a *App) SetTaskReminder(taskID string, reminderTime string) error {
+	if reminderTime != "" {
+		if _, err := time.Parse("15:04", reminderTime); err != nil {
+			return errors.New("invalid reminder time")
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID == taskID {
+			a.data.Templates[i].ReminderTime = reminderTime
+			a.audit("SetTaskReminder", "", taskID, t.ReminderTime, reminderTime)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("task not found")
+}
+
+// runReminders checks for due reminders and future notes until the app
+// shuts down. Reminders whose time passed while the app was closed fire
+// once on startup.
+func (a *App) runReminders() {
+	a.sendDueReminders()
+	a.revealDueNotes()
+	a.sendDueWeekSummary()
+	a.sendDuePlanReminder()
+
+	ticker := time.NewTicker(reminderCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.sendDueReminders()
+			a.revealDueNotes()
+			a.sendDueWeekSummary()
+			a.sendDuePlanReminder()
+		}
+	}
+}
+
+// sendDueReminders notifies about every task whose reminder time has passed
+// today and that isn't done yet. Each reminder fires at most once a day.
+func (a *App) sendDueReminders() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	today := now.Format("2006-01-02")
+	clock := now.Format("15:04")
+	if a.dayExcludedLocked(today) {
+		return
+	}
+
+	sent := false
+	for _, task := range a.getTasksForDateLocked(today) {
+		if task.ReminderTime == "" || task.ReminderTime > clock {
+			continue
+		}
+		if a.data.RemindersSent[task.ID] == today {
+			continue
+		}
+		if taskSucceeded(task, today, a.data.Days[today][task.ID]) {
+			continue
+		}
+
+		if a.data.RemindersSent == nil {
+			a.data.RemindersSent = make(map[string]string)
+		}
+		a.data.RemindersSent[task.ID] = today
+		a.notifyLocked("reminder", task.Name, "Still to do today")
+		sent = true
+	}
+
+	if sent {
+		if err := a.saveDataLocked(); err != nil {
+			println("Error saving reminders:", err.Error())
+		}
+	}
+}
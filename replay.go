@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// replayFlag starts the app on a recorded dataset instead of the real data:
+//
+//	wails dev -appargs "--replay testdata/streak.json"
+const replayFlag = "--replay"
+
+// replayScript is a recorded dataset and the changes to play over it
+type replayScript struct {
+	Data  json.RawMessage `json:"data"` // PlannerData, as in data.json
+	Steps []replayStep    `json:"steps"`
+	Loop  bool            `json:"loop"` // Start again from the recorded data after the last step
+}
+
+// replayStep is one scripted change. Values are saved to Date as if the
+// user had checked them off, so records and warnings fire as usual; Event,
+// if set, is emitted as-is with Payload.
+type replayStep struct {
+	AfterMs int            `json:"afterMs"` // Delay since the previous step
+	Date    string         `json:"date,omitempty"`
+	Values  map[string]int `json:"values,omitempty"`
+	Event   string         `json:"event,omitempty"`
+	Payload any            `json:"payload,omitempty"`
+}
+
+// replayPathFromArgs returns the file given with --replay, if any
+func replayPathFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == replayFlag && i+1 < len(args) {
+			return args[i+1]
+		}
+		if path, ok := strings.CutPrefix(arg, replayFlag+"="); ok {
+			return path
+		}
+	}
+	return ""
+}
+
+// IsReplayMode reports whether the app is showing a recorded dataset
+// rather than the user's data
+func (a *App) IsReplayMode() bool {
+	return a.replayPath != ""
+}
+
+// startReplay loads the replay file into a throwaway data directory, so
+// nothing the frontend does can reach the real data, and starts playing
+// its steps
+func (a *App) startReplay() error {
+	content, err := os.ReadFile(a.replayPath)
+	if err != nil {
+		return err
+	}
+	var script replayScript
+	if err := json.Unmarshal(content, &script); err != nil {
+		return err
+	}
+	recorded, _, ok := decodePlannerData(script.Data)
+	if !ok {
+		return errors.New("replay file has no PLAN data")
+	}
+
+	dir, err := os.MkdirTemp("", "plan-replay-*")
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.replayDir = dir
+	a.settingsPath = filepath.Join(dir, "settings.json")
+	a.dataPath = filepath.Join(dir, "data.json")
+	a.actor = "replay"
+	a.data = recorded
+	err = a.saveDataLocked()
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	a.refreshMenu()
+	go a.runReplay(script)
+	return nil
+}
+
+// runReplay plays the script's steps until the app exits
+func (a *App) runReplay(script replayScript) {
+	for {
+		for _, step := range script.Steps {
+			select {
+			case <-a.ctx.Done():
+				return
+			case <-time.After(time.Duration(step.AfterMs) * time.Millisecond):
+			}
+			a.playReplayStep(step)
+		}
+		if !script.Loop || len(script.Steps) == 0 {
+			return
+		}
+
+		recorded, _, _ := decodePlannerData(script.Data)
+		a.mu.Lock()
+		a.data = recorded
+		a.clearUndoLocked()
+		a.mu.Unlock()
+		a.emitDataChanged("")
+	}
+}
+
+// playReplayStep applies one scripted change
+func (a *App) playReplayStep(step replayStep) {
+	if step.Date != "" {
+		values := a.LoadDay(step.Date).Values
+		for id, value := range step.Values {
+			values[id] = value
+		}
+		if _, err := a.SaveDay(step.Date, values); err != nil {
+			println("Error replaying step:", err.Error())
+		}
+		a.emitDataChanged(step.Date)
+	}
+	if step.Event != "" {
+		runtime.EventsEmit(a.ctx, step.Event, step.Payload)
+	}
+}
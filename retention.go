@@ -0,0 +1,357 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// maintenanceCheckInterval is how often the maintenance job checks whether
+// it has run today
+const maintenanceCheckInterval = time.Hour
+
+// RetentionPolicy is how long detail is kept, in days. Zero keeps it
+// forever. Day values and anything derived from them are never dropped,
+// though with ArchiveYears old years move out of data.json.
+type RetentionPolicy struct {
+	NotesDays    int  `json:"notesDays"`    // Annotations
+	DetailDays   int  `json:"detailDays"`   // Subitem ticks and individual focus sessions
+	AuditDays    int  `json:"auditDays"`    // Audit log entries
+	TrashDays    int  `json:"trashDays"`    // Deleted tasks, with everything recorded for them
+	Archive      bool `json:"archive"`      // Save dropped detail to the backups folder first
+	ArchiveYears int  `json:"archiveYears"` // Years kept in data.json before the current one; older days go to days-YYYY.json
+}
+
+// MaintenanceResult reports what a maintenance run dropped
+type MaintenanceResult struct {
+	Notes         int    `json:"notes"`
+	SubitemDays   int    `json:"subitemDays"`
+	FocusSessions int    `json:"focusSessions"` // Sessions folded into daily totals
+	AuditEntries  int    `json:"auditEntries"`
+	Tasks         int    `json:"tasks"`        // Deleted tasks purged from the trash
+	ArchivedDays  int    `json:"archivedDays"` // Days moved to per-year files
+	ArchivePath   string `json:"archivePath,omitempty"`
+}
+
+// retentionArchive is the file dropped detail is archived to
+type retentionArchive struct {
+	Created       string                         `json:"created"`
+	Annotations   []Annotation                   `json:"annotations,omitempty"`
+	SubitemDays   map[string]map[string][]string `json:"subitemDays,omitempty"`
+	FocusSessions []FocusSession                 `json:"focusSessions,omitempty"`
+	Audit         []AuditEntry                   `json:"audit,omitempty"`
+	Tasks         []TaskTemplate                 `json:"tasks,omitempty"`
+	TaskDays      map[string]DayTasks            `json:"taskDays,omitempty"` // Purged tasks' day values
+}
+
+// GetRetentionPolicy returns how long notes, detail, audit entries and
+// deleted tasks are kept
+func (a *App) GetRetentionPolicy() RetentionPolicy {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.data.Retention == nil {
+		return RetentionPolicy{}
+	}
+	return *a.data.Retention
+}
+
+// SetRetentionPolicy sets how long detail is kept. Nothing is dropped until
+// the next maintenance run.
+func (a *App) SetRetentionPolicy(policy RetentionPolicy) error {
+	for _, days := range []int{policy.NotesDays, policy.DetailDays, policy.AuditDays, policy.TrashDays} {
+		if days < 0 || days > 36500 {
+			return errors.New("retention must be between 0 (forever) and 36500 days")
+		}
+	}
+	if policy.ArchiveYears < 0 || policy.ArchiveYears > 100 {
+		return errors.New("archive years must be between 0 (never) and 100")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.audit("SetRetentionPolicy", "", "", a.data.Retention, policy)
+	if policy == (RetentionPolicy{}) {
+		a.data.Retention = nil
+	} else {
+		a.data.Retention = &policy
+	}
+	return a.saveDataLocked()
+}
+
+// RunMaintenance applies the retention policy now
+func (a *App) RunMaintenance() (MaintenanceResult, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.maintainLocked(time.Now())
+}
+
+// runMaintenance applies the retention policy once a day while the app runs
+func (a *App) runMaintenance() {
+	lastRun := ""
+	check := func() {
+		today := time.Now().Format("2006-01-02")
+		if today == lastRun {
+			return
+		}
+		lastRun = today
+		if _, err := a.RunMaintenance(); err != nil {
+			println("Error running maintenance:", err.Error())
+		}
+	}
+
+	check()
+	ticker := time.NewTicker(maintenanceCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+// maintainLocked drops detail older than the policy allows, archiving it
+// first if asked (must hold lock)
+func (a *App) maintainLocked(now time.Time) (MaintenanceResult, error) {
+	result := MaintenanceResult{}
+	if a.data.Retention == nil {
+		return result, nil
+	}
+	policy := *a.data.Retention
+	cutoff := func(days int) string {
+		if days == 0 {
+			return ""
+		}
+		return now.AddDate(0, 0, -days).Format("2006-01-02")
+	}
+	notesCutoff, detailCutoff, auditCutoff := cutoff(policy.NotesDays), cutoff(policy.DetailDays), cutoff(policy.AuditDays)
+	trashCutoff := cutoff(policy.TrashDays)
+
+	archive := retentionArchive{Created: now.Format(time.RFC3339)}
+
+	// Notes
+	keptNotes := []Annotation{}
+	for _, note := range a.data.Annotations {
+		if notesCutoff != "" && note.lastDate() < notesCutoff {
+			archive.Annotations = append(archive.Annotations, note)
+			continue
+		}
+		keptNotes = append(keptNotes, note)
+	}
+
+	// Subitem ticks; the day's value already records the task's outcome
+	var keptSubitems map[string]map[string][]string
+	if len(a.data.SubitemDays) > 0 {
+		keptSubitems = make(map[string]map[string][]string)
+		for date, subitems := range a.data.SubitemDays {
+			if detailCutoff != "" && date < detailCutoff {
+				if archive.SubitemDays == nil {
+					archive.SubitemDays = make(map[string]map[string][]string)
+				}
+				archive.SubitemDays[date] = subitems
+				continue
+			}
+			keptSubitems[date] = subitems
+		}
+	}
+
+	// Focus sessions are folded into one per task and day, keeping the
+	// totals and session counts focus reports are built from
+	keptSessions := []FocusSession{}
+	old := make(map[[2]string][]FocusSession)
+	for _, s := range a.data.FocusSessions {
+		if detailCutoff == "" || s.Date >= detailCutoff {
+			keptSessions = append(keptSessions, s)
+			continue
+		}
+		key := [2]string{s.TaskID, s.Date}
+		old[key] = append(old[key], s)
+	}
+	for _, sessions := range old {
+		merged := sessions[0]
+		if len(sessions) > 1 {
+			archive.FocusSessions = append(archive.FocusSessions, sessions...)
+			merged.Count = 0
+			merged.Minutes = 0
+			for _, s := range sessions {
+				merged.Count += s.count()
+				merged.Minutes += s.Minutes
+				if s.Start < merged.Start {
+					merged.Start = s.Start
+				}
+				if s.End > merged.End {
+					merged.End = s.End
+				}
+			}
+		}
+		keptSessions = append(keptSessions, merged)
+	}
+	sort.SliceStable(keptSessions, func(i, j int) bool {
+		return keptSessions[i].Date < keptSessions[j].Date
+	})
+	result.FocusSessions = len(a.data.FocusSessions) - len(keptSessions)
+
+	// Old years move to their own files, which report queries read back
+	archivedDays, err := a.archiveOldYearsLocked(now.Year(), policy.ArchiveYears)
+	if err != nil {
+		return MaintenanceResult{}, err
+	}
+
+	// Audit entries live in their own files. auditMu is held until they're
+	// rewritten so no entry written meanwhile is lost.
+	var auditKept map[string][]AuditEntry
+	if auditCutoff != "" {
+		a.auditMu.Lock()
+		auditKept, archive.Audit = a.splitAuditLocked(auditCutoff)
+		if len(archive.Audit) == 0 {
+			a.auditMu.Unlock()
+			auditKept = nil
+		}
+	}
+
+	// Deleted tasks that have been in the trash too long
+	var expired []string
+	if trashCutoff != "" {
+		expired = a.trashedLocked(trashCutoff)
+		for _, id := range expired {
+			task, _ := a.findTemplateLocked(id)
+			archive.Tasks = append(archive.Tasks, task)
+			for date, tasks := range a.data.Days {
+				if value, ok := tasks[id]; ok {
+					if archive.TaskDays == nil {
+						archive.TaskDays = make(map[string]DayTasks)
+					}
+					if archive.TaskDays[date] == nil {
+						archive.TaskDays[date] = make(DayTasks)
+					}
+					archive.TaskDays[date][id] = value
+				}
+			}
+		}
+	}
+
+	result.Notes = len(archive.Annotations)
+	result.SubitemDays = len(archive.SubitemDays)
+	result.AuditEntries = len(archive.Audit)
+	result.Tasks = len(expired)
+	result.ArchivedDays = archivedDays
+	if result == (MaintenanceResult{}) {
+		return result, nil
+	}
+
+	if policy.Archive {
+		result.ArchivePath, err = a.writeRetentionArchiveLocked(archive)
+	}
+	if auditKept != nil {
+		if err == nil {
+			err = a.rewriteAuditLocked(auditKept)
+		}
+		a.auditMu.Unlock()
+	}
+	if err != nil {
+		return MaintenanceResult{}, err
+	}
+
+	if result.Notes+result.SubitemDays+result.FocusSessions+result.Tasks+result.ArchivedDays == 0 {
+		return result, nil
+	}
+	a.data.Annotations = keptNotes
+	a.data.SubitemDays = keptSubitems
+	a.data.FocusSessions = keptSessions
+	a.purgeTasksLocked(expired)
+	a.audit("RunMaintenance", "", "", nil, result)
+	return result, a.saveDataLocked()
+}
+
+// splitAuditLocked reads every audit file and splits its entries into
+// those kept, per file, and those older than cutoff (must hold lock and
+// auditMu)
+func (a *App) splitAuditLocked(cutoff string) (map[string][]AuditEntry, []AuditEntry) {
+	kept := make(map[string][]AuditEntry)
+	dropped := []AuditEntry{}
+	for _, path := range a.auditPaths() {
+		lines, err := a.readSealedLinesLocked(path)
+		if err != nil {
+			continue
+		}
+		kept[path] = []AuditEntry{}
+		for _, line := range lines {
+			var entry AuditEntry
+			if err := json.Unmarshal(line, &entry); err != nil {
+				continue
+			}
+			day := entry.Time
+			if len(day) >= 10 {
+				day = day[:10]
+			}
+			if day < cutoff {
+				dropped = append(dropped, entry)
+			} else {
+				kept[path] = append(kept[path], entry)
+			}
+		}
+	}
+	return kept, dropped
+}
+
+// rewriteAuditLocked replaces each audit file with the entries kept in it,
+// removing files left empty (must hold lock and auditMu)
+func (a *App) rewriteAuditLocked(kept map[string][]AuditEntry) error {
+	for path, entries := range kept {
+		if len(entries) == 0 {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		var content []byte
+		for _, entry := range entries {
+			line, err := json.Marshal(entry)
+			if err == nil {
+				line, err = a.sealLineLocked(line)
+			}
+			if err != nil {
+				return err
+			}
+			content = append(append(content, line...), '\n')
+		}
+		if err := a.atomicWriteFile(path, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRetentionArchiveLocked saves dropped detail next to the backups
+// (must hold lock)
+func (a *App) writeRetentionArchiveLocked(archive retentionArchive) (string, error) {
+	dir := a.backupsDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	sort.Slice(archive.Audit, func(i, j int) bool {
+		return archive.Audit[i].Time < archive.Audit[j].Time
+	})
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err == nil {
+		data, err = a.sealLocked(data)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, "archive-"+time.Now().Format("20060102-150405")+".json")
+	if err := a.atomicWriteFile(path, data); err != nil {
+		return "", err
+	}
+	return path, nil
+}
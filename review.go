@@ -0,0 +1,204 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// reviewSteps are the weekly review's steps, in order
+var reviewSteps = []string{"stats", "struggling", "intentions"}
+
+const (
+	// reviewStruggleThreshold is the weekly progress (percent) below which a
+	// habit is brought up as struggling
+	reviewStruggleThreshold = 50.0
+	// maxIntentions caps how many intentions a review keeps
+	maxIntentions = 5
+)
+
+// ReviewHabit is a struggling habit in a review and what was decided about it
+type ReviewHabit struct {
+	TaskID   string  `json:"taskId"`
+	TaskName string  `json:"taskName"`
+	Done     int     `json:"done"`
+	Target   int     `json:"target"`
+	Progress float64 `json:"progress"`
+	Decision string  `json:"decision,omitempty"` // "keep", "adjust" or "pause"
+}
+
+// WeeklyReview is the saved outcome of a weekly review
+type WeeklyReview struct {
+	WeekStart   string        `json:"weekStart"`
+	CompletedAt string        `json:"completedAt,omitempty"` // RFC3339
+	Average     float64       `json:"average"`
+	Best        []string      `json:"best"` // Names of habits fully on track
+	Struggling  []ReviewHabit `json:"struggling"`
+	Reflection  string        `json:"reflection,omitempty"`
+	Intentions  []string      `json:"intentions"` // For the following week
+}
+
+// ReviewState is an in-progress review as shown by the wizard
+type ReviewState struct {
+	WeeklyReview
+	Steps []string `json:"steps"`
+	Next  string   `json:"next"` // Step to submit next; "" when ready to finish
+}
+
+// StartWeeklyReview begins a review of the week containing weekStart,
+// replacing any review in progress. The stats step is filled in already.
+func (a *App) StartWeeklyReview(weekStart string) (ReviewState, error) {
+	weekStart, err := canonicalWeekStart(weekStart)
+	if err != nil {
+		return ReviewState{}, err
+	}
+	start, _ := time.Parse("2006-01-02", weekStart)
+
+	// GetWeeklyReport takes its own read lock
+	weekly := a.GetWeeklyReport(weekStart)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state := ReviewState{
+		WeeklyReview: WeeklyReview{
+			WeekStart:  weekStart,
+			Average:    weekly["weeklyAverage"].(float64),
+			Best:       []string{},
+			Struggling: []ReviewHabit{},
+			Intentions: []string{},
+		},
+		Steps: reviewSteps,
+		Next:  reviewSteps[0],
+	}
+
+	for _, td := range a.weekDetailLocked(start).Tasks {
+		task, ok := a.findTemplateLocked(td.TaskID)
+		if !ok || td.Target == 0 || isValueTask(task) || isScaleTask(task) {
+			continue
+		}
+		if td.Progress >= 100 {
+			state.Best = append(state.Best, task.Name)
+		} else if td.Progress < reviewStruggleThreshold {
+			state.Struggling = append(state.Struggling, ReviewHabit{
+				TaskID:   task.ID,
+				TaskName: task.Name,
+				Done:     td.Done,
+				Target:   td.Target,
+				Progress: td.Progress,
+			})
+		}
+	}
+
+	a.review = &state
+	return state, nil
+}
+
+// SubmitReviewStep records one step of the review in progress. Steps are
+// taken in order; earlier steps can be submitted again.
+//   - "stats": data["reflection"] is an optional note on the week
+//   - "struggling": data[taskID] is "keep", "adjust" or "pause" per habit
+//   - "intentions": data["intentions"] has one intention per line
+func (a *App) SubmitReviewStep(step string, data map[string]string) (ReviewState, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.review == nil {
+		return ReviewState{}, errors.New("no review in progress")
+	}
+	state := a.review
+
+	index, next := -1, len(reviewSteps)
+	for i, s := range reviewSteps {
+		if s == step {
+			index = i
+		}
+		if s == state.Next {
+			next = i
+		}
+	}
+	if index < 0 {
+		return *state, fmt.Errorf("unknown review step %q", step)
+	}
+	if index > next {
+		return *state, fmt.Errorf("finish the %s step first", state.Next)
+	}
+
+	switch step {
+	case "stats":
+		state.Reflection = strings.TrimSpace(data["reflection"])
+
+	case "struggling":
+		for i, habit := range state.Struggling {
+			decision := data[habit.TaskID]
+			if decision != "" && decision != "keep" && decision != "adjust" && decision != "pause" {
+				return *state, fmt.Errorf("unknown decision %q", decision)
+			}
+			state.Struggling[i].Decision = decision
+		}
+
+	case "intentions":
+		intentions := []string{}
+		for _, line := range strings.Split(data["intentions"], "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				intentions = append(intentions, line)
+			}
+		}
+		if len(intentions) > maxIntentions {
+			return *state, fmt.Errorf("keep it to %d intentions", maxIntentions)
+		}
+		state.Intentions = intentions
+	}
+
+	if index == next {
+		state.Next = ""
+		if index+1 < len(reviewSteps) {
+			state.Next = reviewSteps[index+1]
+		}
+	}
+	return *state, nil
+}
+
+// FinishReview saves the review in progress once every step is done,
+// replacing any earlier review of the same week
+func (a *App) FinishReview() (WeeklyReview, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.review == nil {
+		return WeeklyReview{}, errors.New("no review in progress")
+	}
+	if a.review.Next != "" {
+		return WeeklyReview{}, fmt.Errorf("finish the %s step first", a.review.Next)
+	}
+
+	review := a.review.WeeklyReview
+	review.CompletedAt = time.Now().Format(time.RFC3339)
+
+	reviews := []WeeklyReview{}
+	for _, r := range a.data.Reviews {
+		if r.WeekStart != review.WeekStart {
+			reviews = append(reviews, r)
+		}
+	}
+	a.data.Reviews = append(reviews, review)
+	a.review = nil
+
+	a.audit("FinishReview", review.WeekStart, "", nil, review)
+	a.sendWeekSummaryLocked(review.WeekStart)
+	return review, a.saveDataLocked()
+}
+
+// GetWeeklyReviews returns saved reviews, newest week first
+func (a *App) GetWeeklyReviews() []WeeklyReview {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	reviews := append([]WeeklyReview{}, a.data.Reviews...)
+	sort.Slice(reviews, func(i, j int) bool {
+		return reviews[i].WeekStart > reviews[j].WeekStart
+	})
+	return reviews
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// saveStatusEvent is emitted with a SaveStatus when saving starts, ends or fails
+const saveStatusEvent = "plan:save-status"
+
+// saveStatusInterval is the least time between save status events; changes
+// in between are coalesced into one trailing event. Errors are sent at once.
+const saveStatusInterval = 500 * time.Millisecond
+
+// Save states
+const (
+	saveStateSaved  = "saved"
+	saveStateSaving = "saving"
+	saveStateError  = "error"
+)
+
+// SaveStatus drives the UI's saving indicator
+type SaveStatus struct {
+	State     string `json:"state"`               // "saved", "saving" or "error"
+	LastSaved string `json:"lastSaved,omitempty"` // RFC3339 time of the last successful save
+	Pending   int    `json:"pending"`             // Changed day values not saved yet
+	LastError string `json:"lastError,omitempty"` // Why the last save failed; cleared by a successful save
+}
+
+// GetSaveStatus returns the current save state
+func (a *App) GetSaveStatus() SaveStatus {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.saveStatusLocked()
+}
+
+// saveStatusLocked returns the save status with its defaults filled in (must hold lock)
+func (a *App) saveStatusLocked() SaveStatus {
+	status := a.saveStatus
+	if status.State == "" {
+		status.State = saveStateSaved
+	}
+	return status
+}
+
+// saveStartedLocked marks a save of changes as under way (must hold lock)
+func (a *App) saveStartedLocked(changes int) {
+	a.saveStatus.State = saveStateSaving
+	a.saveStatus.Pending += changes
+	a.emitSaveStatusLocked()
+}
+
+// saveFinishedLocked records how a save ended (must hold lock)
+func (a *App) saveFinishedLocked(err error) {
+	if err != nil {
+		a.saveStatus.State = saveStateError
+		a.saveStatus.LastError = err.Error()
+	} else {
+		a.saveStatus = SaveStatus{State: saveStateSaved, LastSaved: time.Now().Format(time.RFC3339)}
+	}
+	a.emitSaveStatusLocked()
+}
+
+// emitSaveStatusLocked sends the save status, holding it back when one
+// was sent less than saveStatusInterval ago (must hold lock)
+func (a *App) emitSaveStatusLocked() {
+	if a.ctx == nil {
+		return
+	}
+	wait := saveStatusInterval - time.Since(a.saveStatusSent)
+	if wait <= 0 || a.saveStatus.State == saveStateError {
+		a.saveStatusSent = time.Now()
+		runtime.EventsEmit(a.ctx, saveStatusEvent, a.saveStatusLocked())
+		return
+	}
+	if a.saveStatusQueued {
+		return
+	}
+	a.saveStatusQueued = true
+	time.AfterFunc(wait, func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		a.saveStatusQueued = false
+		a.saveStatusSent = time.Now()
+		runtime.EventsEmit(a.ctx, saveStatusEvent, a.saveStatusLocked())
+	})
+}
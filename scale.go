@@ -0,0 +1,103 @@
+package main
+
+import "errors"
+
+// defaultScaleMax is the top of the rating scale for new scale tasks (1-5)
+const defaultScaleMax = 5
+
+// isScaleTask reports whether a task is rated on a 1..ScaleMax scale (mood,
+// energy) rather than completed
+func isScaleTask(t TaskTemplate) bool {
+	return taskTypeOf(t) == "scale"
+}
+
+// scaleMaxOf returns the top of a scale task's rating scale
+func scaleMaxOf(t TaskTemplate) int {
+	if t.ScaleMax > 0 {
+		return t.ScaleMax
+	}
+	return defaultScaleMax
+}
+
+// SetTaskScaleMax changes the top of a scale task's rating scale (2-10)
+func (a *App) SetTaskScaleMax(id string, scaleMax int) error {
+	if scaleMax < 2 || scaleMax > 10 {
+		return errors.New("scale max must be between 2 and 10")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID != id {
+			continue
+		}
+		if !isScaleTask(t) {
+			return errors.New("task is not a scale task")
+		}
+		a.data.Templates[i].ScaleMax = scaleMax
+		a.audit("SetTaskScaleMax", "", id, scaleMaxOf(t), scaleMax)
+		return a.saveDataLocked()
+	}
+
+	return errors.New("task not found")
+}
+
+// checkScaleValuesLocked rejects changed ratings outside a scale task's
+// range; unchanged values recorded before the task became a scale task are
+// left alone. 0 means not rated (must hold lock).
+func (a *App) checkScaleValuesLocked(old DayTasks, values map[string]int) error {
+	for _, t := range a.data.Templates {
+		if !isScaleTask(t) {
+			continue
+		}
+		v, ok := values[t.ID]
+		if !ok {
+			continue
+		}
+		if prev, had := old[t.ID]; had && prev == v {
+			continue
+		}
+		if v < 0 || v > scaleMaxOf(t) {
+			return errors.New("rating is outside the task's scale")
+		}
+	}
+	return nil
+}
+
+// scaleStatsInRangeLocked averages the ratings of every scale task that
+// applies somewhere in an inclusive range, skipping excluded days and days
+// without a rating (must hold lock)
+func (a *App) scaleStatsInRangeLocked(from, to string) []MeasurementStats {
+	result := []MeasurementStats{}
+	for _, task := range a.data.Templates {
+		if !isScaleTask(task) || !a.taskActiveInRangeLocked(task, from, to) {
+			continue
+		}
+
+		stats := MeasurementStats{TaskID: task.ID}
+		for date, dayTasks := range a.data.Days {
+			if date < from || date > to || a.dayExcludedLocked(date) {
+				continue
+			}
+			rating := dayTasks[task.ID]
+			if rating <= 0 {
+				continue
+			}
+			value := float64(rating)
+			if stats.Count == 0 || value < stats.Min {
+				stats.Min = value
+			}
+			if stats.Count == 0 || value > stats.Max {
+				stats.Max = value
+			}
+			stats.Count++
+			stats.Sum += value
+		}
+		if stats.Count > 0 {
+			stats.Average = stats.Sum / float64(stats.Count)
+		}
+		result = append(result, stats)
+	}
+	return result
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// isValidTaskType reports whether taskType is a supported task type
+func isValidTaskType(taskType string) bool {
+	switch taskType {
+	case "binary", "count", "negative", "duration", "value", "scale":
+		return true
+	}
+	return false
+}
+
+// taskTypeOf returns the task's type, defaulting to "binary" for older templates
+func taskTypeOf(task TaskTemplate) string {
+	if task.Type == "" {
+		return "binary"
+	}
+	return task.Type
+}
+
+// taskSucceeded reports whether a task's value on a date counts as success.
+// Binary, count and duration tasks succeed with any positive value; negative
+// (avoidance) tasks succeed when nothing was recorded. Tapering tasks
+// succeed at or under the date's target.
+func taskSucceeded(task TaskTemplate, date string, value int) bool {
+	if target, ok := taperTargetOn(task, date); ok {
+		return value <= target
+	}
+	switch taskTypeOf(task) {
+	case "negative":
+		return value == 0
+	default:
+		return value > 0
+	}
+}
+
+// dayExcludedLocked reports whether a whole day is left out of stats,
+// e.g. during vacation (must hold lock)
+func (a *App) dayExcludedLocked(dateKey string) bool {
+	return rangesContain(a.data.Vacations, dateKey) || a.specialDayExcludedLocked(dateKey) || a.daySkippedLocked(dateKey)
+}
+
+// neutralGapLocked reports whether every day strictly between from and to
+// is one that can't break a streak: excluded or without tasks (must hold lock)
+func (a *App) neutralGapLocked(from, to time.Time) bool {
+	for d := from.AddDate(0, 0, 1); d.Before(to); d = d.AddDate(0, 0, 1) {
+		dateKey := d.Format("2006-01-02")
+		if !a.dayExcludedLocked(dateKey) && len(a.getDailyTasksForDateLocked(dateKey)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ScoringConfig tunes how a day's score is computed. The zero value is the
+// plain completed/total percentage. It's kept in local settings.
+type ScoringConfig struct {
+	PriorityWeights map[int]float64    `json:"priorityWeights,omitempty"` // Priority -> weight; default 1
+	TaskWeights     map[string]float64 `json:"taskWeights,omitempty"`     // taskID -> weight, overriding its priority's
+	PerfectDayBonus float64            `json:"perfectDayBonus,omitempty"` // Points added when every task succeeded
+	AvoidPenalty    float64            `json:"avoidPenalty,omitempty"`    // Points taken off per failed negative task
+}
+
+// weightOf returns a task's weight in the day score: its own weight if it
+// has one, otherwise its priority's
+func (c ScoringConfig) weightOf(task TaskTemplate) float64 {
+	if w, ok := c.TaskWeights[task.ID]; ok {
+		return w
+	}
+	if w, ok := c.PriorityWeights[task.Priority]; ok {
+		return w
+	}
+	return 1
+}
+
+// scoringLocked returns the scoring config in effect (must hold lock)
+func (a *App) scoringLocked() ScoringConfig {
+	if a.settings.Scoring == nil {
+		return ScoringConfig{}
+	}
+	return *a.settings.Scoring
+}
+
+// GetScoringConfig returns the day score formula settings
+func (a *App) GetScoringConfig() ScoringConfig {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	config := a.scoringLocked()
+	config.PriorityWeights = make(map[int]float64)
+	for k, v := range a.scoringLocked().PriorityWeights {
+		config.PriorityWeights[k] = v
+	}
+	config.TaskWeights = make(map[string]float64)
+	for k, v := range a.scoringLocked().TaskWeights {
+		config.TaskWeights[k] = v
+	}
+	return config
+}
+
+// SetScoringConfig changes the day score formula used by every report,
+// streak and export
+func (a *App) SetScoringConfig(config ScoringConfig) error {
+	for priority, w := range config.PriorityWeights {
+		if priority < PriorityNone || priority > PriorityHigh {
+			return errors.New("invalid priority")
+		}
+		if w < 0 || w > 10 {
+			return errors.New("priority weights must be between 0 and 10")
+		}
+	}
+	for _, w := range config.TaskWeights {
+		if w < 0 || w > 10 {
+			return errors.New("task weights must be between 0 and 10")
+		}
+	}
+	if config.PerfectDayBonus < 0 || config.PerfectDayBonus > 50 {
+		return errors.New("perfect day bonus must be between 0 and 50")
+	}
+	if config.AvoidPenalty < 0 || config.AvoidPenalty > 100 {
+		return errors.New("avoid penalty must be between 0 and 100")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.audit("SetScoringConfig", "", "", a.scoringLocked(), config)
+	a.settings.Scoring = &config
+	return a.saveSettingsLocked()
+}
+
+// dayScoreLocked returns the score for a date using the scoring config
+// (must hold lock). With default settings it is the completion percentage;
+// a perfect day bonus can take it above 100. ok is false when the date is
+// excluded, has no tasks or no recorded data.
+func (a *App) dayScoreLocked(dateKey string) (percentage float64, ok bool) {
+	if a.dayExcludedLocked(dateKey) {
+		return 0, false
+	}
+
+	// Weekly-quota tasks are scored per week, not per day
+	tasksForDate := a.getDailyTasksForDateLocked(dateKey)
+	if len(tasksForDate) == 0 {
+		return 0, false
+	}
+
+	dayTasks, ok := a.data.Days[dateKey]
+	if !ok {
+		return 0, false
+	}
+
+	config := a.scoringLocked()
+	completed, total := 0.0, 0.0
+	failedAvoids := 0
+	perfect := true
+	for _, task := range tasksForDate {
+		weight := config.weightOf(task)
+		total += weight
+		if taskSucceeded(task, dateKey, dayTasks[task.ID]) {
+			completed += weight
+			continue
+		}
+		perfect = false
+		if taskTypeOf(task) == "negative" {
+			failedAvoids++
+		}
+	}
+
+	if total == 0 {
+		// Every task weighted out: score by success alone
+		if perfect {
+			return 100.0 + config.PerfectDayBonus, true
+		}
+		return 0, true
+	}
+
+	percentage = completed / total * 100.0
+	percentage -= float64(failedAvoids) * config.AvoidPenalty
+	if percentage < 0 {
+		percentage = 0
+	}
+	if perfect {
+		percentage += config.PerfectDayBonus
+	}
+	return percentage, true
+}
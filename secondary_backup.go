@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// secondaryBackupKeep is the number of daily backups kept in the secondary location
+	secondaryBackupKeep = 14
+	// secondaryBackupCheckInterval is how often the scheduler checks whether a backup is due
+	secondaryBackupCheckInterval = time.Hour
+)
+
+// secondaryBackupCredential is the credentials key of the secondary backup's
+// derived key
+const secondaryBackupCredential = "secondaryBackup"
+
+// SecondaryBackupSettings configures the daily encrypted backup to another location
+type SecondaryBackupSettings struct {
+	Dir         string `json:"dir"`
+	Salt        string `json:"salt"`                  // base64, also embedded in each backup
+	Key         string `json:"key,omitempty"`         // Only in settings from older versions; the key is kept with the credentials now
+	LastSuccess string `json:"lastSuccess,omitempty"` // RFC3339
+	LastError   string `json:"lastError,omitempty"`
+}
+
+// SetSecondaryBackup configures the secondary backup directory.
+// The passphrase is only used to derive the encryption key, which is kept
+// with the credentials rather than in settings; an empty dir disables backups.
+func (a *App) SetSecondaryBackup(dir string, passphrase string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if dir == "" {
+		if err := a.setCredentialLocked(secondaryBackupCredential, ""); err != nil {
+			return err
+		}
+		a.audit("SetSecondaryBackup", "", "", a.secondaryBackupDirLocked(), "")
+		a.settings.SecondaryBackup = nil
+		return a.saveSettingsLocked()
+	}
+	if passphrase == "" {
+		return errors.New("a passphrase is required to encrypt backups")
+	}
+
+	salt, err := newSalt()
+	if err != nil {
+		return err
+	}
+
+	key := base64.StdEncoding.EncodeToString(deriveKey(passphrase, salt))
+	if err := a.setCredentialLocked(secondaryBackupCredential, key); err != nil {
+		return err
+	}
+	a.audit("SetSecondaryBackup", "", "", a.secondaryBackupDirLocked(), dir)
+	a.settings.SecondaryBackup = &SecondaryBackupSettings{
+		Dir:  dir,
+		Salt: base64.StdEncoding.EncodeToString(salt),
+	}
+	return a.saveSettingsLocked()
+}
+
+// moveSecondaryBackupKeyLocked moves the backup key older versions kept in
+// settings into the credentials, reporting whether settings need saving
+// (must hold lock)
+func (a *App) moveSecondaryBackupKeyLocked() bool {
+	backup := a.settings.SecondaryBackup
+	if backup == nil || backup.Key == "" {
+		return false
+	}
+	if err := a.setCredentialLocked(secondaryBackupCredential, backup.Key); err != nil {
+		println("Error moving backup key:", err.Error())
+		return false
+	}
+	backup.Key = ""
+	return true
+}
+
+// GetSecondaryBackupDir returns the configured secondary backup directory
+func (a *App) GetSecondaryBackupDir() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.secondaryBackupDirLocked()
+}
+
+// secondaryBackupDirLocked returns the backup directory (must hold lock)
+func (a *App) secondaryBackupDirLocked() string {
+	if a.settings.SecondaryBackup == nil {
+		return ""
+	}
+	return a.settings.SecondaryBackup.Dir
+}
+
+// runSecondaryBackups writes a backup whenever one is due until the app exits
+func (a *App) runSecondaryBackups() {
+	a.secondaryBackupIfDue()
+
+	ticker := time.NewTicker(secondaryBackupCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.secondaryBackupIfDue()
+		}
+	}
+}
+
+// secondaryBackupIfDue writes today's backup if it hasn't been written yet
+// and the secondary location is reachable
+func (a *App) secondaryBackupIfDue() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	settings := a.settings.SecondaryBackup
+	if settings == nil || settings.Dir == "" || a.locked {
+		return
+	}
+
+	today := time.Now().Format("2006-01-02")
+	if strings.HasPrefix(settings.LastSuccess, today) {
+		return
+	}
+
+	// Skip silently when the drive or synced folder isn't mounted
+	if info, err := os.Stat(settings.Dir); err != nil || !info.IsDir() {
+		return
+	}
+
+	if err := a.writeSecondaryBackupLocked(today); err != nil {
+		// Only notify on the first failure of a run of failures
+		if settings.LastError == "" {
+			a.notifyLocked("backup", "Backup failed", err.Error())
+			a.saveDataLocked()
+		}
+		settings.LastError = err.Error()
+	} else {
+		settings.LastSuccess = time.Now().Format(time.RFC3339)
+		settings.LastError = ""
+	}
+	a.saveSettingsLocked()
+}
+
+// writeSecondaryBackupLocked encrypts the current data, archived years
+// included, into the secondary location and prunes old backups (must hold
+// lock)
+func (a *App) writeSecondaryBackupLocked(today string) error {
+	settings := a.settings.SecondaryBackup
+
+	saved := a.credentialLocked(secondaryBackupCredential)
+	if saved == "" {
+		return errors.New("the backup passphrase is missing; set the backup location again")
+	}
+	key, err := base64.StdEncoding.DecodeString(saved)
+	if err != nil {
+		return err
+	}
+	salt, err := base64.StdEncoding.DecodeString(settings.Salt)
+	if err != nil {
+		return err
+	}
+
+	data, err := a.withArchivedDaysLocked()
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	sealed, err := encryptWithKey(key, salt, plaintext)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(settings.Dir, "plan-backup-"+today+".planbak")
+	if err := a.atomicWriteFile(path, sealed); err != nil {
+		return err
+	}
+
+	// Keep only the newest backups; names sort chronologically
+	matches, _ := filepath.Glob(filepath.Join(settings.Dir, "plan-backup-*.planbak"))
+	sort.Strings(matches)
+	for len(matches) > secondaryBackupKeep {
+		os.Remove(matches[0])
+		matches = matches[1:]
+	}
+	return nil
+}
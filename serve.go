@@ -0,0 +1,237 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// serveReportsCommand runs PLAN as a read-only report server for other
+// devices on the network instead of opening the app window:
+//
+//	plan serve-reports --port 8080
+const serveReportsCommand = "serve-reports"
+
+// errReadOnly is returned by saves while serving reports, which leaves the
+// data to the app
+var errReadOnly = errors.New("reports are served read-only; changes aren't saved")
+
+// reportBar is one labelled percentage in a report
+type reportBar struct {
+	Label    string
+	Percent  float64
+	Excluded bool // Left out of stats, e.g. vacation
+}
+
+// reportTaskRow is one task's progress in a week report
+type reportTaskRow struct {
+	Name     string
+	Done     int
+	Target   int
+	Progress float64
+}
+
+// reportPage is what every report template renders
+type reportPage struct {
+	Title      string
+	Prev, Next string // Links to the neighbouring period
+	Summary    []string
+	Bars       []reportBar
+	Tasks      []reportTaskRow
+	Streaks    []TaskStreak
+	Updated    string
+}
+
+// reportServer renders reports from the data on disk
+type reportServer struct {
+	app *App
+}
+
+// runReportServer serves reports until the process is stopped
+func runReportServer(args []string) error {
+	flags := flag.NewFlagSet(serveReportsCommand, flag.ContinueOnError)
+	port := flags.Int("port", 8080, "port to listen on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *port < 1 || *port > 65535 {
+		return errors.New("port must be between 1 and 65535")
+	}
+
+	// No lock is taken, so the app can keep saving while reports are served
+	app := NewApp()
+	app.readOnly = true
+	app.openStorage()
+	defer app.store.close()
+
+	server := &reportServer{app: app}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", server.overview)
+	mux.HandleFunc("GET /week", server.week)
+	mux.HandleFunc("GET /month", server.month)
+	mux.HandleFunc("GET /year", server.year)
+
+	fmt.Printf("Serving PLAN reports at http://%s:%d (read-only). Press Ctrl+C to stop.\n", lanAddress(), *port)
+	return http.ListenAndServe(fmt.Sprintf(":%d", *port), mux)
+}
+
+// lanAddress returns this machine's first non-loopback IPv4 address, which
+// is what a phone on the same network would use
+func lanAddress() string {
+	addrs, err := net.InterfaceAddrs()
+	if err == nil {
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+				return ipNet.IP.String()
+			}
+		}
+	}
+	return "localhost"
+}
+
+// render writes a report page
+func (s *reportServer) render(w http.ResponseWriter, page reportPage) {
+	page.Updated = time.Now().Format("Jan 2, 15:04")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := reportTemplate.Execute(w, page); err != nil {
+		println("Error rendering report:", err.Error())
+	}
+}
+
+// overview shows today's streaks and the current week. Each handler
+// reloads the data first, so reports follow changes made in the app.
+func (s *reportServer) overview(w http.ResponseWriter, r *http.Request) {
+	s.app.loadData()
+	dashboard := s.app.GetDashboard()
+
+	page := reportPage{
+		Title: "PLAN",
+		Summary: []string{
+			fmt.Sprintf("Current streak: %d days", dashboard.CurrentStreak),
+			fmt.Sprintf("Longest streak: %d days", dashboard.LongestStreak),
+		},
+		Streaks: dashboard.TaskStreaks,
+	}
+	start, _ := time.Parse("2006-01-02", dashboard.WeekStart)
+	for i, percent := range dashboard.WeekPercentages {
+		page.Bars = append(page.Bars, reportBar{Label: start.AddDate(0, 0, i).Format("Mon 2"), Percent: percent})
+	}
+	s.render(w, page)
+}
+
+// week shows a week's daily scores and task progress
+func (s *reportServer) week(w http.ResponseWriter, r *http.Request) {
+	weekStart, err := canonicalWeekStart(r.URL.Query().Get("start"))
+	if err != nil {
+		weekStart = weekStartOf(time.Now()).Format("2006-01-02")
+	}
+	start, _ := time.Parse("2006-01-02", weekStart)
+
+	s.app.loadData()
+	report := s.app.GetWeeklyReport(weekStart)
+	detail := s.app.LoadWeekDetailed(weekStart)
+
+	page := reportPage{
+		Title:   "Week of " + start.Format("Jan 2, 2006"),
+		Prev:    "/week?start=" + start.AddDate(0, 0, -7).Format("2006-01-02"),
+		Next:    "/week?start=" + start.AddDate(0, 0, 7).Format("2006-01-02"),
+		Summary: []string{fmt.Sprintf("Weekly average: %.0f%%", report["weeklyAverage"].(float64))},
+	}
+	excluded := make(map[string]bool)
+	for _, date := range detail.ExcludedDays {
+		excluded[date] = true
+	}
+	for i, percent := range report["dailyPercentages"].([]float64) {
+		day := start.AddDate(0, 0, i)
+		page.Bars = append(page.Bars, reportBar{Label: day.Format("Mon 2"), Percent: percent, Excluded: excluded[day.Format("2006-01-02")]})
+	}
+
+	names := make(map[string]string)
+	for _, t := range s.app.GetTaskTemplates() {
+		names[t.ID] = t.Name
+	}
+	for _, td := range detail.Tasks {
+		if td.Target == 0 {
+			continue
+		}
+		page.Tasks = append(page.Tasks, reportTaskRow{Name: names[td.TaskID], Done: td.Done, Target: td.Target, Progress: td.Progress})
+	}
+	s.render(w, page)
+}
+
+// month shows a month's weekly averages
+func (s *reportServer) month(w http.ResponseWriter, r *http.Request) {
+	month, err := time.Parse("2006-01", r.URL.Query().Get("m"))
+	if err != nil {
+		now := time.Now()
+		month = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	s.app.loadData()
+	report := s.app.GetMonthlyReport(month.Year(), int(month.Month()))
+
+	page := reportPage{
+		Title:   month.Format("January 2006"),
+		Prev:    "/month?m=" + month.AddDate(0, -1, 0).Format("2006-01"),
+		Next:    "/month?m=" + month.AddDate(0, 1, 0).Format("2006-01"),
+		Summary: []string{"Trend: " + report["trendDirection"].(string)},
+	}
+	for i, percent := range report["weeklyAverages"].([]float64) {
+		page.Bars = append(page.Bars, reportBar{Label: "Week " + strconv.Itoa(i+1), Percent: percent})
+	}
+	s.render(w, page)
+}
+
+// year shows a year's monthly averages
+func (s *reportServer) year(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.URL.Query().Get("y"))
+	if err != nil || year < 1 || year > 9999 {
+		year = time.Now().Year()
+	}
+
+	s.app.loadData()
+	report := s.app.GetYearlyReport(year)
+
+	page := reportPage{
+		Title:   strconv.Itoa(year),
+		Prev:    "/year?y=" + strconv.Itoa(year-1),
+		Next:    "/year?y=" + strconv.Itoa(year+1),
+		Summary: []string{fmt.Sprintf("Year average: %.0f%%", report["yearTotal"].(float64))},
+	}
+	for i, percent := range report["monthlyAverages"].([]float64) {
+		page.Bars = append(page.Bars, reportBar{Label: time.Month(i + 1).String()[:3], Percent: percent})
+	}
+	s.render(w, page)
+}
+
+// reportTemplate lays out every report page for small screens
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body{font-family:-apple-system,system-ui,sans-serif;margin:0 auto;max-width:40rem;padding:1rem;background:#1b2636;color:#e8ecf1}
+a{color:#8fb8ff}nav{display:flex;gap:1rem;margin-bottom:1rem}
+.bar{display:flex;align-items:center;gap:.5rem;margin:.3rem 0}.bar span{width:4.5rem}
+.track{flex:1;background:#2c3a50;border-radius:4px;height:1rem}.fill{background:#4caf7d;height:100%;border-radius:4px}
+.excluded{opacity:.4}table{width:100%;border-collapse:collapse}td{padding:.3rem 0}small{color:#8a96a8}
+</style></head><body>
+<nav><a href="/">Today</a><a href="/week">Week</a><a href="/month">Month</a><a href="/year">Year</a></nav>
+<h1>{{.Title}}</h1>
+{{range .Summary}}<p>{{.}}</p>{{end}}
+{{range .Bars}}<div class="bar{{if .Excluded}} excluded{{end}}"><span>{{.Label}}</span><div class="track"><div class="fill" style="width:{{printf "%.0f" .Percent}}%"></div></div><span>{{printf "%.0f" .Percent}}%</span></div>
+{{end}}
+{{if .Tasks}}<h2>Tasks</h2><table>
+{{range .Tasks}}<tr><td>{{.Name}}</td><td>{{.Done}}/{{.Target}}</td><td>{{printf "%.0f" .Progress}}%</td></tr>
+{{end}}</table>{{end}}
+{{if .Streaks}}<h2>Streaks</h2><table>
+{{range .Streaks}}<tr><td>{{.TaskName}}</td><td>{{.Streak}} days{{if .AtRisk}} · not done yet today{{end}}</td></tr>
+{{end}}</table>{{end}}
+<nav>{{if .Prev}}<a href="{{.Prev}}">← Previous</a>{{end}}{{if .Next}}<a href="{{.Next}}">Next →</a>{{end}}</nav>
+<small>Read-only · updated {{.Updated}}</small>
+</body></html>
+`))
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// LocalSettings holds machine-specific settings. They live in a local file
+// under ~/.plan, separate from data.json, so a data directory shared between
+// machines (e.g. a synced folder) only carries shareable data.
+type LocalSettings struct {
+	DataDir         string                   `json:"dataDir,omitempty"` // Custom data directory; default ~/.plan
+	ExportPath      string                   `json:"exportPath,omitempty"`
+	SecondaryBackup *SecondaryBackupSettings `json:"secondaryBackup,omitempty"`
+	Window          *WindowState             `json:"window,omitempty"`
+	ExportSchedule  []ExportRule             `json:"exportSchedule,omitempty"`
+	Storage         string                   `json:"storage,omitempty"`      // "json" (default) or "sqlite"
+	CompressData    bool                     `json:"compressData,omitempty"` // Save data.json.gz instead of data.json
+	BackupRetention *BackupRetention         `json:"backupRetention,omitempty"`
+	DeviceID        string                   `json:"deviceId,omitempty"` // Identifies this machine in value stamps
+	WeekSummary     *WeekSummaryTargets      `json:"weekSummary,omitempty"`
+	Sync            *SyncSettings            `json:"sync,omitempty"`
+	GitHistory      bool                     `json:"gitHistory,omitempty"` // Commit every save to ~/.plan/history
+	Onboarding      *OnboardingState         `json:"onboarding,omitempty"` // First-run flow; nil on machines set up before it
+	Scoring         *ScoringConfig           `json:"scoring,omitempty"`    // Day score formula; nil for the plain percentage
+}
+
+// WindowState remembers the window's geometry between runs
+type WindowState struct {
+	X         int  `json:"x"`
+	Y         int  `json:"y"`
+	Width     int  `json:"width"`
+	Height    int  `json:"height"`
+	Maximised bool `json:"maximised,omitempty"`
+}
+
+// loadSettings reads local settings; missing or unreadable files leave defaults
+func (a *App) loadSettings() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	data, err := os.ReadFile(a.settingsPath)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &a.settings); err != nil {
+		println("Error reading settings:", err.Error())
+	}
+	moved := a.moveSecondaryBackupKeyLocked()
+	if a.moveWeekSummaryPasswordLocked() {
+		moved = true
+	}
+	if moved && !a.readOnly {
+		if err := a.saveSettingsLocked(); err != nil {
+			println("Error saving settings:", err.Error())
+		}
+	}
+}
+
+// saveSettingsLocked persists local settings (must be called with lock held)
+func (a *App) saveSettingsLocked() error {
+	audited := a.takeAudit()
+	if a.readOnly {
+		return errReadOnly
+	}
+	data, err := json.MarshalIndent(a.settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := a.atomicWriteFile(a.settingsPath, data); err != nil {
+		return err
+	}
+	a.writeAudit(audited)
+	return nil
+}
+
+// migrateSettingsLocked moves machine-specific values that older versions
+// kept in data.json into local settings (must hold lock)
+func (a *App) migrateSettingsLocked() error {
+	moved := false
+	if a.data.ExportPath != "" {
+		if a.settings.ExportPath == "" {
+			a.settings.ExportPath = a.data.ExportPath
+		}
+		a.data.ExportPath = ""
+		moved = true
+	}
+	if a.data.SecondaryBackup != nil {
+		if a.settings.SecondaryBackup == nil {
+			a.settings.SecondaryBackup = a.data.SecondaryBackup
+		}
+		a.data.SecondaryBackup = nil
+		moved = true
+	}
+	if a.moveSecondaryBackupKeyLocked() {
+		moved = true
+	}
+
+	if moved {
+		return a.saveSettingsLocked()
+	}
+	return nil
+}
+
+// migrateScoringLocked moves the day score formula older versions kept in
+// data.json into local settings (must hold lock)
+func (a *App) migrateScoringLocked() error {
+	if a.data.Scoring == nil {
+		return nil
+	}
+	if a.settings.Scoring == nil {
+		scoring := *a.data.Scoring
+		a.settings.Scoring = &scoring
+	}
+	a.data.Scoring = nil
+	return a.saveSettingsLocked()
+}
+
+// GetDataDirectory returns the directory holding data.json
+func (a *App) GetDataDirectory() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return filepath.Dir(a.dataPath)
+}
+
+// SetDataDirectory moves planner data to another directory (e.g. a synced
+// folder). If the directory already has a data.json it is used as-is;
+// otherwise the current data is copied there. Local settings stay behind.
+func (a *App) SetDataDirectory(dir string) error {
+	if dir == "" {
+		return errors.New("directory is required")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	if _, ok := a.store.(*sqliteStore); ok {
+		a.mu.Unlock()
+		return errors.New("switch back to data.json storage before moving the data directory")
+	}
+	oldPath, oldSum := a.dataPath, a.dataSum
+	newPath := a.dataFileIn(dir)
+	if _, err := os.Stat(newPath); os.IsNotExist(err) {
+		// Archived years move too; read them while they're still found
+		if err := a.readYearArchivesLocked(); err != nil {
+			a.mu.Unlock()
+			return err
+		}
+		a.dataPath, a.dataSum = newPath, ""
+		if err := a.store.save(&a.data); err != nil {
+			a.dataPath, a.dataSum = oldPath, oldSum
+			a.mu.Unlock()
+			return err
+		}
+		if err := a.rewriteYearArchivesLocked(); err != nil {
+			a.dataPath, a.dataSum = oldPath, oldSum
+			a.mu.Unlock()
+			return err
+		}
+	}
+
+	a.audit("SetDataDirectory", "", "", filepath.Dir(oldPath), dir)
+	a.clearUndoLocked()
+	a.settings.DataDir = dir
+	a.dataPath, a.dataSum = newPath, ""
+	err := a.saveSettingsLocked()
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	a.loadData()
+	a.notifyDataDirChanged()
+
+	a.emitDataChanged("")
+	a.refreshMenu()
+	return nil
+}
+
+// restoreWindowState applies the saved window geometry
+func (a *App) restoreWindowState() {
+	a.mu.RLock()
+	w := a.settings.Window
+	a.mu.RUnlock()
+
+	if w == nil || a.ctx == nil {
+		return
+	}
+	if w.Width > 0 && w.Height > 0 {
+		runtime.WindowSetSize(a.ctx, w.Width, w.Height)
+		runtime.WindowSetPosition(a.ctx, w.X, w.Y)
+	}
+	if w.Maximised {
+		runtime.WindowMaximise(a.ctx)
+	}
+}
+
+// beforeClose saves the window geometry; it never blocks closing
+func (a *App) beforeClose(ctx context.Context) bool {
+	state := &WindowState{Maximised: runtime.WindowIsMaximised(ctx)}
+	if !state.Maximised {
+		state.Width, state.Height = runtime.WindowGetSize(ctx)
+		state.X, state.Y = runtime.WindowGetPosition(ctx)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Keep the last normal geometry when closing maximised
+	if state.Maximised && a.settings.Window != nil {
+		prev := *a.settings.Window
+		prev.Maximised = true
+		state = &prev
+	}
+	a.settings.Window = state
+	a.saveSettingsLocked()
+	return false
+}
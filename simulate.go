@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+)
+
+// Actions of a ReportChange
+const (
+	simulateExclude  = "exclude"  // The task doesn't count at all
+	simulateInclude  = "include"  // An archived, deleted or paused task counts again
+	simulateSchedule = "schedule" // The task applies on different days
+)
+
+// ReportChange is one hypothetical change to a task for SimulateReport.
+// OnDays, TimesPerWeek and DayOfMonth are used by "schedule" and replace
+// the task's whole schedule.
+type ReportChange struct {
+	TaskID       string `json:"taskId"`
+	Action       string `json:"action"` // "exclude", "include" or "schedule"
+	OnDays       string `json:"onDays,omitempty"`
+	TimesPerWeek int    `json:"timesPerWeek,omitempty"`
+	DayOfMonth   int    `json:"dayOfMonth,omitempty"`
+}
+
+// ReportFigures are the headline numbers of a range
+type ReportFigures struct {
+	Average     float64 `json:"average"` // Mean day score over scored days
+	ScoredDays  int     `json:"scoredDays"`
+	PerfectDays int     `json:"perfectDays"` // Days with every task done
+}
+
+// SimulatedWeek is one week's average with and without the changes
+type SimulatedWeek struct {
+	WeekStart string  `json:"weekStart"`
+	Before    float64 `json:"before"`
+	After     float64 `json:"after"`
+}
+
+// SimulatedReport compares a range's report with and without changes
+type SimulatedReport struct {
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Before ReportFigures   `json:"before"`
+	After  ReportFigures   `json:"after"`
+	Delta  float64         `json:"delta"` // After.Average - Before.Average
+	Weeks  []SimulatedWeek `json:"weeks"`
+}
+
+// SimulateReport recomputes the report for a scope (as for Export) as if
+// the changes had been made, without changing anything, e.g. to see how
+// much one struggling habit drags the averages down before dropping it.
+func (a *App) SimulateReport(changes []ReportChange, scope string) (SimulatedReport, error) {
+	a.mu.RLock()
+	from, to, err := a.exportScopeLocked(scope)
+	a.mu.RUnlock()
+	if err != nil {
+		return SimulatedReport{}, err
+	}
+	unlock := a.lockDays(from, to)
+	encoded, err := json.Marshal(a.data)
+	if err != nil {
+		unlock()
+		return SimulatedReport{}, err
+	}
+	report := SimulatedReport{From: from, To: to, Weeks: []SimulatedWeek{}}
+	report.Before = a.reportFiguresLocked(from, to)
+	before := a.simulatedWeeksLocked(from, to)
+	unlock()
+
+	// The changes go to a private copy of the data; nothing is saved
+	simulated := &App{}
+	if err := json.Unmarshal(encoded, &simulated.data); err != nil {
+		return report, err
+	}
+	for _, change := range changes {
+		if err := simulated.applyReportChangeLocked(change); err != nil {
+			return report, err
+		}
+	}
+	report.After = simulated.reportFiguresLocked(from, to)
+	after := simulated.simulatedWeeksLocked(from, to)
+
+	report.Delta = math.Round((report.After.Average-report.Before.Average)*10) / 10
+	for i, week := range before {
+		week.After = after[i].After
+		report.Weeks = append(report.Weeks, week)
+	}
+	return report, nil
+}
+
+// applyReportChangeLocked makes one change to the task (must hold lock)
+func (a *App) applyReportChangeLocked(change ReportChange) error {
+	i := a.templateIndexLocked(change.TaskID)
+	if i < 0 {
+		return errors.New("task not found")
+	}
+	t := &a.data.Templates[i]
+
+	switch change.Action {
+	case simulateExclude:
+		a.data.Templates = append(a.data.Templates[:i], a.data.Templates[i+1:]...)
+	case simulateInclude:
+		t.DeletedAt = nil
+		t.Archived, t.ArchivedAt = false, ""
+		t.Paused = nil
+		t.EndsAt = ""
+	case simulateSchedule:
+		if change.OnDays != "" && change.OnDays != onWeekdays && change.OnDays != onWeekends {
+			return errors.New(`days must be "weekdays", "weekends" or empty`)
+		}
+		if change.TimesPerWeek < 0 || change.TimesPerWeek > 7 {
+			return errors.New("times per week must be between 0 and 7")
+		}
+		if change.DayOfMonth < 0 || change.DayOfMonth > 31 {
+			return errors.New("day of month must be between 0 and 31")
+		}
+		t.OnDays, t.TimesPerWeek, t.DayOfMonth = change.OnDays, change.TimesPerWeek, change.DayOfMonth
+		t.Profiles = nil
+	default:
+		return errors.New(`action must be "exclude", "include" or "schedule"`)
+	}
+	return nil
+}
+
+// reportFiguresLocked scores every day from `from` to `to` (must hold lock)
+func (a *App) reportFiguresLocked(from, to string) ReportFigures {
+	figures := ReportFigures{}
+	start, _ := time.Parse("2006-01-02", from)
+	end, _ := time.Parse("2006-01-02", to)
+	total := 0.0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		score, ok := a.dayScoreLocked(date)
+		if !ok {
+			continue
+		}
+		figures.ScoredDays++
+		total += score
+		perfect := true
+		for _, task := range a.getDailyTasksForDateLocked(date) {
+			if !taskSucceeded(task, date, a.data.Days[date][task.ID]) {
+				perfect = false
+				break
+			}
+		}
+		if perfect {
+			figures.PerfectDays++
+		}
+	}
+	if figures.ScoredDays > 0 {
+		figures.Average = math.Round(total/float64(figures.ScoredDays)*10) / 10
+	}
+	return figures
+}
+
+// simulatedWeeksLocked returns the weekly average of every week overlapping
+// the range, in both Before and After (must hold lock)
+func (a *App) simulatedWeeksLocked(from, to string) []SimulatedWeek {
+	weeks := []SimulatedWeek{}
+	start, _ := time.Parse("2006-01-02", from)
+	end, _ := time.Parse("2006-01-02", to)
+	for week := weekStartOf(start); !week.After(end); week = week.AddDate(0, 0, 7) {
+		_, average, _ := a.weeklyAverageLocked(week)
+		average = math.Round(average*10) / 10
+		weeks = append(weeks, SimulatedWeek{WeekStart: week.Format("2006-01-02"), Before: average, After: average})
+	}
+	return weeks
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// SkipTask marks a task as skipped on a date (sick, rest day). Skipped
+// tasks are left out of the day's score instead of counting as missed, and
+// don't break streaks.
+func (a *App) SkipTask(date string, taskID string, reason string) error {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return errors.New("invalid date")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkEditableLocked(date); err != nil {
+		return err
+	}
+
+	if _, ok := a.findTemplateLocked(taskID); !ok {
+		return errors.New("task not found")
+	}
+
+	if a.data.Skipped == nil {
+		a.data.Skipped = make(map[string]map[string]string)
+	}
+	if a.data.Skipped[date] == nil {
+		a.data.Skipped[date] = make(map[string]string)
+	}
+
+	a.audit("SkipTask", date, taskID, nil, reason)
+	a.data.Skipped[date][taskID] = reason
+	return a.saveDataLocked()
+}
+
+// UnskipTask removes a skip so the task counts normally again
+func (a *App) UnskipTask(date string, taskID string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkEditableLocked(date); err != nil {
+		return err
+	}
+
+	reason, ok := a.data.Skipped[date][taskID]
+	if !ok {
+		return nil
+	}
+
+	delete(a.data.Skipped[date], taskID)
+	if len(a.data.Skipped[date]) == 0 {
+		delete(a.data.Skipped, date)
+	}
+
+	a.audit("UnskipTask", date, taskID, reason, nil)
+	return a.saveDataLocked()
+}
+
+// LoadDaySkipped returns the tasks skipped on a date, with their reasons
+func (a *App) LoadDaySkipped(date string) map[string]string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := make(map[string]string)
+	for k, v := range a.data.Skipped[date] {
+		result[k] = v
+	}
+	return result
+}
+
+// SkipDay skips every task that applies on a date with one reason (sick,
+// travel). The day is left out of stats like a vacation day and is shown
+// as skipped in reports and exports. Tasks already skipped keep their own
+// reason.
+func (a *App) SkipDay(date string, reason string) error {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return errors.New("invalid date")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkEditableLocked(date); err != nil {
+		return err
+	}
+
+	a.rememberLocked("SkipDay")
+	if a.data.Skipped == nil {
+		a.data.Skipped = make(map[string]map[string]string)
+	}
+	if a.data.Skipped[date] == nil {
+		a.data.Skipped[date] = make(map[string]string)
+	}
+	for _, task := range a.getTasksForDateLocked(date) {
+		if _, skipped := a.data.Skipped[date][task.ID]; !skipped {
+			a.data.Skipped[date][task.ID] = reason
+		}
+	}
+	if len(a.data.Skipped[date]) == 0 {
+		delete(a.data.Skipped, date)
+	}
+	if a.data.SkippedDays == nil {
+		a.data.SkippedDays = make(map[string]string)
+	}
+
+	old, wasSkipped := a.data.SkippedDays[date]
+	if wasSkipped {
+		a.audit("SkipDay", date, "", old, reason)
+	} else {
+		a.audit("SkipDay", date, "", nil, reason)
+	}
+	a.data.SkippedDays[date] = reason
+	return a.saveDataLocked()
+}
+
+// UnskipDay undoes SkipDay: the day counts again, and task skips made with
+// the day's reason are removed
+func (a *App) UnskipDay(date string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkEditableLocked(date); err != nil {
+		return err
+	}
+
+	reason, ok := a.data.SkippedDays[date]
+	if !ok {
+		return nil
+	}
+
+	a.rememberLocked("UnskipDay")
+	delete(a.data.SkippedDays, date)
+	for id, taskReason := range a.data.Skipped[date] {
+		if taskReason == reason {
+			delete(a.data.Skipped[date], id)
+		}
+	}
+	if len(a.data.Skipped[date]) == 0 {
+		delete(a.data.Skipped, date)
+	}
+
+	a.audit("UnskipDay", date, "", reason, nil)
+	return a.saveDataLocked()
+}
+
+// GetSkippedDays returns the days skipped as a whole between from and to
+// (inclusive) with their reasons, for marking them in calendars and heatmaps
+func (a *App) GetSkippedDays(from string, to string) (map[string]string, error) {
+	r, err := newDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.skippedDaysInRangeLocked(r.From, r.To), nil
+}
+
+// skippedDaysInRangeLocked returns the days skipped as a whole in an
+// inclusive range (must hold lock)
+func (a *App) skippedDaysInRangeLocked(from, to string) map[string]string {
+	result := make(map[string]string)
+	for date, reason := range a.data.SkippedDays {
+		if date >= from && date <= to {
+			result[date] = reason
+		}
+	}
+	return result
+}
+
+// daySkippedLocked reports whether a whole day was skipped (must hold lock)
+func (a *App) daySkippedLocked(date string) bool {
+	_, ok := a.data.SkippedDays[date]
+	return ok
+}
+
+// taskSkippedLocked reports whether a task was skipped on a date (must hold lock)
+func (a *App) taskSkippedLocked(date string, taskID string) bool {
+	_, ok := a.data.Skipped[date][taskID]
+	return ok
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SpecialDay is a date that recurs every year, like a birthday
+type SpecialDay struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`           // "birthday", "anniversary" or "other"
+	Date     string `json:"date"`           // "01-02" month and day
+	Year     int    `json:"year,omitempty"` // Year it started, for counting years; 0 if unknown
+	Excluded bool   `json:"excluded"`       // Leave the day out of scores and streaks
+}
+
+// SpecialDayOccurrence is a special day falling on a particular date
+type SpecialDayOccurrence struct {
+	SpecialDay
+	On    string `json:"on"`              // "2006-01-02" date it falls on
+	Years int    `json:"years,omitempty"` // Years since Year, when known
+}
+
+// occursOn reports whether the special day falls on date. Feb 29 is kept
+// on Feb 28 in other years.
+func (s SpecialDay) occursOn(date string) bool {
+	if len(date) != len("2006-01-02") {
+		return false
+	}
+	monthDay := date[5:]
+	if monthDay == s.Date {
+		return true
+	}
+	if s.Date == "02-29" && monthDay == "02-28" {
+		t, err := time.Parse("2006-01-02", date)
+		return err == nil && t.AddDate(0, 0, 1).Month() == time.March
+	}
+	return false
+}
+
+// AddSpecialDay registers a yearly date. date is "01-02", or "2006-01-02"
+// when the starting year is known so anniversaries can be counted.
+// excluded leaves the day out of scores and streaks, like a vacation day.
+func (a *App) AddSpecialDay(name string, kind string, date string, excluded bool) (SpecialDay, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return SpecialDay{}, errors.New("name is required")
+	}
+	if kind == "" {
+		kind = "other"
+	}
+	if kind != "birthday" && kind != "anniversary" && kind != "other" {
+		return SpecialDay{}, errors.New(`kind must be "birthday", "anniversary" or "other"`)
+	}
+
+	day := SpecialDay{ID: uuid.New().String(), Name: name, Kind: kind, Excluded: excluded}
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		day.Date = t.Format("01-02")
+		day.Year = t.Year()
+	} else if _, err := time.Parse("2006-01-02", "2000-"+date); err == nil {
+		// 2000 is a leap year, so "02-29" passes
+		day.Date = date
+	} else {
+		return SpecialDay{}, errors.New("date must be MM-DD or YYYY-MM-DD")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.data.SpecialDays = append(a.data.SpecialDays, day)
+	a.audit("AddSpecialDay", "", "", nil, day)
+	return day, a.saveDataLocked()
+}
+
+// RemoveSpecialDay deletes a special day
+func (a *App) RemoveSpecialDay(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, day := range a.data.SpecialDays {
+		if day.ID == id {
+			a.data.SpecialDays = append(a.data.SpecialDays[:i], a.data.SpecialDays[i+1:]...)
+			a.audit("RemoveSpecialDay", "", "", day, nil)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("special day not found")
+}
+
+// GetSpecialDays returns every special day in calendar order
+func (a *App) GetSpecialDays() []SpecialDay {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	days := append([]SpecialDay{}, a.data.SpecialDays...)
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].Date < days[j].Date
+	})
+	return days
+}
+
+// GetSpecialDaysInRange returns the special days falling between from and
+// to (inclusive), for tagging calendars and heatmaps
+func (a *App) GetSpecialDaysInRange(from string, to string) ([]SpecialDayOccurrence, error) {
+	r, err := newDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.specialDaysInRangeLocked(r.From, r.To), nil
+}
+
+// specialDaysInRangeLocked lists special day occurrences in an inclusive
+// range, by date (must hold lock)
+func (a *App) specialDaysInRangeLocked(from, to string) []SpecialDayOccurrence {
+	result := []SpecialDayOccurrence{}
+	if len(a.data.SpecialDays) == 0 {
+		return result
+	}
+
+	start, _ := time.Parse("2006-01-02", from)
+	end, _ := time.Parse("2006-01-02", to)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		for _, day := range a.data.SpecialDays {
+			if !day.occursOn(date) {
+				continue
+			}
+			occurrence := SpecialDayOccurrence{SpecialDay: day, On: date}
+			if day.Year > 0 && d.Year() > day.Year {
+				occurrence.Years = d.Year() - day.Year
+			}
+			result = append(result, occurrence)
+		}
+	}
+	return result
+}
+
+// specialDayExcludedLocked reports whether an excluded special day falls
+// on date (must hold lock)
+func (a *App) specialDayExcludedLocked(date string) bool {
+	for _, day := range a.data.SpecialDays {
+		if day.Excluded && day.occursOn(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// specialDayNamesLocked maps each date in a range to the names of the
+// special days on it (must hold lock)
+func (a *App) specialDayNamesLocked(from, to string) map[string][]string {
+	names := make(map[string][]string)
+	for _, occurrence := range a.specialDaysInRangeLocked(from, to) {
+		names[occurrence.On] = append(names[occurrence.On], occurrence.Name)
+	}
+	return names
+}
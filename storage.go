@@ -0,0 +1,454 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Storage backends for LocalSettings.Storage
+const (
+	storageJSON   = "json"
+	storageSQLite = "sqlite"
+)
+
+// sqliteFileName is the database file kept next to data.json
+const sqliteFileName = "plan.db"
+
+// dataStore persists planner data. data.json is the default; SQLite is an
+// opt-in alternative that writes only the day values that changed.
+type dataStore interface {
+	path() string
+	load() (PlannerData, []QuarantinedEntry, error)
+	save(data *PlannerData) error
+	snapshot() ([]byte, error) // Saved data as JSON, for backups
+	close() error
+}
+
+// jsonStore keeps everything in a single data.json file
+type jsonStore struct {
+	app *App
+}
+
+func (s *jsonStore) path() string {
+	return s.app.dataPath
+}
+
+func (s *jsonStore) load() (PlannerData, []QuarantinedEntry, error) {
+	raw, err := os.ReadFile(s.app.dataPath)
+	if err != nil {
+		return PlannerData{}, nil, err
+	}
+	if isEncrypted(raw) && s.app.dataKey == nil {
+		return PlannerData{}, nil, errDataLocked
+	}
+	// openLocked also decompresses data.json.gz
+	data, err := s.app.openLocked(raw)
+	if err != nil {
+		return PlannerData{}, nil, err
+	}
+
+	if decoded, quarantined, ok := decodePlannerData(data); ok {
+		s.app.dataSum = checksum(raw)
+		return decoded, quarantined, nil
+	}
+
+	if decoded, ok := decodeLegacyData(data); ok {
+		s.app.dataSum = checksum(raw)
+		return decoded, nil, nil
+	}
+	return PlannerData{}, nil, errors.New("unreadable data file")
+}
+
+func (s *jsonStore) save(data *PlannerData) error {
+	var encoded []byte
+	var err error
+	if isCompressedPath(s.app.dataPath) {
+		// Compressed before sealing; encrypted data doesn't compress
+		encoded, err = json.Marshal(data)
+		if err == nil {
+			encoded, err = compressData(encoded)
+		}
+	} else {
+		encoded, err = json.MarshalIndent(data, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	if encoded, err = s.app.sealLocked(encoded); err != nil {
+		return err
+	}
+	if s.app.changedOnDiskLocked() {
+		return errExternalChange
+	}
+	if err := s.app.atomicWriteFile(s.app.dataPath, encoded); err != nil {
+		return err
+	}
+	s.app.dataSum = checksum(encoded)
+	return nil
+}
+
+func (s *jsonStore) snapshot() ([]byte, error) {
+	raw, err := os.ReadFile(s.app.dataPath)
+	if err != nil {
+		return nil, err
+	}
+	// Encrypted data stays sealed, compressed inside
+	return decompressData(raw)
+}
+
+func (s *jsonStore) close() error {
+	return nil
+}
+
+// sqliteStore keeps day values and their stamps as rows and everything
+// else as one JSON document. Saves run in a transaction and only touch
+// changed rows.
+type sqliteStore struct {
+	db   *sql.DB
+	file string
+
+	// What the database holds, to work out what changed on save
+	days   map[string]DayTasks
+	stamps map[stampKey][]byte
+	meta   []byte
+}
+
+// stampKey identifies a day value's stamp row
+type stampKey struct {
+	date   string
+	taskID string
+}
+
+// openSQLiteStore opens or creates the database at file
+func openSQLiteStore(file string) (*sqliteStore, error) {
+	db, err := sql.Open("sqlite3", file+"?_journal_mode=WAL&_busy_timeout=5000")
+	if err != nil {
+		return nil, err
+	}
+	// One connection keeps transactions and the in-memory snapshot in step
+	db.SetMaxOpenConns(1)
+
+	schema := `
+CREATE TABLE IF NOT EXISTS meta (key TEXT PRIMARY KEY, value BLOB NOT NULL);
+CREATE TABLE IF NOT EXISTS dates (date TEXT PRIMARY KEY);
+CREATE TABLE IF NOT EXISTS day_values (
+	date    TEXT NOT NULL,
+	task_id TEXT NOT NULL,
+	value   INTEGER NOT NULL,
+	PRIMARY KEY (date, task_id)
+);
+CREATE INDEX IF NOT EXISTS day_values_task ON day_values (task_id, date);
+CREATE TABLE IF NOT EXISTS value_stamps (
+	date    TEXT NOT NULL,
+	task_id TEXT NOT NULL,
+	stamp   BLOB NOT NULL,
+	PRIMARY KEY (date, task_id)
+);`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &sqliteStore{db: db, file: file, days: make(map[string]DayTasks), stamps: make(map[stampKey][]byte)}, nil
+}
+
+func (s *sqliteStore) path() string {
+	return s.file
+}
+
+func (s *sqliteStore) load() (PlannerData, []QuarantinedEntry, error) {
+	var data PlannerData
+
+	var meta []byte
+	err := s.db.QueryRow(`SELECT value FROM meta WHERE key = 'data'`).Scan(&meta)
+	if errors.Is(err, sql.ErrNoRows) {
+		return data, nil, os.ErrNotExist
+	}
+	if err != nil {
+		return data, nil, err
+	}
+	if err := json.Unmarshal(meta, &data); err != nil {
+		return data, nil, err
+	}
+
+	data.Days = make(map[string]DayTasks)
+	dates, err := s.db.Query(`SELECT date FROM dates`)
+	if err != nil {
+		return data, nil, err
+	}
+	for dates.Next() {
+		var date string
+		if err := dates.Scan(&date); err != nil {
+			dates.Close()
+			return data, nil, err
+		}
+		data.Days[date] = make(DayTasks)
+	}
+	dates.Close()
+
+	rows, err := s.db.Query(`SELECT date, task_id, value FROM day_values`)
+	if err != nil {
+		return data, nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var date, taskID string
+		var value int
+		if err := rows.Scan(&date, &taskID, &value); err != nil {
+			return data, nil, err
+		}
+		if data.Days[date] == nil {
+			data.Days[date] = make(DayTasks)
+		}
+		data.Days[date][taskID] = value
+	}
+	if err := rows.Err(); err != nil {
+		return data, nil, err
+	}
+
+	// Databases written before the stamps table keep them in meta; those
+	// move to rows on the next save
+	stamps, err := s.db.Query(`SELECT date, task_id, stamp FROM value_stamps`)
+	if err != nil {
+		return data, nil, err
+	}
+	defer stamps.Close()
+	saved := make(map[stampKey][]byte)
+	for stamps.Next() {
+		var key stampKey
+		var encoded []byte
+		if err := stamps.Scan(&key.date, &key.taskID, &encoded); err != nil {
+			return data, nil, err
+		}
+		var stamp ValueStamp
+		if err := json.Unmarshal(encoded, &stamp); err != nil {
+			return data, nil, err
+		}
+		if data.Stamps == nil {
+			data.Stamps = make(map[string]map[string]ValueStamp)
+		}
+		if data.Stamps[key.date] == nil {
+			data.Stamps[key.date] = make(map[string]ValueStamp)
+		}
+		data.Stamps[key.date][key.taskID] = stamp
+		saved[key] = encoded
+	}
+	if err := stamps.Err(); err != nil {
+		return data, nil, err
+	}
+
+	s.meta = meta
+	s.stamps = saved
+	s.days = make(map[string]DayTasks, len(data.Days))
+	for date, tasks := range data.Days {
+		s.days[date] = copyDayTasks(tasks)
+	}
+	return data, nil, nil
+}
+
+func (s *sqliteStore) save(data *PlannerData) error {
+	rest := *data
+	rest.Days = nil
+	rest.Stamps = nil
+	meta, err := json.Marshal(rest)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if !bytes.Equal(meta, s.meta) {
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO meta (key, value) VALUES ('data', ?)`, meta); err != nil {
+			return err
+		}
+	}
+
+	for date, tasks := range data.Days {
+		prev, had := s.days[date]
+		if !had {
+			if _, err := tx.Exec(`INSERT OR IGNORE INTO dates (date) VALUES (?)`, date); err != nil {
+				return err
+			}
+		}
+		for id, value := range tasks {
+			if old, ok := prev[id]; ok && old == value {
+				continue
+			}
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO day_values (date, task_id, value) VALUES (?, ?, ?)`, date, id, value); err != nil {
+				return err
+			}
+		}
+		for id := range prev {
+			if _, ok := tasks[id]; !ok {
+				if _, err := tx.Exec(`DELETE FROM day_values WHERE date = ? AND task_id = ?`, date, id); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for date := range s.days {
+		if _, ok := data.Days[date]; !ok {
+			if _, err := tx.Exec(`DELETE FROM day_values WHERE date = ?`, date); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(`DELETE FROM dates WHERE date = ?`, date); err != nil {
+				return err
+			}
+		}
+	}
+
+	stamps := make(map[stampKey][]byte, len(s.stamps))
+	for date, byTask := range data.Stamps {
+		for id, stamp := range byTask {
+			key := stampKey{date: date, taskID: id}
+			encoded, err := json.Marshal(stamp)
+			if err != nil {
+				return err
+			}
+			stamps[key] = encoded
+			if bytes.Equal(encoded, s.stamps[key]) {
+				continue
+			}
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO value_stamps (date, task_id, stamp) VALUES (?, ?, ?)`, date, id, encoded); err != nil {
+				return err
+			}
+		}
+	}
+	for key := range s.stamps {
+		if _, ok := stamps[key]; !ok {
+			if _, err := tx.Exec(`DELETE FROM value_stamps WHERE date = ? AND task_id = ?`, key.date, key.taskID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// Only advance the snapshot once the rows are committed
+	s.meta = meta
+	s.stamps = stamps
+	s.days = make(map[string]DayTasks, len(data.Days))
+	for date, tasks := range data.Days {
+		s.days[date] = copyDayTasks(tasks)
+	}
+	return nil
+}
+
+func (s *sqliteStore) snapshot() ([]byte, error) {
+	data, _, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(data, "", "  ")
+}
+
+func (s *sqliteStore) close() error {
+	return s.db.Close()
+}
+
+// copyDayTasks returns an independent copy of a day's values
+func copyDayTasks(tasks DayTasks) DayTasks {
+	copied := make(DayTasks, len(tasks))
+	for id, value := range tasks {
+		copied[id] = value
+	}
+	return copied
+}
+
+// GetStorageBackend returns where data is stored on this machine: "json" or "sqlite"
+func (a *App) GetStorageBackend() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if _, ok := a.store.(*sqliteStore); ok {
+		return storageSQLite
+	}
+	return storageJSON
+}
+
+// SetStorageBackend switches this machine between data.json and an SQLite
+// database in the same directory. Current data is written to the new
+// backend before switching; the old file is left in place as a copy.
+func (a *App) SetStorageBackend(backend string) error {
+	if backend != storageJSON && backend != storageSQLite {
+		return errors.New(`storage must be "json" or "sqlite"`)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	current := storageJSON
+	if _, ok := a.store.(*sqliteStore); ok {
+		current = storageSQLite
+	}
+	if backend == current {
+		return nil
+	}
+	if a.dataKey != nil || a.locked {
+		return errors.New("turn off encryption before switching storage")
+	}
+
+	dir := filepath.Dir(a.dataPath)
+	var next dataStore
+	nextPath := filepath.Join(dir, a.dataFileNameLocked())
+	if backend == storageSQLite {
+		store, err := openSQLiteStore(filepath.Join(dir, sqliteFileName))
+		if err != nil {
+			return err
+		}
+		// Sync with rows left from an earlier switch so they're replaced
+		if _, _, err := store.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+			store.close()
+			return err
+		}
+		next, nextPath = store, store.path()
+	} else {
+		next = &jsonStore{app: a}
+	}
+
+	oldPath, oldSum := a.dataPath, a.dataSum
+	a.dataPath, a.dataSum = nextPath, "" // Replaces whatever an earlier switch left
+	if err := next.save(&a.data); err != nil {
+		a.dataPath, a.dataSum = oldPath, oldSum
+		next.close()
+		return err
+	}
+
+	a.store.close()
+	a.store = next
+	a.settings.Storage = backend
+	a.audit("SetStorageBackend", "", "", current, backend)
+	return a.saveSettingsLocked()
+}
+
+// shutdown releases storage, and removes any replay or demo directory, when
+// the app exits
+func (a *App) shutdown(_ context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.store != nil {
+		a.store.close()
+	}
+	a.releaseDataLockLocked()
+	if a.replayDir != "" {
+		os.RemoveAll(a.replayDir)
+	}
+	if a.demo != nil {
+		a.demo.store.close()
+		os.RemoveAll(a.demo.dir)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// StreakProgress is a task's current streak measured against its goal
+type StreakProgress struct {
+	TaskID    string `json:"taskId"`
+	TaskName  string `json:"taskName"`
+	Current   int    `json:"current"`
+	Goal      int    `json:"goal"` // 0 when no goal is set
+	Remaining int    `json:"remaining"`
+	Reached   bool   `json:"reached"`
+}
+
+// StreakGoalHit records the day a task's streak reached its goal
+type StreakGoalHit struct {
+	TaskID   string `json:"taskId"`
+	TaskName string `json:"taskName"`
+	Goal     int    `json:"goal"`
+	Date     string `json:"date"`
+}
+
+// SetTaskStreakGoal sets how many consecutive days a task aims for (0 clears it)
+func (a *App) SetTaskStreakGoal(id string, goal int) error {
+	if goal < 0 || goal > maxStreakLookbackDays {
+		return errors.New("streak goal is out of range")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID == id {
+			a.data.Templates[i].StreakGoal = goal
+			a.audit("SetTaskStreakGoal", "", id, t.StreakGoal, goal)
+			return a.saveDataLocked()
+		}
+	}
+
+	return errors.New("task not found")
+}
+
+// GetStreakProgress returns a task's current streak and how far it is from
+// its goal. Today counts once it's done; until then the streak runs
+// through yesterday.
+func (a *App) GetStreakProgress(taskID string) (StreakProgress, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		return StreakProgress{}, errors.New("task not found")
+	}
+
+	current, _ := a.currentStreakLocked(task, time.Now())
+	progress := StreakProgress{TaskID: task.ID, TaskName: task.Name, Current: current, Goal: task.StreakGoal}
+	if task.StreakGoal > 0 {
+		progress.Reached = current >= task.StreakGoal
+		if !progress.Reached {
+			progress.Remaining = task.StreakGoal - current
+		}
+	}
+	return progress, nil
+}
+
+// currentStreakLocked returns a task's streak as of now, counting today
+// only when it's done (must hold lock)
+func (a *App) currentStreakLocked(task TaskTemplate, now time.Time) (streak int, doneToday bool) {
+	today := now.Format("2006-01-02")
+	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
+
+	if dayTasks, recorded := a.data.Days[today]; recorded {
+		doneToday = taskSucceeded(task, today, dayTasks[task.ID])
+	}
+
+	streak = a.taskStreakLocked(task, yesterday)
+	if doneToday {
+		streak++
+	}
+	return streak, doneToday
+}
+
+// streakGoalHitsLocked lists tasks whose streak reached its goal on a date
+// from `from` to `to` inclusive (must hold lock)
+func (a *App) streakGoalHitsLocked(from, to string) []StreakGoalHit {
+	hits := []StreakGoalHit{}
+	start, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return hits
+	}
+	end, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return hits
+	}
+
+	for _, task := range a.data.Templates {
+		if task.StreakGoal == 0 || task.DeletedAt != nil || isFrequencyTask(task) {
+			continue
+		}
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			date := d.Format("2006-01-02")
+			if !a.taskAppliesLocked(task, date) {
+				continue
+			}
+			if a.taskStreakLocked(task, date) == task.StreakGoal {
+				hits = append(hits, StreakGoalHit{TaskID: task.ID, TaskName: task.Name, Goal: task.StreakGoal, Date: date})
+				break
+			}
+		}
+	}
+
+	return hits
+}
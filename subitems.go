@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Subitem is a small step within a task (e.g. "Stretch" in "Morning Routine")
+type Subitem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AddSubitem appends a checklist step to a task
+func (a *App) AddSubitem(taskID string, name string) (Subitem, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID == taskID {
+			item := Subitem{ID: uuid.New().String(), Name: name}
+			a.data.Templates[i].Subitems = append(a.data.Templates[i].Subitems, item)
+			a.audit("AddSubitem", "", taskID, nil, name)
+			return item, a.saveDataLocked()
+		}
+	}
+
+	return Subitem{}, errors.New("task not found")
+}
+
+// UpdateSubitem renames a checklist step
+func (a *App) UpdateSubitem(taskID string, subitemID string, name string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID != taskID {
+			continue
+		}
+		for j, item := range t.Subitems {
+			if item.ID == subitemID {
+				a.data.Templates[i].Subitems[j].Name = name
+				a.audit("UpdateSubitem", "", taskID, item.Name, name)
+				return a.saveDataLocked()
+			}
+		}
+	}
+
+	return nil
+}
+
+// RemoveSubitem deletes a checklist step. Past day values are kept as they were.
+func (a *App) RemoveSubitem(taskID string, subitemID string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID != taskID {
+			continue
+		}
+		for j, item := range t.Subitems {
+			if item.ID == subitemID {
+				a.data.Templates[i].Subitems = append(t.Subitems[:j:j], t.Subitems[j+1:]...)
+				a.audit("RemoveSubitem", "", taskID, item.Name, nil)
+				return a.saveDataLocked()
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetSubitemDone marks a checklist step done or not done for a date and,
+// for binary and count tasks, updates the parent task's day value. Returns
+// the parent value.
+func (a *App) SetSubitemDone(date string, taskID string, subitemID string, done bool) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.checkEditableLocked(date); err != nil {
+		return 0, err
+	}
+
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		return 0, errors.New("task not found")
+	}
+	if isAutoTask(task) {
+		return 0, errors.New("task value is computed automatically")
+	}
+	if !hasSubitem(task, subitemID) {
+		return 0, errors.New("step not found")
+	}
+
+	if a.data.SubitemDays == nil {
+		a.data.SubitemDays = make(map[string]map[string][]string)
+	}
+	if a.data.SubitemDays[date] == nil {
+		a.data.SubitemDays[date] = make(map[string][]string)
+	}
+
+	doneIDs := []string{}
+	for _, id := range a.data.SubitemDays[date][taskID] {
+		if id != subitemID {
+			doneIDs = append(doneIDs, id)
+		}
+	}
+	if done {
+		doneIDs = append(doneIDs, subitemID)
+	}
+	a.data.SubitemDays[date][taskID] = doneIDs
+
+	if !derivesFromSubitems(task) {
+		// Steps are only a checklist here; the recorded value stands
+		a.audit("SetSubitemDone", date, taskID, !done, done)
+		return a.data.Days[date][taskID], a.saveDataLocked()
+	}
+
+	if err := a.pullArchivedDayLocked(date); err != nil {
+		return 0, err
+	}
+	if a.data.Days == nil {
+		a.data.Days = make(map[string]DayTasks)
+	}
+	if a.data.Days[date] == nil {
+		a.data.Days[date] = make(DayTasks)
+	}
+
+	old := auditValue(a.data.Days[date], taskID)
+	value := subitemValue(task, doneIDs)
+	a.data.Days[date][taskID] = value
+	a.audit("SetSubitemDone", date, taskID, old, value)
+
+	return value, a.saveDataLocked()
+}
+
+// hasSubitem reports whether a step is on a task's checklist
+func hasSubitem(task TaskTemplate, subitemID string) bool {
+	for _, item := range task.Subitems {
+		if item.ID == subitemID {
+			return true
+		}
+	}
+	return false
+}
+
+// derivesFromSubitems reports whether a task's day value follows its
+// checklist: binary tasks are done with every step, count tasks count the
+// steps done. Other types record their own value (minutes, ratings...).
+func derivesFromSubitems(task TaskTemplate) bool {
+	switch taskTypeOf(task) {
+	case "binary", "count":
+		return true
+	}
+	return false
+}
+
+// subitemValue derives a parent task's day value from its completed steps.
+// Count tasks record how many steps are done; other tasks are done only
+// when every step is.
+func subitemValue(task TaskTemplate, doneIDs []string) int {
+	done := 0
+	for _, item := range task.Subitems {
+		for _, id := range doneIDs {
+			if id == item.ID {
+				done++
+				break
+			}
+		}
+	}
+
+	if taskTypeOf(task) == "count" {
+		return done
+	}
+	if done > 0 && done == len(task.Subitems) {
+		return 1
+	}
+	return 0
+}
+
+// LoadDaySubitems returns completed checklist step IDs for a date keyed by task ID
+func (a *App) LoadDaySubitems(date string) map[string][]string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := make(map[string][]string)
+	for taskID, ids := range a.data.SubitemDays[date] {
+		result[taskID] = append([]string{}, ids...)
+	}
+	return result
+}
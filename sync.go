@@ -0,0 +1,496 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Sync providers; see cloudsync.go for the cloud ones
+const syncWebDAV = "webdav"
+
+// syncStatusEvent is emitted with a SyncStatus when a sync starts or ends
+const syncStatusEvent = "plan:sync-status"
+
+const (
+	// syncInterval is how often data is synced in the background
+	syncInterval = 5 * time.Minute
+	// syncRetryMin and syncRetryMax bound the wait after failed syncs,
+	// which doubles with each failure in a row
+	syncRetryMin = time.Minute
+	syncRetryMax = time.Hour
+	// syncCheckInterval is how often the background sync checks whether a sync is due
+	syncCheckInterval = 30 * time.Second
+)
+
+var (
+	// errRemoteMissing is returned by a provider when nothing was synced yet
+	errRemoteMissing = errors.New("no data on the server yet")
+	// errRemoteChanged is returned by a provider when the remote copy
+	// changed since it was downloaded, so uploading would overwrite it
+	errRemoteChanged = errors.New("data on the server changed during the sync")
+)
+
+// syncProvider moves the data file to and from a remote copy. Tags identify
+// a version of the remote copy (an ETag or similar).
+type syncProvider interface {
+	// download returns the remote copy and its tag, or errRemoteMissing
+	download() (data []byte, tag string, err error)
+	// upload replaces the remote copy if it's still at tag ("" when there
+	// was none) and returns the new tag, or errRemoteChanged
+	upload(data []byte, tag string) (string, error)
+}
+
+// SyncSettings configures syncing data with a remote copy. The password is
+// kept with the credentials, not here.
+type SyncSettings struct {
+	Provider  string `json:"provider"`      // "webdav", "dropbox" or "gdrive"
+	URL       string `json:"url,omitempty"` // WebDAV: the remote data file
+	Username  string `json:"username,omitempty"`
+	ClientID  string `json:"clientId,omitempty"` // Cloud providers: the registered app
+	LastSync  string `json:"lastSync,omitempty"` // RFC3339 time of the last successful sync
+	LastError string `json:"lastError,omitempty"`
+	RemoteTag string `json:"remoteTag,omitempty"` // Tag of the remote copy at the last sync
+	LocalSum  string `json:"localSum,omitempty"`  // Checksum of the data at the last sync
+}
+
+// SyncStatus describes sync for the settings screen
+type SyncStatus struct {
+	Enabled     bool   `json:"enabled"`
+	Provider    string `json:"provider,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Username    string `json:"username,omitempty"`
+	LastSync    string `json:"lastSync,omitempty"`
+	LastError   string `json:"lastError,omitempty"`
+	LocalChange bool   `json:"localChange"` // Changes here haven't been synced yet
+	Syncing     bool   `json:"syncing"`
+	Failures    int    `json:"failures"`           // Failed syncs in a row
+	NextSync    string `json:"nextSync,omitempty"` // RFC3339 time of the next background sync
+}
+
+// SyncResult describes what a Sync did
+type SyncResult struct {
+	Pulled     int    `json:"pulled"`     // Tasks, values and notes taken from the remote copy
+	Pushed     bool   `json:"pushed"`     // The remote copy was updated
+	Conflict   bool   `json:"conflict"`   // Both copies changed since the last sync; merged by stamps
+	BackupPath string `json:"backupPath"` // Copy of the data from before a merge
+}
+
+// syncCredential is the credentials key of the sync password
+const syncCredential = "sync"
+
+// GetSyncStatus returns the sync settings and how the last sync went
+func (a *App) GetSyncStatus() SyncStatus {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.syncStatusLocked()
+}
+
+// syncStatusLocked builds the sync status (must hold lock)
+func (a *App) syncStatusLocked() SyncStatus {
+	s := a.settings.Sync
+	if s == nil {
+		return SyncStatus{}
+	}
+	status := SyncStatus{
+		Enabled:     true,
+		Provider:    s.Provider,
+		URL:         s.URL,
+		Username:    s.Username,
+		LastSync:    s.LastSync,
+		LastError:   s.LastError,
+		LocalChange: a.syncSumLocked() != s.LocalSum,
+		Syncing:     a.syncing,
+		Failures:    a.syncFailures,
+	}
+	if !a.nextSync.IsZero() {
+		status.NextSync = a.nextSync.Format(time.RFC3339)
+	}
+	return status
+}
+
+// emitSyncStatusLocked tells the frontend how sync is doing (must hold lock)
+func (a *App) emitSyncStatusLocked() {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, syncStatusEvent, a.syncStatusLocked())
+	}
+}
+
+// SetSyncSettings turns on syncing with a WebDAV server such as Nextcloud.
+// remoteURL is the data file on the server, e.g.
+// https://cloud.example.com/remote.php/dav/files/me/PLAN/plan.json; its
+// folder must exist. An empty password keeps the saved one; an empty URL
+// turns sync off and forgets the password.
+func (a *App) SetSyncSettings(provider, remoteURL, username, password string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if remoteURL == "" {
+		if err := a.setCredentialLocked(syncCredential, ""); err != nil {
+			return err
+		}
+		a.audit("SetSyncSettings", "", "", a.syncURLLocked(), "")
+		a.settings.Sync = nil
+		a.cloudSession = nil
+		return a.saveSettingsLocked()
+	}
+	if provider != syncWebDAV {
+		return errors.New(`provider must be "webdav"`)
+	}
+	u, err := url.Parse(remoteURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("server address must be an http or https URL")
+	}
+
+	settings := &SyncSettings{Provider: provider, URL: remoteURL, Username: username}
+	old := a.settings.Sync
+	if old != nil && old.Provider == provider && old.URL == remoteURL {
+		settings.LastSync, settings.RemoteTag, settings.LocalSum = old.LastSync, old.RemoteTag, old.LocalSum
+	}
+	if password != "" || old == nil || old.Provider != provider {
+		if err := a.setCredentialLocked(syncCredential, password); err != nil {
+			return err
+		}
+	}
+	a.audit("SetSyncSettings", "", "", a.syncURLLocked(), remoteURL)
+	a.settings.Sync = settings
+	a.cloudSession = nil
+	return a.saveSettingsLocked()
+}
+
+// syncURLLocked returns where data is synced to, or "" (must hold lock)
+func (a *App) syncURLLocked() string {
+	if a.settings.Sync == nil {
+		return ""
+	}
+	if a.settings.Sync.URL == "" {
+		return a.settings.Sync.Provider
+	}
+	return a.settings.Sync.URL
+}
+
+// syncProviderLocked builds the provider for the sync settings (must hold lock)
+func (a *App) syncProviderLocked() (syncProvider, error) {
+	s := a.settings.Sync
+	if s == nil {
+		return nil, errors.New("sync is not set up")
+	}
+	switch s.Provider {
+	case syncWebDAV:
+		return &webdavProvider{
+			url:      s.URL,
+			username: s.Username,
+			password: a.credentialLocked(syncCredential),
+			client:   &http.Client{Timeout: 30 * time.Second},
+		}, nil
+	case syncDropbox, syncGoogleDrive:
+		// The session is kept so its access token is reused between syncs
+		if a.cloudSession == nil {
+			session, err := a.newOAuthSessionLocked(s)
+			if err != nil {
+				return nil, err
+			}
+			a.cloudSession = session
+		}
+		if s.Provider == syncDropbox {
+			return &dropboxProvider{session: a.cloudSession}, nil
+		}
+		return &driveProvider{session: a.cloudSession}, nil
+	}
+	return nil, fmt.Errorf("unknown sync provider %q", s.Provider)
+}
+
+// syncSumLocked returns a checksum of the data, independent of encryption,
+// to tell whether it changed since the last sync (must hold lock)
+func (a *App) syncSumLocked() string {
+	encoded, err := json.Marshal(a.data)
+	if err != nil {
+		return ""
+	}
+	return checksum(encoded)
+}
+
+// Sync merges the remote copy into the data here and uploads the result.
+// When both copies changed since the last sync, tasks and notes are
+// merged and day values are settled by their stamps (see ValueStamp), the
+// same way on every device; the data here is backed up first. Encrypted
+// data is uploaded encrypted.
+func (a *App) Sync() (SyncResult, error) {
+	a.syncMu.Lock()
+	defer a.syncMu.Unlock()
+
+	a.mu.Lock()
+	provider, err := a.syncProviderLocked()
+	if err == nil {
+		a.syncing = true
+		a.emitSyncStatusLocked()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	var result SyncResult
+	for attempt := 0; attempt < 3; attempt++ {
+		result, err = a.syncOnce(provider)
+		if !errors.Is(err, errRemoteChanged) {
+			break
+		}
+	}
+
+	a.mu.Lock()
+	a.syncing = false
+	if s := a.settings.Sync; s != nil {
+		if err != nil {
+			s.LastError = err.Error()
+		} else {
+			s.LastError = ""
+			s.LastSync = time.Now().Format(time.RFC3339)
+		}
+		if saveErr := a.saveSettingsLocked(); saveErr != nil {
+			println("Error saving sync status:", saveErr.Error())
+		}
+	}
+	a.scheduleSyncLocked(err)
+	a.emitSyncStatusLocked()
+	a.mu.Unlock()
+
+	if result.Pulled > 0 {
+		a.emitDataChanged("")
+		a.refreshMenu()
+	}
+	return result, err
+}
+
+// syncOnce downloads, merges and uploads once. Network calls happen
+// without the lock held.
+func (a *App) syncOnce(provider syncProvider) (SyncResult, error) {
+	remote, tag, err := provider.download()
+	if err != nil && !errors.Is(err, errRemoteMissing) {
+		return SyncResult{}, err
+	}
+
+	a.mu.Lock()
+	result, upload, sum, err := a.mergeRemoteLocked(remote, tag)
+	a.mu.Unlock()
+	if err != nil || upload == nil {
+		return result, err
+	}
+
+	newTag, err := provider.upload(upload, tag)
+	if err != nil {
+		return result, err
+	}
+	result.Pushed = true
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if s := a.settings.Sync; s != nil {
+		s.RemoteTag, s.LocalSum = newTag, sum
+	}
+	return result, nil
+}
+
+// mergeRemoteLocked merges a downloaded remote copy (nil when there is
+// none) and returns the data to upload with its sync checksum, or nil when
+// the remote copy is already up to date (must hold lock)
+func (a *App) mergeRemoteLocked(remote []byte, tag string) (result SyncResult, upload []byte, sum string, err error) {
+	if a.locked {
+		return result, nil, "", errDataLocked
+	}
+	s := a.settings.Sync
+	if s == nil {
+		return result, nil, "", errors.New("sync is not set up")
+	}
+
+	localChanged := a.syncSumLocked() != s.LocalSum
+	if remote != nil && tag != s.RemoteTag {
+		// Devices encrypting with the same passphrase each have their own
+		// salt; openLocked reads the remote copy's from its header
+		plain, err := a.openLocked(remote)
+		if errors.Is(err, errOtherKey) {
+			return result, nil, "", errors.New("data on the server is encrypted with a different passphrase")
+		}
+		if err != nil {
+			return result, nil, "", err
+		}
+		other, quarantined, ok := decodePlannerData(plain)
+		if !ok {
+			return result, nil, "", errors.New("data on the server is not in the PLAN format")
+		}
+		if other.Version > schemaVersion {
+			return result, nil, "", errNewerData
+		}
+
+		result.Conflict = localChanged && s.RemoteTag != ""
+		backupPath, err := a.preChangeBackupLocked("sync")
+		if err != nil {
+			return result, nil, "", err
+		}
+		result.BackupPath = backupPath
+
+		a.rememberLocked("Sync")
+		merged := a.syncDataLocked(other)
+		result.Pulled = merged.Tasks + merged.Values + merged.Notes + merged.SpecialDays
+		if len(quarantined) > 0 {
+			a.quarantineLocked(quarantined)
+		}
+		a.audit("Sync", "", "", s.RemoteTag, tag)
+		if err := a.saveDataLocked(); err != nil {
+			return result, nil, "", err
+		}
+		if !localChanged {
+			// Only the remote copy changed, so it already has everything
+			s.RemoteTag, s.LocalSum = tag, a.syncSumLocked()
+			return result, nil, "", nil
+		}
+	} else if remote != nil && !localChanged {
+		return result, nil, "", nil
+	}
+
+	upload, err = a.encodeDataLocked()
+	if err != nil {
+		return result, nil, "", err
+	}
+	return result, upload, a.syncSumLocked(), nil
+}
+
+// scheduleSyncLocked sets when the background sync runs next: after the
+// usual interval, or sooner after a failure and then backing off
+// (must hold lock)
+func (a *App) scheduleSyncLocked(err error) {
+	if err == nil {
+		a.syncFailures = 0
+		a.nextSync = time.Now().Add(syncInterval)
+		return
+	}
+	a.syncFailures++
+	wait := syncRetryMin
+	for i := 1; i < a.syncFailures && wait < syncRetryMax; i++ {
+		wait *= 2
+	}
+	a.nextSync = time.Now().Add(min(wait, syncRetryMax))
+}
+
+// requestSync asks the background sync to run now
+func (a *App) requestSync() {
+	select {
+	case a.syncRequested <- struct{}{}:
+	default:
+	}
+}
+
+// runSync syncs in the background whenever a sync is due or requested,
+// until the app exits. Locked data waits for Unlock.
+func (a *App) runSync() {
+	ticker := time.NewTicker(syncCheckInterval)
+	defer ticker.Stop()
+	for {
+		requested := false
+		select {
+		case <-ticker.C:
+		case <-a.syncRequested:
+			requested = true
+		}
+
+		a.mu.RLock()
+		due := a.settings.Sync != nil && !a.locked && (requested || !time.Now().Before(a.nextSync))
+		a.mu.RUnlock()
+		if due {
+			if _, err := a.Sync(); err != nil {
+				println("Error syncing:", err.Error())
+			}
+		}
+	}
+}
+
+// webdavProvider syncs with a file on a WebDAV server, using ETags to
+// detect changes made by other devices
+type webdavProvider struct {
+	url      string
+	username string
+	password string
+	client   *http.Client
+}
+
+// request makes an authenticated request to the data file
+func (p *webdavProvider) request(method string, body []byte, header map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(method, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if p.username != "" || p.password != "" {
+		req.SetBasicAuth(p.username, p.password)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	return p.client.Do(req)
+}
+
+// download fetches the data file
+func (p *webdavProvider) download() ([]byte, string, error) {
+	resp, err := p.request(http.MethodGet, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, "", errRemoteMissing
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, "", errors.New("the server rejected the username or password")
+	case resp.StatusCode >= 300:
+		return nil, "", fmt.Errorf("server answered %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	tag := resp.Header.Get("ETag")
+	if tag == "" {
+		tag = "sum:" + checksum(data)
+	}
+	return data, tag, nil
+}
+
+// upload writes the data file if it's still at tag. Servers without ETags
+// get a checksum tag, which can't be checked on upload.
+func (p *webdavProvider) upload(data []byte, tag string) (string, error) {
+	header := map[string]string{"Content-Type": "application/json"}
+	switch {
+	case tag == "":
+		header["If-None-Match"] = "*"
+	case len(tag) < 4 || tag[:4] != "sum:":
+		header["If-Match"] = tag
+	}
+
+	resp, err := p.request(http.MethodPut, data, header)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusPreconditionFailed:
+		return "", errRemoteChanged
+	case resp.StatusCode == http.StatusConflict:
+		return "", errors.New("the folder for the data file doesn't exist on the server")
+	case resp.StatusCode == http.StatusUnauthorized:
+		return "", errors.New("the server rejected the username or password")
+	case resp.StatusCode >= 300:
+		return "", fmt.Errorf("server answered %s", resp.Status)
+	}
+	if newTag := resp.Header.Get("ETag"); newTag != "" {
+		return newTag, nil
+	}
+	return "sum:" + checksum(data), nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Taper steps a count or negative task's daily limit down week by week,
+// e.g. cigarettes from 10 to 0 over 8 weeks. During a taper a day succeeds
+// when the recorded value stays at or under that week's target.
+type Taper struct {
+	Start string `json:"start"` // First day of the taper
+	From  int    `json:"from"`  // Target in the first week
+	To    int    `json:"to"`    // Target from the last week on
+	Weeks int    `json:"weeks"` // Weeks to go from From to To
+}
+
+// TaperStep is one week of a taper schedule with how it went
+type TaperStep struct {
+	WeekStart    string `json:"weekStart"`
+	Target       int    `json:"target"`
+	Days         int    `json:"days"`         // Days with a recorded value
+	DaysOnTarget int    `json:"daysOnTarget"` // Recorded days at or under target
+	Total        int    `json:"total"`
+}
+
+// TaperProgress reports a tapering task's schedule and adherence
+type TaperProgress struct {
+	TaskID        string      `json:"taskId"`
+	Taper         Taper       `json:"taper"`
+	CurrentWeek   int         `json:"currentWeek"` // 1-based; 0 before the start
+	CurrentTarget int         `json:"currentTarget"`
+	Steps         []TaperStep `json:"steps"`
+}
+
+// taperTargetOn resolves a tapering task's target for a date. ok is false
+// when the task has no taper or the taper hasn't started.
+func taperTargetOn(task TaskTemplate, date string) (target int, ok bool) {
+	if task.Taper == nil || date < task.Taper.Start {
+		return 0, false
+	}
+	start, err := time.Parse("2006-01-02", task.Taper.Start)
+	if err != nil {
+		return 0, false
+	}
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 0, false
+	}
+
+	t := task.Taper
+	week := int(day.Sub(start).Hours()/24) / 7
+	if week >= t.Weeks-1 {
+		return t.To, true
+	}
+	// Evenly spaced steps: week 0 is From, week Weeks-1 is To
+	step := float64(t.To-t.From) / float64(t.Weeks-1)
+	return t.From + int(math.Round(step*float64(week))), true
+}
+
+// targetOn returns a task's target for a date, following its taper if any
+func targetOn(task TaskTemplate, date string) int {
+	if target, ok := taperTargetOn(task, date); ok {
+		return target
+	}
+	return task.Target
+}
+
+// SetTaskTaper starts a taper on a count or negative task. weeks 0 removes it.
+func (a *App) SetTaskTaper(taskID string, start string, from int, to int, weeks int) error {
+	if weeks != 0 {
+		if _, err := time.Parse("2006-01-02", start); err != nil {
+			return errors.New("invalid start date")
+		}
+		if weeks < 2 || weeks > 104 {
+			return errors.New("a taper must last between 2 and 104 weeks")
+		}
+		if from < 0 || to < 0 || to > from {
+			return errors.New("a taper must step down to a lower target")
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID != taskID {
+			continue
+		}
+		if weeks != 0 && taskTypeOf(t) != "count" && taskTypeOf(t) != "negative" {
+			return errors.New("only count and negative tasks can taper")
+		}
+
+		var taper *Taper
+		if weeks != 0 {
+			taper = &Taper{Start: start, From: from, To: to, Weeks: weeks}
+		}
+		a.audit("SetTaskTaper", "", taskID, t.Taper, taper)
+		a.data.Templates[i].Taper = taper
+		return a.saveDataLocked()
+	}
+
+	return errors.New("task not found")
+}
+
+// GetTaperProgress returns a tapering task's weekly targets and how many
+// recorded days stayed on target
+func (a *App) GetTaperProgress(taskID string) (TaperProgress, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		return TaperProgress{}, errors.New("task not found")
+	}
+	if task.Taper == nil {
+		return TaperProgress{}, errors.New("task has no taper")
+	}
+	start, err := time.Parse("2006-01-02", task.Taper.Start)
+	if err != nil {
+		return TaperProgress{}, errors.New("invalid taper start")
+	}
+
+	today := time.Now().Format("2006-01-02")
+	progress := TaperProgress{TaskID: taskID, Taper: *task.Taper, Steps: []TaperStep{}}
+	progress.CurrentTarget = targetOn(task, today)
+
+	for w := 0; w < task.Taper.Weeks; w++ {
+		weekStart := start.AddDate(0, 0, 7*w)
+		step := TaperStep{WeekStart: weekStart.Format("2006-01-02")}
+		step.Target, _ = taperTargetOn(task, step.WeekStart)
+
+		for d := 0; d < 7; d++ {
+			date := weekStart.AddDate(0, 0, d).Format("2006-01-02")
+			if date == today || (date < today && date >= step.WeekStart) {
+				progress.CurrentWeek = w + 1
+			}
+			value, recorded := a.data.Days[date][task.ID]
+			if !recorded || date > today || a.dayExcludedLocked(date) {
+				continue
+			}
+			step.Days++
+			step.Total += value
+			if value <= step.Target {
+				step.DaysOnTarget++
+			}
+		}
+		progress.Steps = append(progress.Steps, step)
+	}
+
+	return progress, nil
+}
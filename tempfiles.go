@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// tempFilePattern matches the temporary files atomicWriteFile writes
+	// before renaming them into place
+	tempFilePattern = "plan-tmp-*.json"
+	// staleTempAge is how old a temporary file must be before it's taken
+	// to be left over from a crash rather than a write in progress
+	staleTempAge = time.Hour
+)
+
+// TempCleanup describes the last cleanup of leftover temporary files
+type TempCleanup struct {
+	At      string `json:"at"` // RFC3339
+	Removed int    `json:"removed"`
+	Failed  int    `json:"failed"` // Stale files that couldn't be removed, e.g. held open elsewhere
+}
+
+// exportFolderLocked returns the PLAN_Exports folder under the configured export
+// path (Downloads by default) (must hold lock)
+func (a *App) exportFolderLocked() (string, error) {
+	exportDir := a.settings.ExportPath
+	if exportDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		exportDir = filepath.Join(homeDir, "Downloads")
+	}
+	return filepath.Join(exportDir, "PLAN_Exports"), nil
+}
+
+// cleanupTempFiles removes temporary files a crash left behind in the data,
+// settings, backup and export folders
+func (a *App) cleanupTempFiles() TempCleanup {
+	a.mu.RLock()
+	dirs := []string{filepath.Dir(a.dataPath), filepath.Dir(a.settingsPath), a.backupsDir()}
+	if dir, err := a.exportFolderLocked(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	a.mu.RUnlock()
+
+	cleanup := TempCleanup{At: time.Now().Format(time.RFC3339)}
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		removed, failed := a.removeStaleTempFiles(dir, time.Now())
+		cleanup.Removed += removed
+		cleanup.Failed += failed
+	}
+
+	a.mu.Lock()
+	a.tempCleanup = &cleanup
+	a.mu.Unlock()
+	return cleanup
+}
+
+// removeStaleTempFiles removes temporary files in dir older than
+// staleTempAge, skipping any this app is still writing
+func (a *App) removeStaleTempFiles(dir string, now time.Time) (removed, failed int) {
+	paths, err := filepath.Glob(filepath.Join(dir, tempFilePattern))
+	if err != nil {
+		return 0, 0
+	}
+	for _, path := range paths {
+		if _, writing := a.tempFiles.Load(path); writing {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() || now.Sub(info.ModTime()) < staleTempAge {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			failed++
+			continue
+		}
+		removed++
+	}
+	return removed, failed
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// tierReachedEvent is emitted when a save lifts a task to a higher tier for the day
+const tierReachedEvent = "plan:tier-reached"
+
+// tierNames name the tiers from lowest to highest
+var tierNames = []string{"bronze", "silver", "gold"}
+
+// TierReached describes a task reaching a new tier on a day
+type TierReached struct {
+	TaskID   string `json:"taskId"`
+	TaskName string `json:"taskName"`
+	Date     string `json:"date"`
+	Tier     int    `json:"tier"` // 1 = bronze .. 3 = gold
+	Name     string `json:"name"`
+}
+
+// TierDistribution counts a task's days per tier in a month
+type TierDistribution struct {
+	TaskID string   `json:"taskId"`
+	Month  string   `json:"month"` // "2006-01"
+	Tiers  []int    `json:"tiers"`
+	Names  []string `json:"names"`  // Tier names, lowest first
+	Counts []int    `json:"counts"` // Days per tier: none, bronze, silver, gold
+	Days   int      `json:"days"`   // Days counted, excluded days left out
+}
+
+// tierOf returns the highest tier a value reaches: 0 for none, 1 for bronze
+// and so on
+func tierOf(task TaskTemplate, value int) int {
+	tier := 0
+	for i, threshold := range task.Tiers {
+		if value >= threshold {
+			tier = i + 1
+		}
+	}
+	return tier
+}
+
+// SetTaskTiers sets up to three ascending thresholds (bronze, silver, gold)
+// on a count or duration task, e.g. 5000/8000/12000 steps. An empty list
+// removes them.
+func (a *App) SetTaskTiers(taskID string, tiers []int) error {
+	if len(tiers) > len(tierNames) {
+		return fmt.Errorf("a task can have at most %d tiers", len(tierNames))
+	}
+	for i, threshold := range tiers {
+		if threshold <= 0 {
+			return errors.New("tier thresholds must be positive")
+		}
+		if i > 0 && threshold <= tiers[i-1] {
+			return errors.New("tier thresholds must go up")
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	i := a.templateIndexLocked(taskID)
+	if i < 0 {
+		return errors.New("task not found")
+	}
+	t := a.data.Templates[i]
+	if len(tiers) > 0 && taskTypeOf(t) != "count" && taskTypeOf(t) != "duration" {
+		return errors.New("only count and duration tasks can have tiers")
+	}
+
+	if len(tiers) == 0 {
+		tiers = nil
+	}
+	a.audit("SetTaskTiers", "", taskID, t.Tiers, tiers)
+	a.data.Templates[i].Tiers = tiers
+	return a.saveDataLocked()
+}
+
+// GetTierDays returns the tier reached on each recorded day between from
+// and to (inclusive), for shading a task's heatmap by tier
+func (a *App) GetTierDays(taskID string, from string, to string) (map[string]int, error) {
+	r, err := newDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	defer a.lockDays(r.From, r.To)()
+
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		return nil, errors.New("task not found")
+	}
+	result := make(map[string]int)
+	for date, tasks := range a.data.Days {
+		if value, recorded := tasks[taskID]; recorded && r.contains(date) {
+			result[date] = tierOf(task, value)
+		}
+	}
+	return result, nil
+}
+
+// GetTierDistribution counts how many days of a month reached each tier.
+// Days before the task existed, future days and excluded days are left out.
+func (a *App) GetTierDistribution(taskID string, year int, month int) (TierDistribution, error) {
+	if month < 1 || month > 12 {
+		return TierDistribution{}, errors.New("invalid month")
+	}
+
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	defer a.lockDays(first.Format("2006-01-02"), first.AddDate(0, 1, -1).Format("2006-01-02"))()
+
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		return TierDistribution{}, errors.New("task not found")
+	}
+	if len(task.Tiers) == 0 {
+		return TierDistribution{}, errors.New("task has no tiers")
+	}
+
+	dist := TierDistribution{
+		TaskID: taskID,
+		Month:  first.Format("2006-01"),
+		Tiers:  task.Tiers,
+		Names:  tierNames[:len(task.Tiers)],
+		Counts: make([]int, len(task.Tiers)+1),
+	}
+	today := time.Now().Format("2006-01-02")
+	for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		if date > today || !a.taskAppliesLocked(task, date) || a.dayExcludedLocked(date) {
+			continue
+		}
+		dist.Counts[tierOf(task, a.data.Days[date][taskID])]++
+		dist.Days++
+	}
+	return dist, nil
+}
+
+// tiersReachedLocked lists tasks whose tier on date went up from the old
+// values (must hold lock)
+func (a *App) tiersReachedLocked(date string, old DayTasks, taskIDs []string) []TierReached {
+	reached := []TierReached{}
+	for _, id := range taskIDs {
+		task, ok := a.findTemplateLocked(id)
+		if !ok || len(task.Tiers) == 0 {
+			continue
+		}
+		tier := tierOf(task, a.data.Days[date][id])
+		if tier > tierOf(task, old[id]) {
+			reached = append(reached, TierReached{TaskID: id, TaskName: task.Name, Date: date, Tier: tier, Name: tierNames[tier-1]})
+		}
+	}
+	return reached
+}
+
+// emitTiersReached tells the frontend about newly reached tiers
+func (a *App) emitTiersReached(reached []TierReached) {
+	if a.ctx != nil && len(reached) > 0 {
+		runtime.EventsEmit(a.ctx, tierReachedEvent, reached)
+	}
+}
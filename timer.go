@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// StartTimer starts tracking time for a duration task.
+// The start time is persisted so a running timer survives app restarts.
+func (a *App) StartTimer(taskID string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	task, ok := a.findTemplateLocked(taskID)
+	if !ok {
+		return errors.New("task not found")
+	}
+	if taskTypeOf(task) != "duration" {
+		return errors.New("task is not a duration task")
+	}
+	if isAutoTask(task) {
+		return errors.New("task value is computed automatically")
+	}
+
+	if a.data.Timers == nil {
+		a.data.Timers = make(map[string]string)
+	}
+	if _, running := a.data.Timers[taskID]; running {
+		return nil
+	}
+
+	start := time.Now().Format(time.RFC3339)
+	a.data.Timers[taskID] = start
+	a.audit("StartTimer", start[:10], taskID, nil, start)
+	return a.saveDataLocked()
+}
+
+// StopTimer stops a running timer and adds the elapsed minutes to the
+// value of the day the timer was started on. The run is kept as a focus
+// session; when that day is locked for editing, only the session is kept
+// and the lock error returned. Returns the new day value.
+func (a *App) StopTimer(taskID string) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	startStr, running := a.data.Timers[taskID]
+	if !running {
+		return 0, errors.New("timer is not running")
+	}
+
+	start, err := time.Parse(time.RFC3339, startStr)
+	delete(a.data.Timers, taskID)
+	if err != nil {
+		// Drop the corrupt timer rather than leaving it stuck
+		a.saveDataLocked()
+		return 0, err
+	}
+
+	end := time.Now()
+	minutes := int(math.Round(end.Sub(start).Minutes()))
+	if minutes < 0 {
+		minutes = 0
+	}
+
+	dateKey := start.Format("2006-01-02")
+	if err := a.checkEditableLocked(dateKey); err != nil {
+		// The run is still kept as a session, but the locked day's value isn't touched
+		a.recordFocusSessionLocked(taskID, dateKey, start, end, minutes)
+		a.audit("StopTimer", dateKey, taskID, nil, nil)
+		if saveErr := a.saveDataLocked(); saveErr != nil {
+			return 0, saveErr
+		}
+		return 0, err
+	}
+	if err := a.pullArchivedDayLocked(dateKey); err != nil {
+		return 0, err
+	}
+	if a.data.Days == nil {
+		a.data.Days = make(map[string]DayTasks)
+	}
+	if a.data.Days[dateKey] == nil {
+		a.data.Days[dateKey] = make(DayTasks)
+	}
+
+	had := auditValue(a.data.Days[dateKey], taskID)
+	old := a.data.Days[dateKey][taskID]
+	a.data.Days[dateKey][taskID] = old + minutes
+	a.recordFocusSessionLocked(taskID, dateKey, start, end, minutes)
+	a.audit("StopTimer", dateKey, taskID, had, old+minutes)
+	a.updateRecordsLocked(dateKey, []string{taskID})
+	a.checkLifetimeGoalsLocked([]string{taskID})
+
+	return old + minutes, a.saveDataLocked()
+}
+
+// GetRunningTimers returns the start time of every running timer keyed by task ID
+func (a *App) GetRunningTimers() map[string]string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := make(map[string]string)
+	for k, v := range a.data.Timers {
+		result[k] = v
+	}
+	return result
+}
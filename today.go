@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+// Prefill modes for count and duration tasks on a day with nothing recorded yet
+const (
+	prefillNone      = ""
+	prefillZero      = "zero"
+	prefillYesterday = "yesterday"
+	prefillTarget    = "target"
+)
+
+// TodayView is the day screen's data for the current date. Provisional
+// values come from a task's prefill setting and aren't saved until the user
+// saves the day.
+type TodayView struct {
+	Date        string          `json:"date"`
+	Tasks       []TaskTemplate  `json:"tasks"`
+	Values      map[string]int  `json:"values"`
+	Provisional map[string]bool `json:"provisional"` // taskID -> value is a prefill, not recorded
+	Notes       []FutureNote    `json:"notes"`       // Future notes revealed today
+}
+
+// GetToday returns today's tasks and values, with prefilled values for
+// count and duration tasks that have nothing recorded yet
+func (a *App) GetToday() TodayView {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	today := a.checkInDateLocked(time.Now())
+	view := TodayView{
+		Date:   today,
+		Tasks:  a.getTasksForDateLocked(today),
+		Values: make(map[string]int),
+	}
+
+	for i := range view.Tasks {
+		if view.Tasks[i].Type == "" {
+			view.Tasks[i].Type = "binary"
+		}
+	}
+	a.sortTasksLocked(view.Tasks)
+
+	for k, v := range a.data.Days[today] {
+		view.Values[k] = v
+	}
+	view.Provisional = a.prefillLocked(today, view.Tasks, view.Values)
+	view.Notes = a.futureNotesForLocked(today)
+
+	return view
+}
+
+// prefillLocked fills in values for tasks with a prefill mode and nothing
+// recorded on date, returning which values were filled (must hold lock)
+func (a *App) prefillLocked(date string, tasks []TaskTemplate, values map[string]int) map[string]bool {
+	provisional := make(map[string]bool)
+
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return provisional
+	}
+	yesterday := t.AddDate(0, 0, -1).Format("2006-01-02")
+
+	for _, task := range tasks {
+		if task.Prefill == prefillNone || isAutoTask(task) {
+			continue
+		}
+		if _, recorded := values[task.ID]; recorded {
+			continue
+		}
+
+		switch task.Prefill {
+		case prefillZero:
+			values[task.ID] = 0
+		case prefillYesterday:
+			values[task.ID] = a.data.Days[yesterday][task.ID]
+		case prefillTarget:
+			values[task.ID] = targetOn(task, date)
+		default:
+			continue
+		}
+		provisional[task.ID] = true
+	}
+
+	return provisional
+}
+
+// SetTaskPrefill sets how a count or duration task is prefilled on a new day:
+// "" (off), "zero", "yesterday" or "target"
+func (a *App) SetTaskPrefill(taskID string, mode string) error {
+	switch mode {
+	case prefillNone, prefillZero, prefillYesterday, prefillTarget:
+	default:
+		return errors.New("invalid prefill mode")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, t := range a.data.Templates {
+		if t.ID != taskID {
+			continue
+		}
+		if mode != prefillNone && taskTypeOf(t) != "count" && taskTypeOf(t) != "duration" {
+			return errors.New("only count and duration tasks can be prefilled")
+		}
+		a.data.Templates[i].Prefill = mode
+		a.audit("SetTaskPrefill", "", taskID, t.Prefill, mode)
+		return a.saveDataLocked()
+	}
+
+	return errors.New("task not found")
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Strategies for ImportData
+const (
+	importReplace    = "replace"
+	importKeepMine   = "merge-keep-mine"
+	importKeepTheirs = "merge-keep-theirs"
+)
+
+// ImportResult counts what an import changed
+type ImportResult struct {
+	Tasks       int    `json:"tasks"`  // Tasks added or updated
+	Values      int    `json:"values"` // Day values added or overwritten
+	Notes       int    `json:"notes"`  // Annotations added
+	SpecialDays int    `json:"specialDays"`
+	BackupPath  string `json:"backupPath"` // Copy of the data from before the import
+}
+
+// ExportAllData returns a copy of the complete planner data, in the same
+// shape as data.json
+func (a *App) ExportAllData() (PlannerData, error) {
+	a.mu.RLock()
+	encoded, err := json.Marshal(a.data)
+	a.mu.RUnlock()
+	if err != nil {
+		return PlannerData{}, err
+	}
+
+	var copied PlannerData
+	err = json.Unmarshal(encoded, &copied)
+	return copied, err
+}
+
+// ImportData loads planner data exported by ExportAllData (or a data.json).
+//   - "replace": the imported data replaces everything
+//   - "merge-keep-mine": only tasks, values and notes missing here are added
+//   - "merge-keep-theirs": as above, but imported tasks and values also
+//     overwrite the ones here
+//   - "sync": data from another device; tasks and notes merge as with
+//     "merge-keep-mine", day values by their stamps (see ValueStamp)
+//
+// The current data is backed up first either way.
+func (a *App) ImportData(data string, strategy string) (ImportResult, error) {
+	if strategy != importReplace && strategy != importKeepMine && strategy != importKeepTheirs && strategy != importSync {
+		return ImportResult{}, errors.New(`strategy must be "replace", "merge-keep-mine", "merge-keep-theirs" or "sync"`)
+	}
+	if strategy == importReplace {
+		return ImportResult{}, a.restoreData("ImportData", importReplace, []byte(data), "")
+	}
+
+	other, quarantined, ok := decodePlannerData([]byte(data))
+	if !ok {
+		return ImportResult{}, errors.New("data is not in the PLAN format")
+	}
+	if other.Version > schemaVersion {
+		return ImportResult{}, errNewerData
+	}
+
+	a.mu.Lock()
+	backupPath, err := a.preChangeBackupLocked("import")
+	if err != nil {
+		a.mu.Unlock()
+		return ImportResult{}, err
+	}
+
+	a.rememberLocked("ImportData")
+	var result ImportResult
+	if strategy == importSync {
+		result = a.syncDataLocked(other)
+	} else {
+		result = a.mergeDataLocked(other, strategy == importKeepTheirs)
+	}
+	result.BackupPath = backupPath
+	if len(quarantined) > 0 {
+		a.quarantineLocked(quarantined)
+	}
+	a.audit("ImportData", "", "", nil, strategy)
+	err = a.saveDataLocked()
+	a.mu.Unlock()
+
+	a.emitDataChanged("")
+	a.refreshMenu()
+	return result, err
+}
+
+// mergeDataLocked merges tasks, day values, readings, notes and special
+// days from other. Where both sides have something, theirs decides which
+// wins (must hold lock).
+func (a *App) mergeDataLocked(other PlannerData, theirs bool) ImportResult {
+	result := ImportResult{}
+
+	index := make(map[string]int)
+	for i, t := range a.data.Templates {
+		index[t.ID] = i
+	}
+	for _, t := range other.Templates {
+		i, exists := index[t.ID]
+		if !exists {
+			a.data.Templates = append(a.data.Templates, t)
+			result.Tasks++
+		} else if theirs {
+			a.data.Templates[i] = t
+			result.Tasks++
+		}
+	}
+
+	if a.data.Days == nil {
+		a.data.Days = make(map[string]DayTasks)
+	}
+	for date, tasks := range other.Days {
+		if err := a.pullArchivedDayLocked(date); err != nil {
+			println("Error reading archived day:", err.Error())
+			continue
+		}
+		if a.data.Days[date] == nil {
+			a.data.Days[date] = make(DayTasks)
+		}
+		for id, value := range tasks {
+			mine, exists := a.data.Days[date][id]
+			if !exists || (theirs && mine != value) {
+				a.data.Days[date][id] = value
+				result.Values++
+			}
+		}
+	}
+
+	for date, readings := range other.Measurements {
+		if a.data.Measurements == nil {
+			a.data.Measurements = make(map[string]map[string]float64)
+		}
+		if a.data.Measurements[date] == nil {
+			a.data.Measurements[date] = make(map[string]float64)
+		}
+		for id, reading := range readings {
+			if _, exists := a.data.Measurements[date][id]; !exists || theirs {
+				a.data.Measurements[date][id] = reading
+			}
+		}
+	}
+
+	notes := make(map[string]bool)
+	for _, note := range a.data.Annotations {
+		notes[note.ID] = true
+	}
+	for _, note := range other.Annotations {
+		if !notes[note.ID] {
+			a.data.Annotations = append(a.data.Annotations, note)
+			result.Notes++
+		}
+	}
+
+	special := make(map[string]bool)
+	for _, day := range a.data.SpecialDays {
+		special[day.ID] = true
+	}
+	for _, day := range other.SpecialDays {
+		if !special[day.ID] {
+			a.data.SpecialDays = append(a.data.SpecialDays, day)
+			result.SpecialDays++
+		}
+	}
+
+	for _, r := range other.Vacations {
+		if !containsRange(a.data.Vacations, r) {
+			a.data.Vacations = append(a.data.Vacations, r)
+		}
+	}
+
+	if result.Tasks+result.Values > 0 {
+		a.rebuildRecordsLocked()
+	}
+	return result
+}
+
+// syncDataLocked merges data from another device: tasks and notes as with
+// "merge-keep-mine", day values by their stamps (must hold lock)
+func (a *App) syncDataLocked(other PlannerData) ImportResult {
+	days := other.Days
+	other.Days = nil // Merged by stamp instead
+	result := a.mergeDataLocked(other, false)
+	other.Days = days
+	if result.Values = a.syncDaysLocked(other); result.Values > 0 {
+		a.rebuildRecordsLocked()
+	}
+	return result
+}
+
+// containsRange reports whether ranges includes r exactly
+func containsRange(ranges []DateRange, r DateRange) bool {
+	for _, existing := range ranges {
+		if existing == r {
+			return true
+		}
+	}
+	return false
+}
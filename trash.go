@@ -0,0 +1,106 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// TrashItem is a deleted task waiting in the trash. Deleted tasks stay
+// among the templates, since days before the deletion still count them,
+// until the trash is emptied or they expire.
+type TrashItem struct {
+	Task      TaskTemplate `json:"task"`
+	DeletedAt string       `json:"deletedAt"`
+	PurgeOn   string       `json:"purgeOn,omitempty"` // When maintenance purges it; "" when kept until emptied
+	Values    int          `json:"values"`            // Day values purged with it
+}
+
+// GetTrash returns the deleted tasks, most recently deleted first
+func (a *App) GetTrash() []TrashItem {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	trashDays := 0
+	if a.data.Retention != nil {
+		trashDays = a.data.Retention.TrashDays
+	}
+
+	items := []TrashItem{}
+	for _, t := range a.data.Templates {
+		if t.DeletedAt == nil {
+			continue
+		}
+		if t.Type == "" {
+			t.Type = "binary"
+		}
+		item := TrashItem{Task: t, DeletedAt: *t.DeletedAt}
+		if deleted, err := time.Parse("2006-01-02", *t.DeletedAt); err == nil && trashDays > 0 {
+			item.PurgeOn = deleted.AddDate(0, 0, trashDays).Format("2006-01-02")
+		}
+		for _, tasks := range a.data.Days {
+			if _, ok := tasks[t.ID]; ok {
+				item.Values++
+			}
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt > items[j].DeletedAt
+	})
+	return items
+}
+
+// RestoreFromTrash takes a task out of the trash; see UndeleteTask
+func (a *App) RestoreFromTrash(id string) error {
+	return a.UndeleteTask(id)
+}
+
+// EmptyTrash purges every deleted task with everything recorded for it.
+// The data is backed up first. Returns how many tasks were purged.
+func (a *App) EmptyTrash() (int, error) {
+	a.mu.Lock()
+	ids := a.trashedLocked("")
+	if len(ids) == 0 {
+		a.mu.Unlock()
+		return 0, nil
+	}
+	if _, err := a.preChangeBackupLocked("empty-trash"); err != nil {
+		a.mu.Unlock()
+		return 0, err
+	}
+
+	a.rememberLocked("EmptyTrash")
+	names := a.purgeTasksLocked(ids)
+	a.audit("EmptyTrash", "", "", names, nil)
+	err := a.saveDataLocked()
+	a.mu.Unlock()
+
+	a.emitDataChanged("")
+	return len(ids), err
+}
+
+// trashedLocked returns the IDs of tasks deleted before cutoff, or of all
+// deleted tasks when cutoff is "" (must hold lock)
+func (a *App) trashedLocked(cutoff string) []string {
+	ids := []string{}
+	for _, t := range a.data.Templates {
+		if t.DeletedAt != nil && (cutoff == "" || *t.DeletedAt < cutoff) {
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids
+}
+
+// purgeTasksLocked purges tasks by ID, returning their names (must hold
+// lock; caller saves)
+func (a *App) purgeTasksLocked(ids []string) []string {
+	names := []string{}
+	for _, id := range ids {
+		if i := a.templateIndexLocked(id); i >= 0 {
+			names = append(names, a.data.Templates[i].Name)
+			a.purgeTaskLocked(i)
+		}
+	}
+	return names
+}
@@ -0,0 +1,459 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// maxUndoSteps bounds the undo history
+const maxUndoSteps = 50
+
+// undoEvent is emitted after an undo or redo with an UndoChange payload
+const undoEvent = "plan:undo"
+
+// undoPart identifies one piece of the data an undo step puts back: a
+// template, a day value, a whole day, one entry of a map field, or a whole
+// field
+type undoPart struct {
+	field string // JSON name of the PlannerData field
+	key   string // Template ID, date or map key; "" for a whole field
+	task  string // Task ID within a date, for day values; "" for the day itself
+}
+
+// undoStep is a change that can be undone: the JSON of every part it
+// touched before and after it, nil where the part was absent
+type undoStep struct {
+	label  string
+	before map[undoPart][]byte
+	after  map[undoPart][]byte
+}
+
+// pendingUndo is a change being recorded, until the save that ends it
+type pendingUndo struct {
+	label    string
+	daysOnly bool                // Only day values change, so nothing else was taken
+	before   map[undoPart][]byte // Parts other than day values; day values come from savedDays
+}
+
+// undoSkippedFields are left out of undo steps: stamps and records follow
+// from the rest of the data
+var undoSkippedFields = map[string]bool{"days": true, "stamps": true, "records": true}
+
+// UndoState tells the frontend what Undo and Redo would do
+type UndoState struct {
+	CanUndo   bool   `json:"canUndo"`
+	CanRedo   bool   `json:"canRedo"`
+	UndoLabel string `json:"undoLabel,omitempty"` // e.g. "DeleteTask"
+	RedoLabel string `json:"redoLabel,omitempty"`
+}
+
+// UndoChange describes what an undo or redo changed
+type UndoChange struct {
+	Action  string    `json:"action"` // "undo" or "redo"
+	Label   string    `json:"label"`  // The change that was undone or redone
+	Dates   []string  `json:"dates"`  // Days whose values changed
+	TaskIDs []string  `json:"taskIds"`
+	State   UndoState `json:"state"`
+}
+
+// rememberLocked starts recording a change so it can be undone, and
+// forgets anything that could be redone. The step ends with the next save
+// (must hold lock).
+func (a *App) rememberLocked(label string) {
+	if a.pendingUndo != nil {
+		return
+	}
+	a.pendingUndo = &pendingUndo{label: label, before: a.undoPartsLocked()}
+}
+
+// rememberDaysLocked is rememberLocked for a change to day values only,
+// which doesn't need the rest of the data taken (must hold lock)
+func (a *App) rememberDaysLocked(label string) {
+	if a.pendingUndo != nil {
+		return
+	}
+	a.pendingUndo = &pendingUndo{label: label, daysOnly: true}
+}
+
+// finishUndoStepLocked ends the change being recorded, keeping the parts it
+// touched as an undo step. Runs at the start of every save (must hold lock).
+func (a *App) finishUndoStepLocked() {
+	pending := a.pendingUndo
+	if pending == nil {
+		return
+	}
+	a.pendingUndo = nil
+
+	step := undoStep{label: pending.label, before: make(map[undoPart][]byte), after: make(map[undoPart][]byte)}
+	for date, tasks := range a.data.Days {
+		saved, had := a.savedDays[date]
+		if !had {
+			step.before[undoPart{field: "days", key: date}] = nil
+			step.after[undoPart{field: "days", key: date}] = []byte("{}")
+		}
+		for id, value := range tasks {
+			if old, ok := saved[id]; !ok || old != value {
+				part := undoPart{field: "days", key: date, task: id}
+				step.before[part] = nil
+				if ok {
+					step.before[part] = undoJSON(old)
+				}
+				step.after[part] = undoJSON(value)
+			}
+		}
+	}
+	for date, saved := range a.savedDays {
+		tasks, has := a.data.Days[date]
+		if !has {
+			step.before[undoPart{field: "days", key: date}] = []byte("{}")
+			step.after[undoPart{field: "days", key: date}] = nil
+		}
+		for id, old := range saved {
+			if _, ok := tasks[id]; !ok {
+				part := undoPart{field: "days", key: date, task: id}
+				step.before[part] = undoJSON(old)
+				step.after[part] = nil
+			}
+		}
+	}
+
+	if !pending.daysOnly {
+		now := a.undoPartsLocked()
+		for part, value := range now {
+			if prev, ok := pending.before[part]; !ok || !bytes.Equal(prev, value) {
+				step.before[part] = prev
+				step.after[part] = value
+			}
+		}
+		for part, prev := range pending.before {
+			if _, ok := now[part]; !ok {
+				step.before[part] = prev
+				step.after[part] = nil
+			}
+		}
+	}
+
+	if len(step.before) == 0 {
+		return
+	}
+	a.undoStack = append(a.undoStack, step)
+	if len(a.undoStack) > maxUndoSteps {
+		a.undoStack = a.undoStack[len(a.undoStack)-maxUndoSteps:]
+	}
+	a.redoStack = nil
+}
+
+// clearUndoLocked forgets the undo history, for when the data is replaced
+// wholesale (must hold lock)
+func (a *App) clearUndoLocked() {
+	a.undoStack = nil
+	a.redoStack = nil
+	a.pendingUndo = nil
+}
+
+// undoJSON encodes a part's value; values in the data always encode
+func undoJSON(value any) []byte {
+	encoded, _ := json.Marshal(value)
+	return encoded
+}
+
+// undoFieldName returns a PlannerData field's JSON name
+func undoFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
+
+// undoPartsLocked takes every part of the data other than day values:
+// templates by ID, map fields by key and other fields whole, leaving out
+// absent ones (must hold lock)
+func (a *App) undoPartsLocked() map[undoPart][]byte {
+	parts := make(map[undoPart][]byte)
+	for _, t := range a.data.Templates {
+		parts[undoPart{field: "templates", key: t.ID}] = undoJSON(t)
+	}
+
+	data := reflect.ValueOf(a.data)
+	for i := 0; i < data.NumField(); i++ {
+		name := undoFieldName(data.Type().Field(i))
+		if name == "templates" || undoSkippedFields[name] {
+			continue
+		}
+		field := data.Field(i)
+		if field.Kind() == reflect.Map && field.Type().Key().Kind() == reflect.String {
+			iter := field.MapRange()
+			for iter.Next() {
+				parts[undoPart{field: name, key: iter.Key().String()}] = undoJSON(iter.Value().Interface())
+			}
+			continue
+		}
+		if !field.IsZero() {
+			parts[undoPart{field: name}] = undoJSON(field.Interface())
+		}
+	}
+	return parts
+}
+
+// undoPartLocked returns one part of the data as undoPartsLocked would,
+// nil when it's absent (must hold lock)
+func (a *App) undoPartLocked(part undoPart) []byte {
+	switch part.field {
+	case "days":
+		tasks, ok := a.data.Days[part.key]
+		if !ok {
+			return nil
+		}
+		if part.task == "" {
+			return []byte("{}")
+		}
+		if value, ok := tasks[part.task]; ok {
+			return undoJSON(value)
+		}
+		return nil
+	case "templates":
+		if i := a.templateIndexLocked(part.key); i >= 0 {
+			return undoJSON(a.data.Templates[i])
+		}
+		return nil
+	}
+
+	field := a.undoFieldLocked(part.field)
+	if !field.IsValid() {
+		return nil
+	}
+	if part.key != "" {
+		value := field.MapIndex(reflect.ValueOf(part.key).Convert(field.Type().Key()))
+		if !value.IsValid() {
+			return nil
+		}
+		return undoJSON(value.Interface())
+	}
+	if field.IsZero() {
+		return nil
+	}
+	return undoJSON(field.Interface())
+}
+
+// setUndoPartLocked puts a part of the data back to value, removing it
+// when value is nil (must hold lock)
+func (a *App) setUndoPartLocked(part undoPart, value []byte) error {
+	switch part.field {
+	case "days":
+		if value == nil {
+			if part.task == "" {
+				delete(a.data.Days, part.key)
+			} else {
+				delete(a.data.Days[part.key], part.task)
+			}
+			return nil
+		}
+		if err := a.pullArchivedDayLocked(part.key); err != nil {
+			return err
+		}
+		if a.data.Days[part.key] == nil {
+			a.data.Days[part.key] = make(DayTasks)
+		}
+		if part.task == "" {
+			return nil
+		}
+		var n int
+		if err := json.Unmarshal(value, &n); err != nil {
+			return err
+		}
+		a.data.Days[part.key][part.task] = n
+		return nil
+	case "templates":
+		i := a.templateIndexLocked(part.key)
+		if value == nil {
+			if i >= 0 {
+				a.data.Templates = append(a.data.Templates[:i], a.data.Templates[i+1:]...)
+			}
+			return nil
+		}
+		var t TaskTemplate
+		if err := json.Unmarshal(value, &t); err != nil {
+			return err
+		}
+		if i >= 0 {
+			a.data.Templates[i] = t
+		} else {
+			a.data.Templates = append(a.data.Templates, t)
+		}
+		return nil
+	}
+
+	field := a.undoFieldLocked(part.field)
+	if !field.IsValid() {
+		return errors.New("unknown field " + part.field)
+	}
+	if part.key != "" {
+		key := reflect.ValueOf(part.key).Convert(field.Type().Key())
+		if value == nil {
+			if !field.IsNil() {
+				field.SetMapIndex(key, reflect.Value{})
+			}
+			return nil
+		}
+		entry := reflect.New(field.Type().Elem())
+		if err := json.Unmarshal(value, entry.Interface()); err != nil {
+			return err
+		}
+		if field.IsNil() {
+			field.Set(reflect.MakeMap(field.Type()))
+		}
+		field.SetMapIndex(key, entry.Elem())
+		return nil
+	}
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	whole := reflect.New(field.Type())
+	if err := json.Unmarshal(value, whole.Interface()); err != nil {
+		return err
+	}
+	field.Set(whole.Elem())
+	return nil
+}
+
+// undoFieldLocked returns the settable PlannerData field with a JSON name
+// (must hold lock)
+func (a *App) undoFieldLocked(name string) reflect.Value {
+	data := reflect.ValueOf(&a.data).Elem()
+	for i := 0; i < data.NumField(); i++ {
+		if undoFieldName(data.Type().Field(i)) == name {
+			return data.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// undoStateLocked reports what can be undone and redone (must hold lock)
+func (a *App) undoStateLocked() UndoState {
+	state := UndoState{CanUndo: len(a.undoStack) > 0, CanRedo: len(a.redoStack) > 0}
+	if state.CanUndo {
+		state.UndoLabel = a.undoStack[len(a.undoStack)-1].label
+	}
+	if state.CanRedo {
+		state.RedoLabel = a.redoStack[len(a.redoStack)-1].label
+	}
+	return state
+}
+
+// GetUndoState returns what Undo and Redo would do
+func (a *App) GetUndoState() UndoState {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.undoStateLocked()
+}
+
+// Undo reverts the most recent deletion, reorder or day edit
+func (a *App) Undo() (UndoChange, error) {
+	return a.stepHistory("undo")
+}
+
+// Redo reapplies the most recently undone change
+func (a *App) Redo() (UndoChange, error) {
+	return a.stepHistory("redo")
+}
+
+// stepHistory moves one step back ("undo") or forward ("redo"), putting
+// back only the parts the step touched. When a change without an undo step
+// has touched them since, it refuses rather than lose that change, and the
+// history it can't step through is forgotten.
+func (a *App) stepHistory(action string) (UndoChange, error) {
+	a.mu.Lock()
+
+	from, to := &a.undoStack, &a.redoStack
+	if action == "redo" {
+		from, to = to, from
+	}
+	if len(*from) == 0 {
+		a.mu.Unlock()
+		return UndoChange{}, errors.New("nothing to " + action)
+	}
+	step := (*from)[len(*from)-1]
+
+	expected, target := step.after, step.before
+	if action == "redo" {
+		expected, target = step.before, step.after
+	}
+	for part, value := range expected {
+		if !bytes.Equal(a.undoPartLocked(part), value) {
+			*from = nil
+			a.mu.Unlock()
+			return UndoChange{}, errors.New("can't " + action + " " + step.label + " without losing changes made since")
+		}
+	}
+
+	// Day values go in after the rest and whole days last, so removing a
+	// day isn't undone by putting back one of its values
+	order := func(part undoPart) int {
+		switch {
+		case part.field != "days":
+			return 0
+		case part.task != "":
+			return 1
+		}
+		return 2
+	}
+	parts := []undoPart{}
+	for part := range target {
+		parts = append(parts, part)
+	}
+	sort.Slice(parts, func(i, j int) bool { return order(parts[i]) < order(parts[j]) })
+
+	change := UndoChange{Action: action, Label: step.label}
+	dates, tasks := make(map[string]bool), make(map[string]bool)
+	for _, part := range parts {
+		if err := a.setUndoPartLocked(part, target[part]); err != nil {
+			a.mu.Unlock()
+			return UndoChange{}, err
+		}
+		switch part.field {
+		case "days":
+			dates[part.key] = true
+			if part.task != "" {
+				tasks[part.task] = true
+			}
+		case "templates":
+			tasks[part.key] = true
+		}
+	}
+	change.Dates, change.TaskIDs = []string{}, []string{}
+	for date := range dates {
+		change.Dates = append(change.Dates, date)
+	}
+	for id := range tasks {
+		change.TaskIDs = append(change.TaskIDs, id)
+	}
+	sort.Strings(change.Dates)
+	sort.Strings(change.TaskIDs)
+	if len(dates) > 0 || len(tasks) > 0 {
+		a.rebuildRecordsLocked()
+	}
+
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, step)
+
+	method := "Undo"
+	if action == "redo" {
+		method = "Redo"
+	}
+	a.audit(method, "", "", nil, step.label)
+	err := a.saveDataLocked()
+	change.State = a.undoStateLocked()
+	a.mu.Unlock()
+
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, undoEvent, change)
+	}
+	a.emitDataChanged("")
+	a.refreshMenu()
+	return change, err
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// externalChangeEvent is emitted after data.json changed outside the app
+// (e.g. by a sync client) and was reloaded
+const externalChangeEvent = "plan:external-change"
+
+// watchDebounce waits for a burst of writes, as sync clients make, to settle
+const watchDebounce = 500 * time.Millisecond
+
+// errExternalChange is returned by a save that would overwrite a version
+// of data.json written by another program
+var errExternalChange = errors.New("data.json was changed outside PLAN; reloading it")
+
+// ExternalChange describes a reload of data.json after an outside change
+type ExternalChange struct {
+	BackupPath string `json:"backupPath"` // This app's data from before the reload
+}
+
+// checksum identifies a version of data.json
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// changedOnDiskLocked reports whether data.json differs from what the app
+// last read or wrote (must hold lock)
+func (a *App) changedOnDiskLocked() bool {
+	if a.dataSum == "" {
+		return false
+	}
+	content, err := os.ReadFile(a.dataPath)
+	return err == nil && checksum(content) != a.dataSum
+}
+
+// watchDataFile reloads data.json whenever another program changes it,
+// until the app exits. The data directory is watched rather than the file,
+// since atomic writes replace the file.
+func (a *App) watchDataFile() {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		println("Error watching data file:", err.Error())
+		return
+	}
+	defer watcher.Close()
+
+	watched := ""
+	rewatch := func() {
+		a.mu.RLock()
+		dir := filepath.Dir(a.dataPath)
+		a.mu.RUnlock()
+		if dir == watched {
+			return
+		}
+		if watched != "" {
+			watcher.Remove(watched)
+		}
+		watched = ""
+		if err := watcher.Add(dir); err != nil {
+			println("Error watching data directory:", err.Error())
+			return
+		}
+		watched = dir
+	}
+	rewatch()
+
+	var settle <-chan time.Time
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-a.dataDirChanged:
+			rewatch()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			a.mu.RLock()
+			path := a.dataPath
+			a.mu.RUnlock()
+			if filepath.Clean(event.Name) == path && !event.Has(fsnotify.Chmod) {
+				settle = time.After(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			println("Error watching data file:", err.Error())
+		case <-settle:
+			settle = nil
+			a.reloadExternalChange()
+		}
+	}
+}
+
+// reloadExternalChange loads data.json if another program changed it. The
+// app's own version is backed up first, so edits made here aren't lost.
+func (a *App) reloadExternalChange() {
+	a.mu.Lock()
+	if _, ok := a.store.(*jsonStore); !ok || a.locked || !a.changedOnDiskLocked() {
+		a.mu.Unlock()
+		return
+	}
+
+	backupPath, err := a.preChangeBackupLocked("external-change")
+	if err != nil {
+		a.mu.Unlock()
+		println("Error backing up before reloading data:", err.Error())
+		return
+	}
+	decoded, quarantined, err := a.store.load()
+	if err != nil {
+		// Likely caught mid-write; the next change event retries
+		a.mu.Unlock()
+		println("Error reloading data:", err.Error())
+		return
+	}
+
+	a.clearUndoLocked()
+	a.data = decoded
+	if len(quarantined) > 0 {
+		a.quarantineLocked(quarantined)
+	}
+	a.migrateDataLocked()
+	a.recordChangesLocked("ExternalChange")
+	a.auditNow("ExternalChange", "", "", nil, backupPath)
+	a.mu.Unlock()
+
+	a.emitDataChanged("")
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, externalChangeEvent, ExternalChange{BackupPath: backupPath})
+	}
+	a.refreshMenu()
+}
+
+// notifyDataDirChanged points the watcher at a new data directory
+func (a *App) notifyDataDirChanged() {
+	select {
+	case a.dataDirChanged <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import "time"
+
+// WeekTaskDetail summarizes one task over a week for the week grid
+type WeekTaskDetail struct {
+	TaskID     string          `json:"taskId"`
+	Total      int             `json:"total"`      // Sum of recorded values
+	Done       int             `json:"done"`       // Days the task succeeded
+	Target     int             `json:"target"`     // Weekly quota, or applicable days for daily tasks
+	Progress   float64         `json:"progress"`   // Done/Target as a percentage, capped at 100
+	Applicable map[string]bool `json:"applicable"` // date -> whether the task applies that day
+}
+
+// WeekDetail is LoadWeek's raw values plus per-task progress
+type WeekDetail struct {
+	Days         map[string]map[string]int `json:"days"`
+	Tasks        []WeekTaskDetail          `json:"tasks"`
+	Frequency    []FrequencyProgress       `json:"frequency"`    // Progress of weekly-quota tasks toward their quota
+	ExcludedDays []string                  `json:"excludedDays"` // Vacation days left out of stats
+}
+
+// LoadWeekDetailed returns a week's values together with weekly totals,
+// target progress and per-day applicability for each task, and each
+// weekly-quota task's progress toward its quota
+func (a *App) LoadWeekDetailed(startDate string) WeekDetail {
+	defer a.lockWeek(startDate)()
+
+	t, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return WeekDetail{
+			Days:         make(map[string]map[string]int),
+			Tasks:        []WeekTaskDetail{},
+			Frequency:    []FrequencyProgress{},
+			ExcludedDays: []string{},
+		}
+	}
+	return a.weekDetailLocked(t)
+}
+
+// weekDetailLocked builds the week detail for the 7 days from t (must hold lock)
+func (a *App) weekDetailLocked(t time.Time) WeekDetail {
+	detail := WeekDetail{
+		Days:         a.loadWeekLocked(t),
+		Tasks:        []WeekTaskDetail{},
+		Frequency:    a.frequencyProgressLocked(t),
+		ExcludedDays: []string{},
+	}
+
+	dates := make([]string, 7)
+	for i := range dates {
+		dates[i] = t.AddDate(0, 0, i).Format("2006-01-02")
+		if a.dayExcludedLocked(dates[i]) {
+			detail.ExcludedDays = append(detail.ExcludedDays, dates[i])
+		}
+	}
+
+	// Every task that applies on at least one day of the week
+	tasks := []TaskTemplate{}
+	for _, task := range a.data.Templates {
+		for _, date := range dates {
+			if a.taskAppliesLocked(task, date) {
+				tasks = append(tasks, task)
+				break
+			}
+		}
+	}
+	a.sortTasksLocked(tasks)
+
+	for _, task := range tasks {
+		td := WeekTaskDetail{TaskID: task.ID, Applicable: make(map[string]bool)}
+		for _, date := range dates {
+			applies := a.taskAppliesLocked(task, date) && !a.dayExcludedLocked(date) && !a.taskSkippedLocked(date, task.ID)
+			td.Applicable[date] = applies
+			if !applies {
+				continue
+			}
+			if !isFrequencyTask(task) {
+				td.Target++
+			}
+			value := detail.Days[date][task.ID]
+			td.Total += value
+			if _, recorded := a.data.Days[date]; recorded && taskSucceeded(task, date, value) {
+				td.Done++
+			}
+		}
+
+		if isFrequencyTask(task) {
+			td.Target = task.TimesPerWeek
+		}
+		td.Progress = frequencyPercentage(FrequencyProgress{Done: td.Done, Quota: td.Target})
+		detail.Tasks = append(detail.Tasks, td)
+	}
+
+	return detail
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// HasPlannedWeek reports whether the week containing weekStart has
+// intentions, set in the review of the week before
+func (a *App) HasPlannedWeek(weekStart string) (bool, error) {
+	weekStart, err := canonicalWeekStart(weekStart)
+	if err != nil {
+		return false, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.hasPlannedWeekLocked(weekStart), nil
+}
+
+// hasPlannedWeekLocked is HasPlannedWeek for a canonical week start
+// (must hold lock)
+func (a *App) hasPlannedWeekLocked(weekStart string) bool {
+	start, _ := time.Parse("2006-01-02", weekStart)
+	reviewed := start.AddDate(0, 0, -7).Format("2006-01-02")
+	for _, r := range a.data.Reviews {
+		if r.WeekStart == reviewed && len(r.Intentions) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPlanReminderTime returns when on a week's last day to remind about
+// planning the next one; "" means never
+func (a *App) GetPlanReminderTime() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.data.PlanReminderTime
+}
+
+// SetPlanReminderTime sets the time of day ("15:04") on the last day of the
+// week at which to remind the user, if the next week has no intentions yet.
+// An empty time turns the reminder off.
+func (a *App) SetPlanReminderTime(reminderTime string) error {
+	if reminderTime != "" {
+		if _, err := time.Parse("15:04", reminderTime); err != nil {
+			return errors.New("invalid reminder time")
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.audit("SetPlanReminderTime", "", "", a.data.PlanReminderTime, reminderTime)
+	a.data.PlanReminderTime = reminderTime
+	return a.saveDataLocked()
+}
+
+// sendDuePlanReminder reminds the user to set intentions for next week
+// once the reminder time on this week's last day has passed. It fires at
+// most once a week.
+func (a *App) sendDuePlanReminder() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	if a.data.PlanReminderTime == "" || now.Format("15:04") < a.data.PlanReminderTime {
+		return
+	}
+	start := weekStartOf(now)
+	if now.Format("2006-01-02") != start.AddDate(0, 0, 6).Format("2006-01-02") {
+		return
+	}
+	next := start.AddDate(0, 0, 7)
+	nextWeek := next.Format("2006-01-02")
+	if nextWeek <= a.data.PlanReminderSent || a.hasPlannedWeekLocked(nextWeek) {
+		return
+	}
+
+	a.data.PlanReminderSent = nextWeek
+	a.notifyLocked("plan-week", "Plan next week", fmt.Sprintf("Set your intentions for the week of %s", next.Format("Jan 2")))
+	if err := a.saveDataLocked(); err != nil {
+		println("Error saving plan reminder:", err.Error())
+	}
+}
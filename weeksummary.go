@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WeekSummaryTargets are where week summaries go besides the notification
+// inbox. They're machine settings, so only one machine sends them.
+type WeekSummaryTargets struct {
+	Webhook string       `json:"webhook,omitempty"` // URL that receives the WeekDiff as JSON
+	Email   *EmailTarget `json:"email,omitempty"`
+}
+
+// weekSummaryEmailCredential is the credentials key of the week summary
+// email password
+const weekSummaryEmailCredential = "weekSummaryEmail"
+
+// EmailTarget is an SMTP account week summaries are mailed through
+type EmailTarget struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"` // Kept with the credentials, never in settings
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+// StreakChange is a task whose streak started or ended during a week
+type StreakChange struct {
+	TaskName string `json:"taskName"`
+	Days     int    `json:"days"` // Length of the new streak, or of the one that ended
+}
+
+// TaskChange is how a task's weekly progress moved from one week to the next
+type TaskChange struct {
+	TaskName string  `json:"taskName"`
+	Before   float64 `json:"before"`
+	After    float64 `json:"after"`
+}
+
+// WeekDiff compares a finished week with the week before it
+type WeekDiff struct {
+	WeekStart       string         `json:"weekStart"`
+	Average         float64        `json:"average"`
+	PreviousAverage float64        `json:"previousAverage"`
+	StreaksGained   []StreakChange `json:"streaksGained"`
+	StreaksLost     []StreakChange `json:"streaksLost"`
+	Improvement     *TaskChange    `json:"improvement,omitempty"` // Biggest gain in weekly progress
+	Regression      *TaskChange    `json:"regression,omitempty"`  // Biggest drop in weekly progress
+	Title           string         `json:"title"`
+	Text            string         `json:"text"` // The summary as plain text, as sent everywhere
+}
+
+// GetWeekSummaryTargets returns the webhook and email week summaries are sent to
+func (a *App) GetWeekSummaryTargets() WeekSummaryTargets {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.settings.WeekSummary == nil {
+		return WeekSummaryTargets{}
+	}
+	return *a.settings.WeekSummary.redacted()
+}
+
+// redacted returns a copy of the targets without the email password, for
+// settings, the UI and the audit log
+func (t *WeekSummaryTargets) redacted() *WeekSummaryTargets {
+	if t == nil {
+		return nil
+	}
+	targets := *t
+	if targets.Email != nil {
+		email := *targets.Email
+		email.Password = ""
+		targets.Email = &email
+	}
+	return &targets
+}
+
+// SetWeekSummaryTargets sets where week summaries are sent besides the
+// inbox. An empty webhook or nil email turns that target off; an empty
+// password keeps the saved one.
+func (a *App) SetWeekSummaryTargets(targets WeekSummaryTargets) error {
+	if targets.Webhook != "" {
+		u, err := url.Parse(targets.Webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("webhook must be an http or https URL")
+		}
+	}
+	if e := targets.Email; e != nil {
+		if e.Host == "" || e.From == "" || e.To == "" {
+			return errors.New("email needs a server, a sender and a recipient")
+		}
+		if e.Port < 1 || e.Port > 65535 {
+			return errors.New("email port must be between 1 and 65535")
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if targets.Email == nil {
+		if err := a.setCredentialLocked(weekSummaryEmailCredential, ""); err != nil {
+			return err
+		}
+	} else if targets.Email.Password != "" {
+		if err := a.setCredentialLocked(weekSummaryEmailCredential, targets.Email.Password); err != nil {
+			return err
+		}
+	}
+	old := a.settings.WeekSummary
+	if targets.Webhook == "" && targets.Email == nil {
+		a.settings.WeekSummary = nil
+	} else {
+		a.settings.WeekSummary = targets.redacted()
+	}
+	a.audit("SetWeekSummaryTargets", "", "", old, a.settings.WeekSummary)
+	return a.saveSettingsLocked()
+}
+
+// GetWeekDiff compares the week starting weekStart with the week before
+func (a *App) GetWeekDiff(weekStart string) (WeekDiff, error) {
+	weekStart, err := canonicalWeekStart(weekStart)
+	if err != nil {
+		return WeekDiff{}, err
+	}
+
+	// The diff compares with the week before, which lockWeek's padding covers
+	defer a.lockWeek(weekStart)()
+	return a.weekDiffLocked(weekStart), nil
+}
+
+// weekDiffLocked builds the week summary shared by the inbox, email and
+// webhook (must hold lock)
+func (a *App) weekDiffLocked(weekStart string) WeekDiff {
+	start, _ := time.Parse("2006-01-02", weekStart)
+	prev := start.AddDate(0, 0, -7)
+	end := start.AddDate(0, 0, 6).Format("2006-01-02")
+	prevEnd := start.AddDate(0, 0, -1).Format("2006-01-02")
+
+	diff := WeekDiff{WeekStart: weekStart, StreaksGained: []StreakChange{}, StreaksLost: []StreakChange{}}
+	_, diff.Average, _ = a.weeklyAverageLocked(start)
+	_, diff.PreviousAverage, _ = a.weeklyAverageLocked(prev)
+
+	for _, task := range a.data.Templates {
+		if !taskActiveOn(task, end) || isValueTask(task) || isScaleTask(task) {
+			continue
+		}
+		after, first := a.streakSpanLocked(task, end)
+		before := a.taskStreakLocked(task, prevEnd)
+		if before > 0 && after < before {
+			diff.StreaksLost = append(diff.StreaksLost, StreakChange{TaskName: task.Name, Days: before})
+		}
+		if after > 0 && first >= weekStart {
+			diff.StreaksGained = append(diff.StreaksGained, StreakChange{TaskName: task.Name, Days: after})
+		}
+	}
+
+	progress := func(t time.Time) map[string]float64 {
+		result := make(map[string]float64)
+		for _, td := range a.weekDetailLocked(t).Tasks {
+			if td.Target > 0 {
+				result[td.TaskID] = td.Progress
+			}
+		}
+		return result
+	}
+	now, then := progress(start), progress(prev)
+	for id, after := range now {
+		before, ok := then[id]
+		task, found := a.findTemplateLocked(id)
+		if !ok || !found {
+			continue
+		}
+		change := &TaskChange{TaskName: task.Name, Before: before, After: after}
+		if after > before && (diff.Improvement == nil || after-before > diff.Improvement.After-diff.Improvement.Before) {
+			diff.Improvement = change
+		}
+		if after < before && (diff.Regression == nil || after-before < diff.Regression.After-diff.Regression.Before) {
+			diff.Regression = change
+		}
+	}
+
+	diff.Title, diff.Text = diff.summary()
+	return diff
+}
+
+// summary writes the diff as a title and plain text body
+func (d WeekDiff) summary() (title, text string) {
+	start, _ := time.Parse("2006-01-02", d.WeekStart)
+	title = fmt.Sprintf("Week of %s: %.0f%%", start.Format("Jan 2"), d.Average)
+
+	var b strings.Builder
+	change := d.Average - d.PreviousAverage
+	switch {
+	case change >= 0.5:
+		fmt.Fprintf(&b, "Up %.0f points from %.0f%% the week before.\n", change, d.PreviousAverage)
+	case change <= -0.5:
+		fmt.Fprintf(&b, "Down %.0f points from %.0f%% the week before.\n", -change, d.PreviousAverage)
+	default:
+		fmt.Fprintf(&b, "Level with the week before (%.0f%%).\n", d.PreviousAverage)
+	}
+	for _, s := range d.StreaksGained {
+		fmt.Fprintf(&b, "New streak: %s (%d days)\n", s.TaskName, s.Days)
+	}
+	for _, s := range d.StreaksLost {
+		fmt.Fprintf(&b, "Streak ended: %s (was %d days)\n", s.TaskName, s.Days)
+	}
+	if c := d.Improvement; c != nil {
+		fmt.Fprintf(&b, "Biggest improvement: %s, %.0f%% → %.0f%%\n", c.TaskName, c.Before, c.After)
+	}
+	if c := d.Regression; c != nil {
+		fmt.Fprintf(&b, "Biggest drop: %s, %.0f%% → %.0f%%\n", c.TaskName, c.Before, c.After)
+	}
+	return title, strings.TrimRight(b.String(), "\n")
+}
+
+// sendWeekSummaryLocked puts a finished week's summary in the inbox and
+// sends it to the configured targets, once per week. Sending happens in
+// the background (must hold lock; caller saves).
+func (a *App) sendWeekSummaryLocked(weekStart string) {
+	if weekStart <= a.data.WeekSummarySent {
+		return
+	}
+	a.data.WeekSummarySent = weekStart
+
+	diff := a.weekDiffLocked(weekStart)
+	a.notifyLocked("week-summary", diff.Title, diff.Text)
+
+	if targets := a.settings.WeekSummary.redacted(); targets != nil {
+		if targets.Email != nil {
+			targets.Email.Password = a.credentialLocked(weekSummaryEmailCredential)
+		}
+		go a.deliverWeekSummary(*targets, diff)
+	}
+}
+
+// moveWeekSummaryPasswordLocked moves the email password older versions
+// kept in settings into the credentials, reporting whether settings need
+// saving (must hold lock)
+func (a *App) moveWeekSummaryPasswordLocked() bool {
+	targets := a.settings.WeekSummary
+	if targets == nil || targets.Email == nil || targets.Email.Password == "" {
+		return false
+	}
+	if err := a.setCredentialLocked(weekSummaryEmailCredential, targets.Email.Password); err != nil {
+		println("Error moving email password:", err.Error())
+		return false
+	}
+	a.settings.WeekSummary = targets.redacted()
+	return true
+}
+
+// sendDueWeekSummary summarizes last week once it's over, if finishing a
+// weekly review hasn't already
+func (a *App) sendDueWeekSummary() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	lastWeek := weekStartOf(time.Now()).AddDate(0, 0, -7).Format("2006-01-02")
+	if lastWeek <= a.data.WeekSummarySent || len(a.data.Days) == 0 {
+		return
+	}
+	a.sendWeekSummaryLocked(lastWeek)
+	a.saveDataLocked()
+}
+
+// deliverWeekSummary posts the summary to the webhook and mails it,
+// noting failures in the inbox
+func (a *App) deliverWeekSummary(targets WeekSummaryTargets, diff WeekDiff) {
+	failures := []string{}
+	if targets.Webhook != "" {
+		if err := postWeekSummary(targets.Webhook, diff); err != nil {
+			failures = append(failures, "webhook: "+err.Error())
+		}
+	}
+	if targets.Email != nil {
+		if err := mailWeekSummary(*targets.Email, diff); err != nil {
+			failures = append(failures, "email: "+err.Error())
+		}
+	}
+	if len(failures) == 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.notifyLocked("week-summary", "Week summary couldn't be sent", strings.Join(failures, "\n"))
+	a.saveDataLocked()
+}
+
+// postWeekSummary sends the diff as JSON to a webhook
+func postWeekSummary(webhook string, diff WeekDiff) error {
+	body, err := json.Marshal(diff)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Post(webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("server answered %s", resp.Status)
+	}
+	return nil
+}
+
+// mailWeekSummary sends the summary as a plain text email
+func mailWeekSummary(e EmailTarget, diff WeekDiff) error {
+	var auth smtp.Auth
+	if e.Username != "" {
+		auth = smtp.PlainAuth("", e.Username, e.Password, e.Host)
+	}
+	message := "From: " + e.From + "\r\n" +
+		"To: " + e.To + "\r\n" +
+		"Subject: " + diff.Title + "\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n\r\n" +
+		strings.ReplaceAll(diff.Text, "\n", "\r\n") + "\r\n"
+	return smtp.SendMail(e.Host+":"+strconv.Itoa(e.Port), auth, e.From, []string{e.To}, []byte(message))
+}
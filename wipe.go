@@ -0,0 +1,237 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// WipePreview reports what a destructive cleanup removes. With dryRun the
+// data is untouched; otherwise BackupPath names the copy taken beforehand.
+type WipePreview struct {
+	Days         int    `json:"days"`         // Dates losing recorded values
+	Values       int    `json:"values"`       // Recorded task values
+	Measurements int    `json:"measurements"` // Value task readings
+	Skips        int    `json:"skips"`
+	Sessions     int    `json:"sessions"` // Focus sessions
+	Tasks        int    `json:"tasks"`    // Task templates (factory reset only)
+	BackupPath   string `json:"backupPath,omitempty"`
+	ConfirmToken string `json:"confirmToken,omitempty"` // Pass to FactoryReset to go ahead
+}
+
+// wipeLocked removes recorded data on dates within a range, for one task
+// or for all when taskID is "". With dryRun it only counts (must hold lock;
+// caller saves).
+func (a *App) wipeLocked(r DateRange, taskID string, dryRun bool) WipePreview {
+	preview := WipePreview{}
+
+	for date, dayTasks := range a.data.Days {
+		if !r.contains(date) {
+			continue
+		}
+		removed := 0
+		for id := range dayTasks {
+			if taskID == "" || id == taskID {
+				removed++
+				if !dryRun {
+					delete(dayTasks, id)
+				}
+			}
+		}
+		if removed > 0 {
+			preview.Days++
+			preview.Values += removed
+		}
+		if !dryRun && len(dayTasks) == 0 {
+			delete(a.data.Days, date)
+		}
+	}
+
+	for date, subitems := range a.data.SubitemDays {
+		if !r.contains(date) || dryRun {
+			continue
+		}
+		for id := range subitems {
+			if taskID == "" || id == taskID {
+				delete(subitems, id)
+			}
+		}
+		if len(subitems) == 0 {
+			delete(a.data.SubitemDays, date)
+		}
+	}
+
+	for date, values := range a.data.Measurements {
+		if !r.contains(date) {
+			continue
+		}
+		for id := range values {
+			if taskID == "" || id == taskID {
+				preview.Measurements++
+				if !dryRun {
+					delete(values, id)
+				}
+			}
+		}
+		if !dryRun && len(values) == 0 {
+			delete(a.data.Measurements, date)
+		}
+	}
+
+	for date, skipped := range a.data.Skipped {
+		if !r.contains(date) {
+			continue
+		}
+		for id := range skipped {
+			if taskID == "" || id == taskID {
+				preview.Skips++
+				if !dryRun {
+					delete(skipped, id)
+				}
+			}
+		}
+		if !dryRun && len(skipped) == 0 {
+			delete(a.data.Skipped, date)
+		}
+	}
+
+	if !dryRun && taskID == "" {
+		for date := range a.data.SkippedDays {
+			if r.contains(date) {
+				delete(a.data.SkippedDays, date)
+			}
+		}
+	}
+
+	sessions := []FocusSession{}
+	for _, session := range a.data.FocusSessions {
+		if r.contains(session.Date) && (taskID == "" || session.TaskID == taskID) {
+			preview.Sessions++
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	if !dryRun {
+		a.data.FocusSessions = sessions
+	}
+
+	return preview
+}
+
+// DeleteDataRange removes every task's recorded data between two dates
+// (inclusive). With dryRun it only reports what would be removed; otherwise
+// a backup is written first and the deletion is skipped if that fails.
+func (a *App) DeleteDataRange(start string, end string, dryRun bool) (WipePreview, error) {
+	r, err := newDateRange(start, end)
+	if err != nil {
+		return WipePreview{}, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.deleteHistoryLocked("DeleteDataRange", r, "", dryRun)
+}
+
+// DeleteTaskHistory removes one task's recorded data between two dates
+// (inclusive) while keeping the task. dryRun and backups work as in
+// DeleteDataRange.
+func (a *App) DeleteTaskHistory(id string, start string, end string, dryRun bool) (WipePreview, error) {
+	r, err := newDateRange(start, end)
+	if err != nil {
+		return WipePreview{}, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.findTemplateLocked(id); !ok {
+		return WipePreview{}, errors.New("task not found")
+	}
+	return a.deleteHistoryLocked("DeleteTaskHistory", r, id, dryRun)
+}
+
+// deleteHistoryLocked backs up and then wipes a date range (must hold lock)
+func (a *App) deleteHistoryLocked(method string, r DateRange, taskID string, dryRun bool) (WipePreview, error) {
+	if dryRun {
+		loaded := a.loadArchivedDaysLocked(r.From, r.To)
+		defer a.unloadArchivedDaysLocked(loaded)
+		return a.wipeLocked(r, taskID, true), nil
+	}
+
+	// Archived years in the range come back into data.json, so the backup
+	// has them and the wipe reaches them
+	years, err := a.unarchiveYearsLocked(r.From, r.To)
+	if err != nil {
+		return WipePreview{}, err
+	}
+	preview := a.wipeLocked(r, taskID, true)
+	backupPath, err := a.preChangeBackupLocked("delete")
+	if err != nil {
+		return preview, err
+	}
+	preview.BackupPath = backupPath
+
+	a.rememberLocked(method)
+	a.wipeLocked(r, taskID, false)
+	a.rebuildRecordsLocked()
+	a.audit(method, r.From+".."+r.To, taskID, preview, nil)
+	if err := a.saveDataLocked(); err != nil {
+		return preview, err
+	}
+	a.removeYearArchivesLocked(years)
+	return preview, nil
+}
+
+// FactoryReset erases all planner data and starts over with the default
+// tasks. Local settings such as the export folder are kept. Call it with
+// an empty token to get a preview and a confirmation token, then again
+// with that token to reset. A backup is always written first.
+func (a *App) FactoryReset(confirmToken string) (WipePreview, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	loaded := a.loadArchivedDaysLocked("", "")
+	preview := a.wipeLocked(DateRange{From: "0000-00-00", To: "9999-99-99"}, "", true)
+	a.unloadArchivedDaysLocked(loaded)
+	for _, t := range a.data.Templates {
+		if t.DeletedAt == nil {
+			preview.Tasks++
+		}
+	}
+
+	if confirmToken == "" || confirmToken != a.resetToken {
+		a.resetToken = uuid.New().String()
+		preview.ConfirmToken = a.resetToken
+		if confirmToken != "" {
+			return preview, errors.New("confirmation token is invalid or expired")
+		}
+		return preview, nil
+	}
+	a.resetToken = ""
+
+	years, err := a.unarchiveYearsLocked("", "")
+	if err != nil {
+		return preview, err
+	}
+	backupPath, err := a.preChangeBackupLocked("reset")
+	if err != nil {
+		return preview, err
+	}
+	preview.BackupPath = backupPath
+
+	a.audit("FactoryReset", "", "", preview, nil)
+	a.data = PlannerData{
+		Templates:     []TaskTemplate{},
+		Days:          make(map[string]DayTasks),
+		ExportHistory: make(map[string]string),
+	}
+	a.applyPresetLocked(defaultPresetID)
+	a.data.SeenChangesVersion = latestChangeVersion()
+
+	if err := a.saveDataLocked(); err != nil {
+		return preview, err
+	}
+	a.removeYearArchivesLocked(years)
+	return preview, nil
+}
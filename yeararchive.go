@@ -0,0 +1,490 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// yearArchive is a year of day values moved out of data.json, with their
+// stamps so moving them doesn't look like a deletion to other devices
+type yearArchive struct {
+	Year   int                              `json:"year"`
+	Days   map[string]DayTasks              `json:"days"`
+	Stamps map[string]map[string]ValueStamp `json:"stamps,omitempty"`
+}
+
+// yearArchivePath returns where a year's archive is kept, next to data.json
+func (a *App) yearArchivePath(year int) string {
+	return filepath.Join(filepath.Dir(a.dataPath), fmt.Sprintf("days-%d.json", year))
+}
+
+// GetArchivedYears returns the years whose days were moved out of
+// data.json, oldest first
+func (a *App) GetArchivedYears() []int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	years := append([]int{}, a.data.ArchivedYears...)
+	sort.Ints(years)
+	return years
+}
+
+// UnarchiveYears moves every archived year back into data.json and turns
+// year archiving off, so the next maintenance run doesn't move them again
+func (a *App) UnarchiveYears() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.data.ArchivedYears) == 0 {
+		return nil
+	}
+	years, err := a.unarchiveYearsLocked("", "")
+	if err != nil {
+		return err
+	}
+	if a.data.Retention != nil {
+		a.data.Retention.ArchiveYears = 0
+	}
+	a.audit("UnarchiveYears", "", "", years, nil)
+	if err := a.saveDataLocked(); err != nil {
+		return err
+	}
+	a.removeYearArchivesLocked(years)
+	return nil
+}
+
+// migrateYearArchivesLocked changes nothing: data.json may now leave old
+// years to days-YYYY.json, and bumping the version keeps builds that don't
+// know about ArchivedYears from saving over it and losing them (must hold
+// lock)
+func (a *App) migrateYearArchivesLocked() error {
+	return nil
+}
+
+// archivePaddingDays widens the range lockDays loads, for scores that look
+// at the days around the ones asked for, such as streaks and weekly quotas
+const archivePaddingDays = 7
+
+// lockDays takes the read lock for a query of dates from `from` to `to`
+// ("" for no bound) and returns the function that releases it. When the
+// query reaches into archived years it takes the write lock instead, and
+// those years are in Days until it's released, so report code reads them
+// like any other day.
+func (a *App) lockDays(from, to string) (unlock func()) {
+	if start, err := time.Parse("2006-01-02", from); err == nil {
+		from = start.AddDate(0, 0, -archivePaddingDays).Format("2006-01-02")
+	}
+	if end, err := time.Parse("2006-01-02", to); err == nil {
+		to = end.AddDate(0, 0, archivePaddingDays).Format("2006-01-02")
+	}
+
+	a.mu.RLock()
+	if len(a.archivedYearsLocked(from, to)) == 0 {
+		return a.mu.RUnlock
+	}
+	a.mu.RUnlock()
+
+	a.mu.Lock()
+	loaded := a.loadArchivedDaysLocked(from, to)
+	return func() {
+		a.unloadArchivedDaysLocked(loaded)
+		a.mu.Unlock()
+	}
+}
+
+// lockWeek is lockDays for the week starting startDate
+func (a *App) lockWeek(startDate string) (unlock func()) {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return a.lockDays(startDate, startDate)
+	}
+	return a.lockDays(startDate, start.AddDate(0, 0, 6).Format("2006-01-02"))
+}
+
+// archivedYearsLocked returns the archived years overlapping a range
+// (must hold lock)
+func (a *App) archivedYearsLocked(from, to string) []int {
+	years := []int{}
+	for _, year := range a.data.ArchivedYears {
+		first, last := fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year)
+		if (from == "" || last >= from) && (to == "" || first <= to) {
+			years = append(years, year)
+		}
+	}
+	return years
+}
+
+// loadArchivedDaysLocked adds the days of archived years overlapping a
+// range to Days and returns the dates added. Dates already in Days were
+// edited after archiving and win; writers pull the whole day back first
+// with pullArchivedDayLocked, so they're complete (must hold write lock).
+func (a *App) loadArchivedDaysLocked(from, to string) []string {
+	loaded := []string{}
+	for _, year := range a.archivedYearsLocked(from, to) {
+		archive, err := a.yearArchiveLocked(year)
+		if err != nil {
+			println("Error loading archived year:", err.Error())
+			continue
+		}
+		if a.data.Days == nil {
+			a.data.Days = make(map[string]DayTasks)
+		}
+		for date, tasks := range archive.Days {
+			if _, edited := a.data.Days[date]; !edited {
+				a.data.Days[date] = tasks
+				loaded = append(loaded, date)
+			}
+		}
+	}
+	return loaded
+}
+
+// unloadArchivedDaysLocked removes days added by loadArchivedDaysLocked
+// (must hold write lock)
+func (a *App) unloadArchivedDaysLocked(loaded []string) {
+	for _, date := range loaded {
+		delete(a.data.Days, date)
+	}
+}
+
+// pullArchivedDayLocked moves an archived day back into Days, with its
+// stamps, before some of its values are written. Days already in Days win
+// over the archive, so writing one value to a day left in the archive would
+// hide the rest of it (must hold write lock).
+func (a *App) pullArchivedDayLocked(date string) error {
+	if _, ok := a.data.Days[date]; ok || len(date) != len("2006-01-02") {
+		return nil
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil || !containsYear(a.data.ArchivedYears, year) {
+		return nil
+	}
+	archive, err := a.yearArchiveLocked(year)
+	if err != nil {
+		return err
+	}
+	tasks, ok := archive.Days[date]
+	if !ok {
+		return nil
+	}
+
+	if a.data.Days == nil {
+		a.data.Days = make(map[string]DayTasks)
+	}
+	a.data.Days[date] = copyDayTasks(tasks)
+	if stamps, ok := archive.Stamps[date]; ok {
+		if a.data.Stamps == nil {
+			a.data.Stamps = make(map[string]map[string]ValueStamp)
+		}
+		if _, ok := a.data.Stamps[date]; !ok {
+			a.data.Stamps[date] = maps.Clone(stamps)
+		}
+	}
+	// Moving the day isn't a change to it
+	if a.savedDays != nil {
+		a.savedDays[date] = copyDayTasks(tasks)
+	}
+	return nil
+}
+
+// pullArchivedTaskDaysLocked moves the archived days with a value for a
+// task back into Days, for changes to all of a task's history (must hold
+// write lock)
+func (a *App) pullArchivedTaskDaysLocked(taskID string) error {
+	for _, year := range a.data.ArchivedYears {
+		archive, err := a.yearArchiveLocked(year)
+		if err != nil {
+			return err
+		}
+		for date, tasks := range archive.Days {
+			if _, ok := tasks[taskID]; ok {
+				if err := a.pullArchivedDayLocked(date); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// withArchivedDaysLocked returns a copy of the data with the archived
+// years' days and stamps back in Days, for copies kept away from the
+// archive files, such as the secondary backup (must hold lock)
+func (a *App) withArchivedDaysLocked() (PlannerData, error) {
+	data := a.data
+	if len(data.ArchivedYears) == 0 {
+		return data, nil
+	}
+	data.Days = maps.Clone(a.data.Days)
+	data.Stamps = maps.Clone(a.data.Stamps)
+	if data.Days == nil {
+		data.Days = make(map[string]DayTasks)
+	}
+	if data.Stamps == nil {
+		data.Stamps = make(map[string]map[string]ValueStamp)
+	}
+	for _, year := range a.data.ArchivedYears {
+		archive, err := a.yearArchiveLocked(year)
+		if err != nil {
+			return PlannerData{}, err
+		}
+		for date, tasks := range archive.Days {
+			if _, edited := data.Days[date]; edited {
+				continue
+			}
+			data.Days[date] = tasks
+			if stamps, ok := archive.Stamps[date]; ok {
+				data.Stamps[date] = stamps
+			}
+		}
+	}
+	data.ArchivedYears = nil
+	return data, nil
+}
+
+// archivedDaysLocked returns the archived days not also in Days, for code
+// that reads all history under the read lock (must hold lock)
+func (a *App) archivedDaysLocked() map[string]DayTasks {
+	days := make(map[string]DayTasks)
+	for _, year := range a.data.ArchivedYears {
+		archive, err := a.yearArchiveLocked(year)
+		if err != nil {
+			continue
+		}
+		for date, tasks := range archive.Days {
+			if _, edited := a.data.Days[date]; !edited {
+				days[date] = tasks
+			}
+		}
+	}
+	return days
+}
+
+// yearArchiveLocked reads a year's archive, keeping it in memory for later
+// queries. A missing file is an error rather than an empty year, so the
+// year's history isn't silently dropped (must hold lock).
+func (a *App) yearArchiveLocked(year int) (*yearArchive, error) {
+	a.archiveMu.Lock()
+	defer a.archiveMu.Unlock()
+
+	if archive, ok := a.yearArchives[year]; ok {
+		return archive, nil
+	}
+	archive := &yearArchive{Year: year, Days: make(map[string]DayTasks)}
+	content, err := os.ReadFile(a.yearArchivePath(year))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("days-%d.json is missing; copy it next to the data file", year)
+	}
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := a.openLocked(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(plaintext, archive); err != nil {
+		return nil, fmt.Errorf("days-%d.json is unreadable: %v", year, err)
+	}
+	if a.yearArchives == nil {
+		a.yearArchives = make(map[int]*yearArchive)
+	}
+	a.yearArchives[year] = archive
+	return archive, nil
+}
+
+// writeYearArchiveLocked saves a year's archive, encrypted when the data is
+// (must hold lock)
+func (a *App) writeYearArchiveLocked(archive *yearArchive) error {
+	encoded, err := json.Marshal(archive)
+	if err == nil {
+		encoded, err = a.sealLocked(encoded)
+	}
+	if err != nil {
+		return err
+	}
+	if err := a.atomicWriteFile(a.yearArchivePath(archive.Year), encoded); err != nil {
+		return err
+	}
+
+	a.archiveMu.Lock()
+	if a.yearArchives == nil {
+		a.yearArchives = make(map[int]*yearArchive)
+	}
+	a.yearArchives[archive.Year] = archive
+	a.archiveMu.Unlock()
+	return nil
+}
+
+// readYearArchivesLocked reads every archive into memory, before the key
+// they were written with changes (must hold lock)
+func (a *App) readYearArchivesLocked() error {
+	for _, year := range a.data.ArchivedYears {
+		if _, err := a.yearArchiveLocked(year); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteYearArchivesLocked saves every archive again with the current
+// key, after encryption is turned on or off. The archives must have been
+// read with readYearArchivesLocked first (must hold lock).
+func (a *App) rewriteYearArchivesLocked() error {
+	for _, year := range a.data.ArchivedYears {
+		archive, err := a.yearArchiveLocked(year)
+		if err != nil {
+			return err
+		}
+		if err := a.writeYearArchiveLocked(archive); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forgetYearArchives drops archives kept in memory, when the data they
+// belong to is replaced or locked
+func (a *App) forgetYearArchives() {
+	a.archiveMu.Lock()
+	a.yearArchives = nil
+	a.archiveMu.Unlock()
+}
+
+// archiveOldYearsLocked moves the days of years more than keepYears before
+// now's year into per-year files, merging with earlier archives. The files
+// are written before the days leave Days, so nothing is lost if the app
+// stops in between. Returns the number of days moved (must hold lock;
+// caller saves).
+func (a *App) archiveOldYearsLocked(nowYear int, keepYears int) (int, error) {
+	if keepYears <= 0 {
+		return 0, nil
+	}
+	cutoff := fmt.Sprintf("%04d-01-01", nowYear-keepYears)
+
+	byYear := make(map[int][]string)
+	for date := range a.data.Days {
+		if date >= cutoff {
+			continue
+		}
+		year, err := strconv.Atoi(date[:4])
+		if err != nil || len(date) != len("2006-01-02") {
+			continue
+		}
+		byYear[year] = append(byYear[year], date)
+	}
+
+	moved := 0
+	for year, dates := range byYear {
+		archive := &yearArchive{Year: year, Days: make(map[string]DayTasks)}
+		if containsYear(a.data.ArchivedYears, year) {
+			existing, err := a.yearArchiveLocked(year)
+			if err != nil {
+				return moved, err
+			}
+			for date, tasks := range existing.Days {
+				archive.Days[date] = tasks
+			}
+			for date, stamps := range existing.Stamps {
+				if archive.Stamps == nil {
+					archive.Stamps = make(map[string]map[string]ValueStamp)
+				}
+				archive.Stamps[date] = stamps
+			}
+		}
+		for _, date := range dates {
+			archive.Days[date] = a.data.Days[date]
+			if stamps, ok := a.data.Stamps[date]; ok {
+				if archive.Stamps == nil {
+					archive.Stamps = make(map[string]map[string]ValueStamp)
+				}
+				archive.Stamps[date] = stamps
+			}
+		}
+		if err := a.writeYearArchiveLocked(archive); err != nil {
+			return moved, err
+		}
+
+		for _, date := range dates {
+			delete(a.data.Days, date)
+			delete(a.data.Stamps, date)
+			delete(a.savedDays, date)
+		}
+		if !containsYear(a.data.ArchivedYears, year) {
+			a.data.ArchivedYears = append(a.data.ArchivedYears, year)
+		}
+		moved += len(dates)
+	}
+	sort.Ints(a.data.ArchivedYears)
+	return moved, nil
+}
+
+// unarchiveYearsLocked moves archived years overlapping a range back into
+// Days and returns them. Their files are left until the data is saved;
+// see removeYearArchivesLocked (must hold lock).
+func (a *App) unarchiveYearsLocked(from, to string) ([]int, error) {
+	years := a.archivedYearsLocked(from, to)
+	for _, year := range years {
+		archive, err := a.yearArchiveLocked(year)
+		if err != nil {
+			return nil, err
+		}
+		if a.data.Days == nil {
+			a.data.Days = make(map[string]DayTasks)
+		}
+		if a.data.Stamps == nil {
+			a.data.Stamps = make(map[string]map[string]ValueStamp)
+		}
+		for date, tasks := range archive.Days {
+			if _, edited := a.data.Days[date]; edited {
+				continue
+			}
+			a.data.Days[date] = copyDayTasks(tasks)
+			if a.savedDays != nil {
+				a.savedDays[date] = copyDayTasks(tasks)
+			}
+			if stamps, ok := archive.Stamps[date]; ok {
+				a.data.Stamps[date] = stamps
+			}
+		}
+	}
+
+	kept := a.data.ArchivedYears[:0]
+	for _, year := range a.data.ArchivedYears {
+		if !containsYear(years, year) {
+			kept = append(kept, year)
+		}
+	}
+	a.data.ArchivedYears = kept
+	return years, nil
+}
+
+// removeYearArchivesLocked deletes the files of years moved back into
+// data.json, once it has been saved (must hold lock)
+func (a *App) removeYearArchivesLocked(years []int) {
+	a.archiveMu.Lock()
+	defer a.archiveMu.Unlock()
+
+	for _, year := range years {
+		delete(a.yearArchives, year)
+		if err := os.Remove(a.yearArchivePath(year)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			println("Error removing archived year:", err.Error())
+		}
+	}
+}
+
+// containsYear reports whether years includes year
+func containsYear(years []int, year int) bool {
+	for _, y := range years {
+		if y == year {
+			return true
+		}
+	}
+	return false
+}